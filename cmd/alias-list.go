@@ -121,13 +121,15 @@ func (d byAlias) Less(i, j int) bool { return d[i].Alias < d[j].Alias }
 
 func buildAliasMessage(alias string, deprecated bool, aliasCfg *aliasConfigV10) aliasMessage {
 	aliasMsg := aliasMessage{
-		prettyPrint: false,
-		Alias:       alias,
-		URL:         aliasCfg.URL,
-		AccessKey:   aliasCfg.AccessKey,
-		SecretKey:   aliasCfg.SecretKey,
-		API:         aliasCfg.API,
-		Src:         aliasCfg.Src,
+		prettyPrint:  false,
+		Alias:        alias,
+		URL:          aliasCfg.URL,
+		AccessKey:    aliasCfg.AccessKey,
+		SecretKey:    aliasCfg.SecretKey,
+		API:          aliasCfg.API,
+		Src:          aliasCfg.Src,
+		RequestLimit: aliasCfg.RequestLimit,
+		RetryPolicy:  aliasCfg.RetryPolicy,
 	}
 
 	if deprecated {
@@ -74,6 +74,10 @@ type aliasMessage struct {
 	API         string `json:"api,omitempty"`
 	Path        string `json:"path,omitempty"`
 	Src         string `json:"src,omitempty"`
+	// Requests per second, see --limit-requests
+	RequestLimit string `json:"requestLimit,omitempty"`
+	// Retry policy, see --retry-policy
+	RetryPolicy string `json:"retryPolicy,omitempty"`
 	// Deprecated field, replaced by Path
 	Lookup string `json:"lookup,omitempty"`
 }
@@ -85,21 +89,30 @@ func (h aliasMessage) String() string {
 	switch h.op {
 	case "list":
 		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Path", "Path"},
-			Row{"Src", "Src"},
-		)
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Path", "Path"},
+			{"Src", "Src"},
+		}
 		// Handle deprecated lookup
 		path := h.Path
 		if path == "" {
 			path = h.Lookup
 		}
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path, h.Src)
+		contents := []string{h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path, h.Src}
+		if h.RequestLimit != "" {
+			rows = append(rows, Row{"RequestLimit", "RequestLimit"})
+			contents = append(contents, h.RequestLimit)
+		}
+		if h.RetryPolicy != "" {
+			rows = append(rows, Row{"RetryPolicy", "RetryPolicy"})
+			contents = append(contents, h.RetryPolicy)
+		}
+		return newPrettyRecord(2, rows...).buildRecord(contents...)
 	case "remove":
 		return console.Colorize("AliasMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add": // add is deprecated
@@ -95,6 +95,16 @@ EXAMPLES:
      {{.Prompt}} echo -e "BKIKJAA5BMMU2RHO6IBB\nV8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12" | \
                  {{.HelpName}} mys3 https://s3.amazonaws.com --api "s3v4" --path "off"
      {{.EnableHistory}}
+  6. Add MinIO service under "myminio" alias, limited to 100 requests per second of which at most 20 deletes.
+     For security reasons turn off bash history momentarily.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} myminio http://localhost:9000 minio minio123 --limit-requests "100,delete=20"
+     {{.EnableHistory}}
+  7. Add MinIO service under "myminio" alias, retrying transient errors up to 10 times within 10 minutes.
+     For security reasons turn off bash history momentarily.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} myminio http://localhost:9000 minio minio123 --retry-policy "attempts=10,max-elapsed=10m"
+     {{.EnableHistory}}
 `,
 }
 
@@ -172,6 +182,9 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 		SecretKey: aliasCfgV10.SecretKey,
 		API:       aliasCfgV10.API,
 		Path:      aliasCfgV10.Path,
+
+		RequestLimit: aliasCfgV10.RequestLimit,
+		RetryPolicy:  aliasCfgV10.RetryPolicy,
 	}
 }
 
@@ -189,8 +202,8 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string, pee
 		Debug:             globalDebug,
 		ConnReadDeadline:  globalConnReadDeadline,
 		ConnWriteDeadline: globalConnWriteDeadline,
-		UploadLimit:       int64(globalLimitUpload),
-		DownloadLimit:     int64(globalLimitDownload),
+		UploadLimit:       globalLimitUpload,
+		DownloadLimit:     globalLimitDownload,
 	}
 	if peerCert != nil {
 		configurePeerCertificate(s3Config, peerCert)
@@ -341,12 +354,24 @@ func mainAliasSet(cli *cli.Context, deprecated bool) error {
 	s3Config, err := BuildS3Config(ctx, alias, url, accessKey, secretKey, api, path, peerCert)
 	fatalIf(err.Trace(alias, url, accessKey), "Unable to initialize new alias from the provided credentials.")
 
+	// The request limit and retry policy given to 'alias set'
+	// are kept in the config.
+	var requestLimit, retryPolicy string
+	if globalLimitRequests != nil {
+		requestLimit = globalLimitRequests.String()
+	}
+	if globalRetryPolicy != nil {
+		retryPolicy = globalRetryPolicy.String()
+	}
+
 	msg := setAlias(alias, aliasConfigV10{
-		URL:       s3Config.HostURL,
-		AccessKey: s3Config.AccessKey,
-		SecretKey: s3Config.SecretKey,
-		API:       s3Config.Signature,
-		Path:      path,
+		URL:          s3Config.HostURL,
+		AccessKey:    s3Config.AccessKey,
+		SecretKey:    s3Config.SecretKey,
+		API:          s3Config.Signature,
+		Path:         path,
+		RequestLimit: requestLimit,
+		RetryPolicy:  retryPolicy,
 	}) // Add an alias with specified credentials.
 
 	msg.op = "set"
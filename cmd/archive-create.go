@@ -0,0 +1,412 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var archiveCreateFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "include",
+		Usage: "only archive the objects matching a pattern, such as '*.csv'",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "do not archive the objects matching a pattern, such as 'tmp/'",
+	},
+	filterFromFlag,
+	cli.StringFlag{
+		Name:  "add-manifest",
+		Usage: "add an entry with this name listing the archived objects as CSV",
+	},
+}
+
+var archiveCreateCmd = cli.Command{
+	Name:         "create",
+	Usage:        "stream the objects of a prefix into a zip or tar archive object",
+	Action:       mainArchiveCreate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(archiveCreateFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+DESCRIPTION:
+  The format of the archive is chosen by the extension of TARGET: '.zip', '.tar',
+  '.tar.gz', '.tgz', '.tar.zst' or '.tzst'. The archive is uploaded while the objects are read, without
+  any local temporary space.
+
+  Entries are named like the objects copied by 'mc cp --recursive': relative to
+  SOURCE when it ends with a '/', including its last path element otherwise.
+
+EXAMPLES:
+  01. Archive a dataset as a zip object.
+      {{.Prompt}} {{.HelpName}} play/datasets/census-2020/ play/exports/census-2020.zip
+
+  02. Archive the CSV files of a dataset, as a compressed tar object on another site.
+      {{.Prompt}} {{.HelpName}} --include '*.csv' play/datasets/census-2020/ s3/partners/census-2020.tar.gz
+
+  03. Archive a local folder without its temporary files.
+      {{.Prompt}} {{.HelpName}} --exclude 'tmp/' --exclude '*.swp' ~/reports play/exports/reports.zip
+
+  04. Archive a dataset with an entry 'MANIFEST.csv' listing the archived objects.
+      {{.Prompt}} {{.HelpName}} --add-manifest MANIFEST.csv play/datasets/census-2020/ play/exports/census-2020.zip
+`,
+}
+
+// archiveEntryMessage - an object added to an archive.
+type archiveEntryMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized archive entry message
+func (m archiveEntryMessage) String() string {
+	return console.Colorize("ArchiveEntry", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Name))
+}
+
+// JSON jsonified archive entry message
+func (m archiveEntryMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// archiveCreateMessage - an archive created.
+type archiveCreateMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Format  string `json:"format"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+// String colorized archive message
+func (m archiveCreateMessage) String() string {
+	return console.Colorize("Archive", fmt.Sprintf("Created `%s` with %d objects, %s.", m.Target, m.Objects, humanize.IBytes(uint64(m.Size))))
+}
+
+// JSON jsonified archive message
+func (m archiveCreateMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// archiveWriter - writes the entries of an archive.
+type archiveWriter interface {
+	add(name string, size int64, modTime time.Time, r io.Reader) error
+	Close() error
+}
+
+// zipArchive - a zip archive, entries are deflated.
+type zipArchive struct {
+	w *zip.Writer
+}
+
+func (a zipArchive) add(name string, _ int64, modTime time.Time, r io.Reader) error {
+	w, e := a.w.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+	if e != nil {
+		return e
+	}
+	_, e = io.Copy(w, r)
+	return e
+}
+
+func (a zipArchive) Close() error {
+	return a.w.Close()
+}
+
+// tarArchive - a tar archive, compressed when compressor is set.
+type tarArchive struct {
+	w          *tar.Writer
+	compressor io.Closer
+}
+
+func (a tarArchive) add(name string, size int64, modTime time.Time, r io.Reader) error {
+	e := a.w.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	})
+	if e != nil {
+		return e
+	}
+	n, e := io.Copy(a.w, r)
+	if e == nil && n != size {
+		e = fmt.Errorf("read %d bytes of `%s`, expected %d", n, name, size)
+	}
+	return e
+}
+
+func (a tarArchive) Close() error {
+	if e := a.w.Close(); e != nil {
+		return e
+	}
+	if a.compressor != nil {
+		return a.compressor.Close()
+	}
+	return nil
+}
+
+// archiveFormat - the format of an archive named urlStr, empty if unknown.
+func archiveFormat(urlStr string) string {
+	name := strings.ToLower(urlStr)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return "tar.zst"
+	case strings.HasSuffix(name, ".tar"):
+		return "tar"
+	}
+	return ""
+}
+
+// newArchiveWriter - an archive of format written to w.
+func newArchiveWriter(format string, w io.Writer) archiveWriter {
+	switch format {
+	case "zip":
+		return zipArchive{w: zip.NewWriter(w)}
+	case "tar.gz":
+		gz := gzip.NewWriter(w)
+		return tarArchive{w: tar.NewWriter(gz), compressor: gz}
+	case "tar.zst":
+		zw, _ := zstd.NewWriter(w)
+		return tarArchive{w: tar.NewWriter(zw), compressor: zw}
+	}
+	return tarArchive{w: tar.NewWriter(w)}
+}
+
+// archiveFilterRules - the rules of --exclude, --include and --filter-from,
+// in this order. Folders are traversed and other objects excluded when
+// only some objects are included.
+func archiveFilterRules(cliCtx *cli.Context) filterRules {
+	var lines []string
+	for _, pattern := range cliCtx.StringSlice("exclude") {
+		lines = append(lines, "- "+pattern)
+	}
+	for _, pattern := range cliCtx.StringSlice("include") {
+		lines = append(lines, "+ "+pattern)
+	}
+	rules, e := parseFilterRules(strings.NewReader(strings.Join(lines, "\n")))
+	fatalIf(probe.NewError(e), "Unable to parse --include or --exclude.")
+	rules = append(rules, parseFilterFromFlag(cliCtx)...)
+	if len(cliCtx.StringSlice("include")) > 0 {
+		others, _ := parseFilterRules(strings.NewReader("+ */\n- *"))
+		rules = append(rules, others...)
+	}
+	return rules
+}
+
+// archiveEntryName - the name of an object in an archive of sourceURL,
+// relative to it when it is a folder, to its parent otherwise.
+func archiveEntryName(sourceURL ClientURL, content *ClientContent) string {
+	separator := string(sourceURL.Separator)
+	base := sourceURL.Path
+	if !strings.HasSuffix(base, separator) {
+		base = base[:strings.LastIndex(base, separator)+1]
+	}
+	name := strings.TrimPrefix(content.URL.Path, base)
+	return strings.TrimPrefix(strings.ReplaceAll(name, separator, "/"), "/")
+}
+
+// writeArchive - write the objects of sourceURL to an archive, returns
+// the number of objects archived.
+func writeArchive(ctx context.Context, w archiveWriter, sourceURL string, rules filterRules, manifestName string, encKeyDB map[string][]prefixSSEPair) (int64, *probe.Error) {
+	alias, _, _ := mustExpandAlias(sourceURL)
+	clnt, err := newClient(sourceURL)
+	if err != nil {
+		return 0, err.Trace(sourceURL)
+	}
+
+	var manifest bytes.Buffer
+	manifestCSV := csv.NewWriter(&manifest)
+	manifestCSV.Write([]string{"name", "size", "etag", "lastModified"})
+
+	var objects int64
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return objects, content.Err.Trace(sourceURL)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		name := archiveEntryName(clnt.GetURL(), content)
+		if name == "" || name == manifestName || !rules.Match(name, content) {
+			continue
+		}
+
+		objectURL := content.URL.String()
+		objectPath := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+		// Objects compressed or encrypted by mc are read with the size of
+		// their content, which listings may not have.
+		reader, stream, err := getSourceStream(ctx, alias, objectURL, getSourceOpts{
+			GetOptions: GetOptions{SSE: getSSE(objectPath, encKeyDB[alias])},
+		})
+		if err != nil {
+			return objects, err.Trace(objectURL)
+		}
+		if stream.Size < 0 {
+			reader.Close()
+			return objects, probe.NewError(errors.New("the size of the content is unknown")).Trace(objectURL)
+		}
+		e := w.add(name, stream.Size, content.Time, reader)
+		reader.Close()
+		if e != nil {
+			return objects, probe.NewError(e).Trace(objectURL)
+		}
+		printMsg(archiveEntryMessage{Source: objectPath, Name: name, Size: stream.Size})
+
+		objects++
+		manifestCSV.Write([]string{
+			name,
+			strconv.FormatInt(stream.Size, 10),
+			strings.Trim(content.ETag, `"`),
+			content.Time.UTC().Format(time.RFC3339),
+		})
+	}
+
+	if manifestName != "" {
+		manifestCSV.Flush()
+		if e := w.add(manifestName, int64(manifest.Len()), UTCNow(), &manifest); e != nil {
+			return objects, probe.NewError(e).Trace(manifestName)
+		}
+	}
+	if e := w.Close(); e != nil {
+		return objects, probe.NewError(e)
+	}
+	return objects, nil
+}
+
+// checkArchiveCreateSyntax - validate all the passed arguments
+func checkArchiveCreateSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	targetURL := cliCtx.Args().Get(1)
+	if archiveFormat(targetURL) == "" {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Unknown archive format of `%s`, use a '.zip', '.tar', '.tar.gz', '.tgz', '.tar.zst' or '.tzst' extension.", targetURL)
+	}
+	if strings.Contains(cliCtx.String("add-manifest"), "..") || strings.HasPrefix(cliCtx.String("add-manifest"), "/") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("add-manifest")), "The name of --add-manifest must be relative to the archive.")
+	}
+}
+
+// mainArchiveCreate is the handle for "mc archive create" command.
+func mainArchiveCreate(cliCtx *cli.Context) error {
+	checkArchiveCreateSyntax(cliCtx)
+	console.SetColor("ArchiveEntry", color.New(color.FgGreen))
+	console.SetColor("Archive", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelArchive := context.WithCancel(globalContext)
+	defer cancelArchive()
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	sourceURL := cliCtx.Args().Get(0)
+	targetURL := cliCtx.Args().Get(1)
+	format := archiveFormat(targetURL)
+	rules := archiveFilterRules(cliCtx)
+
+	// The archive is written while it is uploaded, a failure on either
+	// side stops the other one.
+	pr, pw := io.Pipe()
+	var (
+		objects    int64
+		archiveErr *probe.Error
+	)
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		objects, archiveErr = writeArchive(ctx, newArchiveWriter(format, pw), sourceURL, rules, cliCtx.String("add-manifest"), encKeyDB)
+		if archiveErr != nil {
+			pw.CloseWithError(archiveErr.ToGoError())
+		} else {
+			pw.Close()
+		}
+	}()
+
+	alias, targetURLFull, _, err := expandAlias(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to create the archive `%s`.", targetURL)
+	size, err := putTargetStream(ctx, alias, targetURLFull, "", "", "", pr, -1, nil, PutOptions{
+		sse:      getSSE(targetURL, encKeyDB[alias]),
+		metadata: map[string]string{"Content-Type": guessURLContentType(targetURL)},
+	})
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-doneCh
+	// A failure to read the objects fails the upload as well, while a
+	// failed upload only closes the pipe of the archive.
+	if archiveErr != nil && !errors.Is(archiveErr.ToGoError(), io.ErrClosedPipe) {
+		fatalIf(archiveErr, "Unable to archive `%s`.", sourceURL)
+	}
+	fatalIf(err.Trace(targetURL), "Unable to create the archive `%s`.", targetURL)
+
+	printMsg(archiveCreateMessage{
+		Target:  targetURL,
+		Format:  format,
+		Objects: objects,
+		Size:    size,
+	})
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestArchiveFormat(t *testing.T) {
+	testCases := map[string]string{
+		"play/exports/data.zip":     "zip",
+		"play/exports/data.ZIP":     "zip",
+		"play/exports/data.tar":     "tar",
+		"play/exports/data.tar.gz":  "tar.gz",
+		"play/exports/data.tgz":     "tar.gz",
+		"play/exports/data.tar.zst": "tar.zst",
+		"play/exports/data.rar":     "",
+		"play/exports/data":         "",
+	}
+	for urlStr, expected := range testCases {
+		if format := archiveFormat(urlStr); format != expected {
+			t.Fatalf("%s: expected format %q, got %q", urlStr, expected, format)
+		}
+	}
+}
+
+func TestArchiveEntryName(t *testing.T) {
+	testCases := []struct {
+		source, object, name string
+	}{
+		{"https://s3.example.com/bucket/data/", "https://s3.example.com/bucket/data/2024/a.csv", "2024/a.csv"},
+		{"https://s3.example.com/bucket/data", "https://s3.example.com/bucket/data/2024/a.csv", "data/2024/a.csv"},
+		{"https://s3.example.com/bucket/data/a.csv", "https://s3.example.com/bucket/data/a.csv", "a.csv"},
+		{"https://s3.example.com/bucket", "https://s3.example.com/bucket/a.csv", "bucket/a.csv"},
+	}
+	for i, testCase := range testCases {
+		content := &ClientContent{URL: *newClientURL(testCase.object)}
+		if name := archiveEntryName(*newClientURL(testCase.source), content); name != testCase.name {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.name, name)
+		}
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	// Local paths without any alias.
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.csv":      "a,b\n1,2\n",
+		"sub/b.csv":  "c,d\n3,4\n",
+		"sub/c.json": `{"e": 5}`,
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if e := os.MkdirAll(filepath.Dir(path), 0o755); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.WriteFile(path, []byte(data), 0o644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	rules, e := parseFilterRules(strings.NewReader("- *.json"))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	for _, format := range []string{"zip", "tar", "tar.gz"} {
+		var archive bytes.Buffer
+		objects, err := writeArchive(context.Background(), newArchiveWriter(format, &archive), dir+string(filepath.Separator), rules, "MANIFEST.csv", nil)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if objects != 2 {
+			t.Fatalf("%s: expected 2 objects, got %d", format, objects)
+		}
+
+		entries := map[string]string{}
+		switch format {
+		case "zip":
+			r, e := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+			if e != nil {
+				t.Fatalf("%s: %v", format, e)
+			}
+			for _, f := range r.File {
+				rc, e := f.Open()
+				if e != nil {
+					t.Fatalf("%s: %v", format, e)
+				}
+				data, _ := io.ReadAll(rc)
+				rc.Close()
+				entries[f.Name] = string(data)
+			}
+		default:
+			var reader io.Reader = &archive
+			if format == "tar.gz" {
+				gz, e := gzip.NewReader(reader)
+				if e != nil {
+					t.Fatalf("%s: %v", format, e)
+				}
+				reader = gz
+			}
+			tr := tar.NewReader(reader)
+			for {
+				h, e := tr.Next()
+				if e == io.EOF {
+					break
+				}
+				if e != nil {
+					t.Fatalf("%s: %v", format, e)
+				}
+				data, _ := io.ReadAll(tr)
+				entries[h.Name] = string(data)
+			}
+		}
+
+		var names []string
+		for name := range entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if expected := []string{"MANIFEST.csv", "a.csv", "sub/b.csv"}; !reflect.DeepEqual(names, expected) {
+			t.Fatalf("%s: expected entries %v, got %v", format, expected, names)
+		}
+		if entries["sub/b.csv"] != files["sub/b.csv"] {
+			t.Fatalf("%s: expected %q, got %q", format, files["sub/b.csv"], entries["sub/b.csv"])
+		}
+		if manifest := entries["MANIFEST.csv"]; !strings.HasPrefix(manifest, "name,size,etag,lastModified\n") || !strings.Contains(manifest, "\nsub/b.csv,8,") {
+			t.Fatalf("%s: unexpected manifest %q", format, manifest)
+		}
+	}
+}
+
+// compressedObjectHandler - serves a bucket with a single object
+// compressed by mc, listed with its compressed size.
+type compressedObjectHandler struct {
+	compressed []byte
+	size       int
+}
+
+func (h compressedObjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Query().Has("location"):
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+	case r.URL.Path == "/bucket/" || r.URL.Path == "/bucket":
+		fmt.Fprintf(w, `<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><Prefix></Prefix><KeyCount>1</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated><Contents><Key>logs.txt</Key><LastModified>2025-01-01T00:00:00.000Z</LastModified><ETag>"etag"</ETag><Size>%d</Size><StorageClass>STANDARD</StorageClass></Contents></ListBucketResult>`, len(h.compressed))
+	case r.URL.Path == "/bucket/logs.txt":
+		w.Header().Set("Content-Length", strconv.Itoa(len(h.compressed)))
+		w.Header().Set("Last-Modified", "Wed, 01 Jan 2025 00:00:00 GMT")
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set(compressCodecKey, compressZstd)
+		w.Header().Set(compressSizeKey, strconv.Itoa(h.size))
+		if r.Method == http.MethodGet {
+			w.Write(h.compressed)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestWriteArchiveCompressed(t *testing.T) {
+	data := []byte(strings.Repeat("GET /index.html 200\n", 1000))
+	var compressed bytes.Buffer
+	if _, e := compressStream(&compressed, bytes.NewReader(data), compressZstd); e != nil {
+		t.Fatal(e)
+	}
+	server := httptest.NewServer(compressedObjectHandler{compressed: compressed.Bytes(), size: len(data)})
+	defer server.Close()
+
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		config := newMcConfig()
+		config.Aliases["archivetest"] = aliasConfigV10{
+			URL:       server.URL,
+			AccessKey: "WLGDGYAQYIGI833EV05A",
+			SecretKey: "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF",
+			API:       "S3v4",
+			Path:      "on",
+		}
+		return config, nil
+	}
+
+	var archive bytes.Buffer
+	objects, err := writeArchive(context.Background(), newArchiveWriter("tar", &archive), "archivetest/bucket/", filterRules{}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objects != 1 {
+		t.Fatalf("expected 1 object, got %d", objects)
+	}
+	tr := tar.NewReader(&archive)
+	h, e := tr.Next()
+	if e != nil {
+		t.Fatal(e)
+	}
+	content, e := io.ReadAll(tr)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if h.Name != "logs.txt" || h.Size != int64(len(data)) || !bytes.Equal(content, data) {
+		t.Fatalf("expected %s of %d bytes, got %s of %d bytes", "logs.txt", len(data), h.Name, h.Size)
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+)
+
+// extractBufferSize - the largest file of a tar archive read in memory,
+// to be uploaded while the next files are read. Larger files are uploaded
+// before the archive is read further.
+const extractBufferSize = 8 << 20
+
+// errExtractReread - a file streamed from a tar archive cannot be read twice.
+var errExtractReread = errors.New("the file was already read from the archive")
+
+// archiveMember - a file of an archive.
+type archiveMember struct {
+	name     string
+	size     int64
+	modTime  time.Time
+	mode     os.FileMode
+	hasOwner bool // zip archives have no owner
+	uid, gid int
+	uname    string
+	gname    string
+
+	// open reads the file, the archive is not read further until
+	// release is called when it is streamed from a tar archive.
+	open    func() (io.ReadCloser, error)
+	release func()
+
+	err *probe.Error
+}
+
+// attrs - the file attributes of a member, in the format of the
+// attributes preserved by cp.
+func (m archiveMember) attrs() string {
+	var attrs strings.Builder
+	if m.hasOwner {
+		attrs.WriteString("gid:" + strconv.Itoa(m.gid) + "/")
+		if m.gname != "" {
+			attrs.WriteString("gname:" + m.gname + "/")
+		}
+	}
+	// A regular file, with its permissions.
+	attrs.WriteString("mode:" + strconv.FormatUint(uint64(0o100000|m.mode.Perm()), 10))
+	attrs.WriteString("/mtime:" + strconv.FormatInt(m.modTime.Unix(), 10) + "#" + strconv.Itoa(m.modTime.Nanosecond()))
+	if m.hasOwner {
+		attrs.WriteString("/uid:" + strconv.Itoa(m.uid))
+		if m.uname != "" {
+			attrs.WriteString("/uname:" + m.uname)
+		}
+	}
+	return attrs.String()
+}
+
+// archiveMemberName - the relative name of a file of an archive, empty
+// when it is not a regular file. Names can not escape the target.
+func archiveMemberName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasSuffix(name, "/") {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// readZipArchive - send the files of a zip archive, any of them can be
+// read at any time until all of them are released.
+func readZipArchive(ctx context.Context, r io.ReaderAt, size int64, memberCh chan<- archiveMember) *probe.Error {
+	zr, e := zip.NewReader(r, size)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, f := range zr.File {
+		name := archiveMemberName(f.Name)
+		if name == "" || !f.Mode().IsRegular() {
+			continue
+		}
+		var once sync.Once
+		member := archiveMember{
+			name:    name,
+			size:    int64(f.UncompressedSize64),
+			modTime: f.Modified,
+			mode:    f.Mode(),
+			open: func() (io.ReadCloser, error) {
+				return f.Open()
+			},
+			release: func() { once.Do(wg.Done) },
+		}
+		wg.Add(1)
+		select {
+		case memberCh <- member:
+		case <-ctx.Done():
+			wg.Done()
+			return probe.NewError(ctx.Err())
+		}
+	}
+	return nil
+}
+
+// readTarArchive - send the files of a tar archive. Small files are read
+// in memory, the others are streamed from the archive.
+func readTarArchive(ctx context.Context, r io.Reader, memberCh chan<- archiveMember) *probe.Error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, e := tr.Next()
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return probe.NewError(e)
+		}
+		name := archiveMemberName(hdr.Name)
+		if name == "" || (hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA) {
+			continue
+		}
+		member := archiveMember{
+			name:     name,
+			size:     hdr.Size,
+			modTime:  hdr.ModTime,
+			mode:     hdr.FileInfo().Mode(),
+			hasOwner: true,
+			uid:      hdr.Uid,
+			gid:      hdr.Gid,
+			uname:    hdr.Uname,
+			gname:    hdr.Gname,
+			release:  func() {},
+		}
+
+		var releaseCh chan struct{}
+		if hdr.Size <= extractBufferSize {
+			data, e := io.ReadAll(tr)
+			if e != nil {
+				return probe.NewError(e).Trace(hdr.Name)
+			}
+			member.open = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			}
+		} else {
+			releaseCh = make(chan struct{})
+			var once, opened sync.Once
+			member.release = func() { once.Do(func() { close(releaseCh) }) }
+			member.open = func() (rc io.ReadCloser, e error) {
+				e = errExtractReread
+				opened.Do(func() { rc, e = io.NopCloser(tr), nil })
+				return rc, e
+			}
+		}
+
+		select {
+		case memberCh <- member:
+		case <-ctx.Done():
+			return probe.NewError(ctx.Err())
+		}
+		if releaseCh != nil {
+			select {
+			case <-releaseCh:
+			case <-ctx.Done():
+				return probe.NewError(ctx.Err())
+			}
+		}
+	}
+}
+
+// readArchive - send the files of the archive archiveURL of format, and
+// any error reading it.
+func readArchive(ctx context.Context, archiveURL, format string, encKeyDB map[string][]prefixSSEPair) <-chan archiveMember {
+	memberCh := make(chan archiveMember)
+	go func() {
+		defer close(memberCh)
+		sendErr := func(err *probe.Error) {
+			select {
+			case memberCh <- archiveMember{err: err.Trace(archiveURL)}:
+			case <-ctx.Done():
+			}
+		}
+
+		alias, urlStrFull, _ := mustExpandAlias(archiveURL)
+		reader, content, err := getSourceStream(ctx, alias, urlStrFull, getSourceOpts{
+			GetOptions: GetOptions{SSE: getSSE(archiveURL, encKeyDB[alias])},
+		})
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		defer reader.Close()
+
+		switch format {
+		case "zip":
+			// Zip archives are read from their end, a remote archive is downloaded first.
+			f, ok := reader.(*os.File)
+			if !ok {
+				tmp, e := os.CreateTemp("", "mc-extract-*.zip")
+				if e != nil {
+					sendErr(probe.NewError(e))
+					return
+				}
+				defer os.Remove(tmp.Name())
+				defer tmp.Close()
+				if _, e = io.Copy(tmp, reader); e != nil {
+					sendErr(probe.NewError(e))
+					return
+				}
+				f = tmp
+			}
+			err = readZipArchive(ctx, f, content.Size, memberCh)
+		case "tar.gz":
+			gz, e := gzip.NewReader(reader)
+			if e != nil {
+				sendErr(probe.NewError(e))
+				return
+			}
+			err = readTarArchive(ctx, gz, memberCh)
+		case "tar.zst":
+			zr, e := zstd.NewReader(reader)
+			if e != nil {
+				sendErr(probe.NewError(e))
+				return
+			}
+			defer zr.Close()
+			err = readTarArchive(ctx, zr, memberCh)
+		default:
+			err = readTarArchive(ctx, reader, memberCh)
+		}
+		if err != nil && ctx.Err() == nil {
+			sendErr(err)
+		}
+	}()
+	return memberCh
+}
+
+// checkExtractSyntax - validate the extraction of an archive SOURCE to
+// TARGET, none of the flags of unsupported can be set.
+func checkExtractSyntax(cliCtx *cli.Context, unsupported []string) {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	parseChecksum(cliCtx)
+
+	for _, flag := range unsupported {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), fmt.Sprintf("`--extract` cannot be used with `--%s`.", flag))
+		}
+	}
+
+	sourceURL, targetURL := cliCtx.Args().Get(0), cliCtx.Args().Get(1)
+	if format := archiveFormat(sourceURL); format == "" {
+		fatalIf(errInvalidArgument().Trace(sourceURL), "Unknown archive format of `%s`, use a '.zip', '.tar', '.tar.gz', '.tgz', '.tar.zst' or '.tzst' archive.", sourceURL)
+	}
+	url := newClientURL(targetURL)
+	if url.Host != "" && url.Path == string(url.Separator) {
+		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Target `%s` does not contain bucket name.", targetURL))
+	}
+}
+
+// extractOpts - the options of --extract.
+type extractOpts struct {
+	sourceURL        string
+	targetURL        string
+	encKeyDB         map[string][]prefixSSEPair
+	preserve         bool
+	storageClass     string
+	md5              bool
+	checksum         minio.ChecksumType
+	compress         string
+	disableMultipart bool
+	multipartSize    string
+	multipartThreads string
+	maxWorkers       int
+}
+
+// extractURLs - the URLs uploading a file of an archive to the target.
+func extractURLs(opts extractOpts, member archiveMember) URLs {
+	targetURL := strings.TrimSuffix(opts.targetURL, "/") + "/" + member.name
+	targetAlias, targetURLFull, _ := mustExpandAlias(targetURL)
+	return URLs{
+		// Files of the archive are named after it, without alias
+		// they are never copied on the server side.
+		SourceContent: &ClientContent{
+			URL:  *newClientURL(opts.sourceURL + "/" + member.name),
+			Size: member.size,
+			Time: member.modTime,
+			Type: member.mode,
+		},
+		TargetAlias: targetAlias,
+		TargetContent: &ClientContent{
+			URL:          *newClientURL(targetURLFull),
+			Metadata:     map[string]string{},
+			UserMetadata: map[string]string{},
+			StorageClass: opts.storageClass,
+		},
+		MD5:              opts.md5,
+		checksum:         opts.checksum,
+		compress:         opts.compress,
+		DisableMultipart: opts.disableMultipart,
+	}
+}
+
+// extractOpener - open a file of an archive, as the source of an upload.
+func extractOpener(member archiveMember, preserve bool) openSourceFunc {
+	return func(_ context.Context, _, urlStr string, _ getSourceOpts) (io.ReadCloser, *ClientContent, *probe.Error) {
+		reader, e := member.open()
+		if e != nil {
+			return nil, nil, probe.NewError(e).Trace(urlStr)
+		}
+		content := &ClientContent{
+			URL:  *newClientURL(urlStr),
+			Size: member.size,
+			Time: member.modTime,
+			Type: member.mode,
+			Metadata: map[string]string{
+				"Content-Type": guessURLContentType(member.name),
+			},
+		}
+		if preserve {
+			content.Metadata[metadataKey] = member.attrs()
+		}
+		return reader, content, nil
+	}
+}
+
+// extractArchive - upload the files of an archive as objects under the
+// target, with parallel workers.
+func extractArchive(ctx context.Context, opts extractOpts) error {
+	format := archiveFormat(opts.sourceURL)
+
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+	} else {
+		pg = newAccounter(0)
+	}
+
+	statusCh := make(chan URLs)
+	parallel := newParallelManager(statusCh, opts.maxWorkers)
+	go func() {
+		var totalObjects, totalBytes int64
+		for member := range readArchive(ctx, opts.sourceURL, format, opts.encKeyDB) {
+			if member.err != nil {
+				statusCh <- URLs{Error: member.err}
+				break
+			}
+			urls := extractURLs(opts, member)
+			totalObjects++
+			totalBytes += member.size
+			urls.TotalCount, urls.TotalSize = totalObjects, totalBytes
+			pg.SetTotal(totalBytes)
+
+			parallel.queueTask(func() URLs {
+				defer member.release()
+				return doCopy(ctx, doCopyOpts{
+					cpURLs:           urls,
+					pg:               pg,
+					encryptionKeys:   opts.encKeyDB,
+					preserve:         opts.preserve,
+					multipartSize:    opts.multipartSize,
+					multipartThreads: opts.multipartThreads,
+					openSource:       extractOpener(member, opts.preserve),
+				})
+			}, member.size)
+		}
+		parallel.stopAndWait()
+		close(statusCh)
+	}()
+
+	var retErr error
+	for urls := range statusCh {
+		if urls.Error == nil {
+			continue
+		}
+		retErr = exitStatus(globalErrorExitStatus)
+		if !globalQuiet && !globalJSON {
+			console.Eraseline()
+		}
+		if urls.SourceContent == nil {
+			errorIf(urls.Error.Trace(opts.sourceURL), "Unable to read the archive `%s`.", opts.sourceURL)
+			continue
+		}
+		errorIf(urls.Error.Trace(urls.SourceContent.URL.String()), "Failed to extract `%s`.", urls.SourceContent.URL)
+	}
+	showLastProgressBar(pg, retErr)
+	return retErr
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestArchiveMemberName(t *testing.T) {
+	testCases := map[string]string{
+		"a.csv":              "a.csv",
+		"./data/a.csv":       "data/a.csv",
+		"/data/a.csv":        "data/a.csv",
+		"../../etc/passwd":   "etc/passwd",
+		"data/../../a.csv":   "a.csv",
+		"data\\2024\\a.csv":  "data/2024/a.csv",
+		"data/":              "",
+		"data/2024/../a.csv": "data/a.csv",
+	}
+	for name, expected := range testCases {
+		if memberName := archiveMemberName(name); memberName != expected {
+			t.Fatalf("%s: expected %q, got %q", name, expected, memberName)
+		}
+	}
+}
+
+func TestArchiveMemberAttrs(t *testing.T) {
+	member := archiveMember{
+		modTime:  time.Unix(1700000000, 5),
+		mode:     0o640,
+		hasOwner: true,
+		uid:      1000,
+		gid:      100,
+		uname:    "minio",
+	}
+	expected := "gid:100/mode:33184/mtime:1700000000#5/uid:1000/uname:minio"
+	if attrs := member.attrs(); attrs != expected {
+		t.Fatalf("expected %q, got %q", expected, attrs)
+	}
+
+	// Members of zip archives have no owner.
+	member = archiveMember{modTime: time.Unix(1700000000, 5), mode: 0o640}
+	expected = "mode:33184/mtime:1700000000#5"
+	if attrs := member.attrs(); attrs != expected {
+		t.Fatalf("expected %q, got %q", expected, attrs)
+	}
+}
+
+func TestReadArchive(t *testing.T) {
+	// Local paths without any alias.
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+
+	files := map[string]string{
+		"a.csv":     "a,b\n1,2\n",
+		"sub/b.csv": "c,d\n3,4\n",
+		// Larger than the buffer, streamed from tar archives.
+		"sub/large.bin": strings.Repeat("x", extractBufferSize+1),
+	}
+	dir := t.TempDir()
+	for _, format := range []string{"zip", "tar", "tar.gz", "tar.zst"} {
+		archivePath := filepath.Join(dir, "data."+format)
+		f, e := os.Create(archivePath)
+		if e != nil {
+			t.Fatal(e)
+		}
+		w := newArchiveWriter(format, f)
+		for _, name := range []string{"a.csv", "sub/b.csv", "sub/large.bin"} {
+			if e = w.add(name, int64(len(files[name])), time.Now(), strings.NewReader(files[name])); e != nil {
+				t.Fatalf("%s: %v", format, e)
+			}
+		}
+		if e = w.Close(); e != nil {
+			t.Fatalf("%s: %v", format, e)
+		}
+		f.Close()
+
+		entries := map[string]string{}
+		for member := range readArchive(context.Background(), archivePath, format, nil) {
+			if member.err != nil {
+				t.Fatalf("%s: %v", format, member.err)
+			}
+			rc, e := member.open()
+			if e != nil {
+				t.Fatalf("%s: %v", format, e)
+			}
+			data, e := io.ReadAll(rc)
+			rc.Close()
+			member.release()
+			if e != nil {
+				t.Fatalf("%s: %v", format, e)
+			}
+			if int64(len(data)) != member.size {
+				t.Fatalf("%s: %s: expected size %d, got %d", format, member.name, member.size, len(data))
+			}
+			entries[member.name] = string(data)
+		}
+		if !reflect.DeepEqual(entries, files) {
+			t.Fatalf("%s: unexpected members", format)
+		}
+	}
+}
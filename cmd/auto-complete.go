@@ -231,6 +231,7 @@ var (
 var completeCmds = map[string]complete.Predictor{
 	// S3 API level commands
 	"/ls":        complete.PredictOr(s3Completer, fsCompleter),
+	"/bisync":    complete.PredictOr(s3Completer, fsCompleter),
 	"/cp":        complete.PredictOr(s3Completer, fsCompleter),
 	"/mv":        complete.PredictOr(s3Completer, fsCompleter),
 	"/rm":        complete.PredictOr(s3Completer, fsCompleter),
@@ -246,6 +247,8 @@ var completeCmds = map[string]complete.Predictor{
 	"/anonymous": complete.PredictOr(s3Completer, fsCompleter),
 	"/tree":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 	"/du":        complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/split":     complete.PredictOr(s3Completer, fsCompleter),
+	"/join":      complete.PredictOr(s3Completer, fsCompleter),
 
 	"/retention/set":   s3Completer,
 	"/retention/clear": s3Completer,
@@ -255,6 +258,14 @@ var completeCmds = map[string]complete.Predictor{
 	"/legalhold/clear": s3Completer,
 	"/legalhold/info":  s3Completer,
 
+	"/sync/plan":  complete.PredictOr(s3Completer, fsCompleter),
+	"/sync/apply": complete.PredictFiles("*.json"),
+
+	"/archive/create": complete.PredictOr(s3Completer, fsCompleter),
+
+	"/meta/set": s3Completer,
+	"/meta/rm":  s3Completer,
+
 	"/sql": s3Completer,
 	"/mb":  aliasCompleter,
 
@@ -296,6 +307,10 @@ var completeCmds = map[string]complete.Predictor{
 	"/share/list":     nil,
 	"/share/upload":   s3Completer,
 
+	"/session/list":   nil,
+	"/session/resume": nil,
+	"/session/clear":  nil,
+
 	"/ilm/list":    s3Complete{deepLevel: 2},
 	"/ilm/add":     s3Complete{deepLevel: 2},
 	"/ilm/edit":    s3Complete{deepLevel: 2},
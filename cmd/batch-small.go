@@ -0,0 +1,297 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// Limits of a batch of small objects. A batch is archived in memory before
+// it is uploaded, every upload in parallel holds up to smallBatchMaxBytes.
+const (
+	smallBatchMaxObjects = 1000
+	smallBatchMaxBytes   = 64 << 20
+)
+
+// smallBatch - small objects uploaded to a bucket in a single request.
+type smallBatch struct {
+	clnt *S3Client
+	urls []URLs
+	keys []string
+	size int64
+}
+
+// smallBatcher - groups the small objects copied to a target which
+// extracts uploaded tar archives in batches by bucket, objects which
+// cannot be batched are uploaded one by one.
+type smallBatcher struct {
+	threshold int64
+	encKeyDB  map[string][]prefixSSEPair
+
+	mu        sync.Mutex
+	supported map[string]bool        // by alias of the target
+	batches   map[string]*smallBatch // by alias and bucket of the target
+}
+
+// newSmallBatcher - the batcher of --batch-small, nil without it.
+func newSmallBatcher(cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) (*smallBatcher, *probe.Error) {
+	if !cliCtx.Bool("batch-small") {
+		return nil, nil
+	}
+	threshold, e := humanize.ParseBytes(cliCtx.String("batch-small-size"))
+	if e != nil {
+		return nil, probe.NewError(e).Trace(cliCtx.String("batch-small-size"))
+	}
+	return &smallBatcher{
+		threshold: int64(threshold),
+		encKeyDB:  encKeyDB,
+		supported: make(map[string]bool),
+		batches:   make(map[string]*smallBatch),
+	}, nil
+}
+
+// isSnowballSupported - whether the server of an alias extracts uploaded
+// tar archives. Only MinIO does, recognized by its cluster health check.
+func isSnowballSupported(ctx context.Context, alias string) bool {
+	anonClient, err := newAnonymousClient(alias)
+	if err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, e := anonClient.Healthy(ctx, madmin.HealthOpts{})
+	return e == nil && (result.Healthy || result.MaintenanceMode || result.WriteQuorum > 0)
+}
+
+// batchable - whether an object is uploaded as is by a stream copy and
+// small enough to be batched. Server side copies, checksums, compression,
+// encryption and object locking need a request for every object.
+func (b *smallBatcher) batchable(urls URLs) bool {
+	if urls.Error != nil || urls.SourceContent == nil || urls.TargetContent == nil {
+		return false
+	}
+	source, target := urls.SourceContent, urls.TargetContent
+	switch {
+	case source.Type.IsDir() || source.Size > b.threshold:
+	case urls.TargetAlias == "" || urls.SourceAlias == urls.TargetAlias:
+	case urls.MoveFrom != nil || len(urls.fanOut) > 0:
+	case urls.MD5 || urls.checksum.IsSet() || urls.compress != "":
+	case source.RetentionEnabled || target.RetentionEnabled || target.LegalHoldEnabled:
+	case getSSE(filepath.ToSlash(filepath.Join(urls.TargetAlias, target.URL.Path)), b.encKeyDB[urls.TargetAlias]) != nil:
+	default:
+		return true
+	}
+	return false
+}
+
+// add - add a small object to the batch of its target bucket, false if it
+// is not batched, always without --batch-small. Returns the batch when it
+// is full.
+func (b *smallBatcher) add(ctx context.Context, urls URLs) (*smallBatch, bool) {
+	if b == nil || !b.batchable(urls) {
+		return nil, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	supported, ok := b.supported[urls.TargetAlias]
+	if !ok {
+		supported = isSnowballSupported(ctx, urls.TargetAlias)
+		b.supported[urls.TargetAlias] = supported
+	}
+	if !supported {
+		return nil, false
+	}
+
+	clnt, err := newClientFromAlias(urls.TargetAlias, urls.TargetContent.URL.String())
+	if err != nil {
+		return nil, false
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return nil, false
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	if bucket == "" || object == "" || strings.HasSuffix(object, "/") {
+		return nil, false
+	}
+
+	key := urls.TargetAlias + "/" + bucket
+	batch := b.batches[key]
+	if batch == nil {
+		batch = &smallBatch{clnt: s3Clnt}
+		b.batches[key] = batch
+	}
+	batch.urls = append(batch.urls, urls)
+	batch.keys = append(batch.keys, object)
+	batch.size += urls.SourceContent.Size
+	if len(batch.urls) < smallBatchMaxObjects && batch.size < smallBatchMaxBytes {
+		return nil, true
+	}
+	delete(b.batches, key)
+	return batch, true
+}
+
+// flush - the batches which are not full yet.
+func (b *smallBatcher) flush() []*smallBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batches := make([]*smallBatch, 0, len(b.batches))
+	for key, batch := range b.batches {
+		batches = append(batches, batch)
+		delete(b.batches, key)
+	}
+	return batches
+}
+
+// snowballHeader - the header of a metadata key of an object in a batch,
+// user metadata is prefixed like in a regular upload.
+func snowballHeader(key string) string {
+	key = http.CanonicalHeaderKey(key)
+	switch {
+	case strings.HasPrefix(key, "X-Amz-"), strings.HasPrefix(key, "X-Minio-"), strings.HasPrefix(key, "Content-"):
+		return key
+	case key == "Cache-Control", key == "Expires":
+		return key
+	}
+	return "X-Amz-Meta-" + key
+}
+
+// upload - upload the objects of a batch, with the options of a regular
+// upload. The objects of a failed batch and those which failed to be read
+// or verified are uploaded one by one with upload. Returns the outcome of
+// every object, in the order of the batch.
+func (batch *smallBatch) upload(ctx context.Context, opts uploadSourceToTargetURLOpts, upload func(URLs) URLs) []URLs {
+	results := make([]URLs, len(batch.urls))
+	failed := make([]bool, len(batch.urls))
+
+	openCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make(chan minio.SnowballObject)
+	go func() {
+		defer close(objects)
+		for i, urls := range batch.urls {
+			sourcePath := filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+			reader, content, err := getSourceStream(openCtx, urls.SourceAlias, urls.SourceContent.URL.String(), getSourceOpts{
+				GetOptions: GetOptions{
+					VersionID: urls.SourceContent.VersionID,
+					SSE:       getSSE(sourcePath, opts.encKeyDB[urls.SourceAlias]),
+					Preserve:  opts.preserve,
+				},
+			})
+			if err != nil {
+				failed[i] = true
+				continue
+			}
+			if content.Size < 0 {
+				// Compressed content of an unknown size.
+				reader.Close()
+				failed[i] = true
+				continue
+			}
+
+			metadata := make(map[string]string, len(content.Metadata))
+			for k, v := range content.Metadata {
+				metadata[http.CanonicalHeaderKey(k)] = v
+			}
+			delete(metadata, "X-Amz-Storage-Class")
+			for k, v := range urls.TargetContent.Metadata {
+				metadata[http.CanonicalHeaderKey(k)] = v
+			}
+			for k, v := range urls.TargetContent.UserMetadata {
+				metadata[http.CanonicalHeaderKey(k)] = v
+			}
+			if content.Tags != nil {
+				if t, e := tags.NewTags(content.Tags, true); e == nil {
+					metadata["X-Amz-Tagging"] = t.String()
+				}
+				delete(metadata, "X-Amz-Tagging-Count")
+			}
+			if storageClass := urls.TargetContent.StorageClass; storageClass != "" {
+				metadata["X-Amz-Storage-Class"] = strings.ToUpper(storageClass)
+			}
+			headers := make(http.Header, len(metadata))
+			for k, v := range filterMetadata(metadata) {
+				headers.Set(snowballHeader(k), v)
+			}
+
+			object := minio.SnowballObject{
+				Key:     batch.keys[i],
+				Size:    content.Size,
+				Content: reader,
+				Headers: headers,
+				Close:   func() { reader.Close() },
+			}
+			select {
+			case objects <- object:
+			case <-openCtx.Done():
+				reader.Close()
+				return
+			}
+		}
+	}()
+
+	err := batch.clnt.PutSnowball(openCtx, objects)
+	cancel()
+	for object := range objects {
+		// Close the objects opened before the upload failed.
+		object.Close()
+	}
+
+	for i, urls := range batch.urls {
+		if err != nil || failed[i] {
+			results[i] = upload(urls)
+			continue
+		}
+		if opts.verify {
+			sourcePath := filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+			targetPath := filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+			var verr *probe.Error
+			urls.verify, verr = verifyCopy(ctx, urls,
+				getSSE(sourcePath, opts.encKeyDB[urls.SourceAlias]), getSSE(targetPath, opts.encKeyDB[urls.TargetAlias]))
+			if verr != nil {
+				// Copied again, and verified, on its own.
+				results[i] = upload(urls)
+				continue
+			}
+		}
+		// Counted once uploaded, the objects which are uploaded again on
+		// their own are counted by that upload.
+		if opts.progress != nil {
+			io.CopyN(io.Discard, opts.progress, urls.SourceContent.Size)
+		}
+		results[i] = urls.WithError(nil)
+	}
+	return results
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestSmallBatcherBatchable(t *testing.T) {
+	b := &smallBatcher{threshold: 1 << 20}
+	small := func(modify func(*URLs)) URLs {
+		urls := URLs{
+			SourceContent: &ClientContent{URL: *newClientURL("/data/a.txt"), Size: 100},
+			TargetAlias:   "myminio",
+			TargetContent: &ClientContent{URL: *newClientURL("https://minio.example.com/bucket/a.txt")},
+		}
+		if modify != nil {
+			modify(&urls)
+		}
+		return urls
+	}
+
+	testCases := []struct {
+		urls      URLs
+		batchable bool
+	}{
+		{urls: small(nil), batchable: true},
+		{urls: small(func(u *URLs) { u.SourceContent.Size = 1<<20 + 1 })},
+		{urls: small(func(u *URLs) { u.SourceContent.Type = os.ModeDir })},
+		{urls: small(func(u *URLs) { u.SourceAlias = "myminio" })},
+		{urls: small(func(u *URLs) { u.TargetAlias = "" })},
+		{urls: small(func(u *URLs) { u.MD5 = true })},
+		{urls: small(func(u *URLs) { u.checksum = minio.ChecksumSHA256 })},
+		{urls: small(func(u *URLs) { u.TargetContent.RetentionEnabled = true })},
+		{urls: small(func(u *URLs) { u.TargetContent.LegalHoldEnabled = true })},
+		{urls: small(func(u *URLs) { u.MoveFrom = &ClientContent{} })},
+		{urls: small(func(u *URLs) { u.fanOut = []URLs{{}} })},
+		{urls: small(func(u *URLs) { u.TargetContent = nil })},
+	}
+
+	for i, testCase := range testCases {
+		if batchable := b.batchable(testCase.urls); batchable != testCase.batchable {
+			t.Fatalf("Test %d: expected batchable %v, got %v", i+1, testCase.batchable, batchable)
+		}
+	}
+
+	var disabled *smallBatcher
+	if _, ok := disabled.add(context.Background(), small(nil)); ok {
+		t.Fatal("expected no batch without --batch-small")
+	}
+}
+
+func TestSnowballHeader(t *testing.T) {
+	testCases := map[string]string{
+		"content-type":        "Content-Type",
+		"Cache-Control":       "Cache-Control",
+		"x-amz-tagging":       "X-Amz-Tagging",
+		"X-Amz-Meta-Mc-Attrs": "X-Amz-Meta-Mc-Attrs",
+		"key1":                "X-Amz-Meta-Key1",
+	}
+	for key, expected := range testCases {
+		if header := snowballHeader(key); header != expected {
+			t.Fatalf("%s: expected %s, got %s", key, expected, header)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/quick"
+)
+
+// bisyncFingerprint - what is known about one side of an entry
+// at the end of the last successful sync.
+type bisyncFingerprint struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	ETag    string    `json:"etag,omitempty"`
+}
+
+// bisyncEntryV1 - last synced state of an object on both sides.
+type bisyncEntryV1 struct {
+	First  bisyncFingerprint `json:"first"`
+	Second bisyncFingerprint `json:"second"`
+}
+
+// JSON file to persist the listing of the last bisync run.
+type bisyncDBV1 struct {
+	Version string    `json:"version"`
+	First   string    `json:"first"`
+	Second  string    `json:"second"`
+	Updated time.Time `json:"updated"`
+
+	// key is the object name relative to both sync roots.
+	Entries map[string]bisyncEntryV1 `json:"entries"`
+}
+
+// Instantiate a new bisync state database.
+func newBisyncDBV1(first, second string) *bisyncDBV1 {
+	return &bisyncDBV1{
+		Version: "1",
+		First:   first,
+		Second:  second,
+		Entries: make(map[string]bisyncEntryV1),
+	}
+}
+
+// newBisyncFingerprint - fingerprint of a listed object.
+func newBisyncFingerprint(content *ClientContent) bisyncFingerprint {
+	return bisyncFingerprint{
+		Size:    content.Size,
+		ModTime: content.Time.UTC(),
+		ETag:    trimETag(content.ETag),
+	}
+}
+
+// changed - verify if a listed object differs from its fingerprint.
+func (f bisyncFingerprint) changed(content *ClientContent) bool {
+	if f.Size != content.Size || !f.ModTime.Equal(content.Time.UTC()) {
+		return true
+	}
+	etag := trimETag(content.ETag)
+	return f.ETag != "" && etag != "" && f.ETag != etag
+}
+
+// getBisyncDBFile - the state database of a pair of sync roots, the
+// order of the roots matters as entries keep a side for each.
+func getBisyncDBFile(first, second string) (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	sum := sha256.Sum256([]byte(first + "\x00" + second))
+	return filepath.Join(configDir, globalBisyncDir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// loadBisyncDBV1 - load the state of a previous run, a missing
+// database means the roots were never synced before.
+func loadBisyncDBV1(filename, first, second string) (*bisyncDBV1, *probe.Error) {
+	if _, e := os.Stat(filename); e != nil {
+		if os.IsNotExist(e) {
+			return newBisyncDBV1(first, second), nil
+		}
+		return nil, probe.NewError(e).Trace(filename)
+	}
+
+	qs, e := quick.NewConfig(newBisyncDBV1(first, second), nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	if e = qs.Load(filename); e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	db := qs.Data().(*bisyncDBV1)
+	if db.Entries == nil {
+		db.Entries = make(map[string]bisyncEntryV1)
+	}
+	return db, nil
+}
+
+// Save - persist the state database to disk.
+func (db *bisyncDBV1) Save(filename string) *probe.Error {
+	if e := os.MkdirAll(filepath.Dir(filename), 0o700); e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	db.Updated = UTCNow()
+	qs, e := quick.NewConfig(db, nil)
+	if e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	if e = qs.Save(filename); e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	return nil
+}
@@ -0,0 +1,544 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// bisync conflict resolution policies.
+const (
+	bisyncConflictNewer    = "newer"
+	bisyncConflictKeepBoth = "keep-both"
+	bisyncConflictFail     = "fail"
+)
+
+var bisyncFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "conflict",
+		Usage: "resolve objects changed on both sides, one of 'newer', 'keep-both' or 'fail'",
+		Value: bisyncConflictFail,
+	},
+	cli.StringFlag{
+		Name:  "conflict-suffix",
+		Usage: "suffix added to the name of the second version with '--conflict keep-both'",
+		Value: ".conflict",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the changes without applying them",
+	},
+}
+
+// Two-way synchronization of two folders.
+var bisyncCmd = cli.Command{
+	Name:         "bisync",
+	Usage:        "synchronize changes between two folders in both directions",
+	Action:       mainBisync,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(bisyncFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] PATH1 PATH2
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Bisync keeps the listing of both paths at the end of each run in the mc configuration folder.
+  Objects created, modified or removed on one side since the last run are created, modified
+  or removed on the other side. Objects changed on both sides are conflicts, resolved with:
+
+    fail      - report the conflict and leave both versions untouched (default).
+    newer     - the most recently modified version wins, a removal never wins over a modification.
+    keep-both - the version of PATH1 keeps the name, the version of PATH2 is saved on both sides
+                with the conflict suffix inserted before the file extension.
+
+  The first run merges both paths, objects existing on both sides with different content are
+  conflicts.
+
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+EXAMPLES:
+  1. Synchronize a local folder with a bucket in both directions.
+     {{.Prompt}} {{.HelpName}} ~/dataset myminio/dataset
+
+  2. Synchronize in both directions, the most recent version wins on conflicts.
+     {{.Prompt}} {{.HelpName}} --conflict newer ~/dataset myminio/dataset
+
+  3. Synchronize in both directions, keeping both versions on conflicts.
+     {{.Prompt}} {{.HelpName}} --conflict keep-both --conflict-suffix .remote ~/dataset myminio/dataset
+
+  4. Print the changes a synchronization would apply.
+     {{.Prompt}} {{.HelpName}} --dry-run ~/dataset myminio/dataset
+`,
+}
+
+// bisyncOp - action taken on an object to bring both sides in sync.
+type bisyncOp int
+
+const (
+	bisyncInSync bisyncOp = iota
+	bisyncCopyToSecond
+	bisyncCopyToFirst
+	bisyncRemoveFirst
+	bisyncRemoveSecond
+	bisyncKeepBoth
+	bisyncConflict
+)
+
+// bisyncAction - the action planned for an object.
+type bisyncAction struct {
+	op  bisyncOp
+	key string
+}
+
+// bisyncMessage container for bisync messages.
+type bisyncMessage struct {
+	Status    string `json:"status"`
+	Operation string `json:"operation"`
+	Key       string `json:"key"`
+	Source    string `json:"source,omitempty"`
+	Target    string `json:"target,omitempty"`
+}
+
+// String colorized bisync message.
+func (m bisyncMessage) String() string {
+	switch m.Operation {
+	case "copy":
+		return console.Colorize("Bisync", "`"+m.Source+"` -> `"+m.Target+"`")
+	case "remove":
+		return console.Colorize("Bisync", "Removed `"+m.Target+"`.")
+	case "keep-both":
+		return console.Colorize("Bisync", "Kept both versions of `"+m.Key+"`, `"+m.Source+"` -> `"+m.Target+"`")
+	case "conflict":
+		return console.Colorize("BisyncConflict", "Conflict: `"+m.Key+"` changed on both sides.")
+	}
+	return ""
+}
+
+// JSON jsonified bisync message.
+func (m bisyncMessage) JSON() string {
+	m.Status = "success"
+	if m.Operation == "conflict" {
+		m.Status = "error"
+	}
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// bisyncChange - verify if an object changed on one side since the last run.
+func bisyncChange(prior *bisyncFingerprint, content *ClientContent) (changed bool) {
+	switch {
+	case prior == nil:
+		return content != nil
+	case content == nil:
+		return true
+	default:
+		return prior.changed(content)
+	}
+}
+
+// bisyncResolve - resolve an object changed on both sides.
+func bisyncResolve(policy string, first, second *ClientContent) bisyncOp {
+	switch {
+	case policy == bisyncConflictFail:
+		return bisyncConflict
+	case first == nil:
+		// A modification always wins over a removal.
+		return bisyncCopyToFirst
+	case second == nil:
+		return bisyncCopyToSecond
+	case policy == bisyncConflictKeepBoth:
+		return bisyncKeepBoth
+	case first.Time.After(second.Time):
+		return bisyncCopyToSecond
+	default:
+		return bisyncCopyToFirst
+	}
+}
+
+// planBisync - compare the current listings with the last synced state and
+// decide the action of every object, sorted by object name.
+func planBisync(entries map[string]bisyncEntryV1, first, second map[string]*ClientContent, policy string) (actions []bisyncAction) {
+	keys := make(map[string]struct{}, len(entries))
+	for key := range entries {
+		keys[key] = struct{}{}
+	}
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		f, s := first[key], second[key]
+		if f == nil && s == nil {
+			// Removed on both sides.
+			continue
+		}
+
+		var priorFirst, priorSecond *bisyncFingerprint
+		if entry, ok := entries[key]; ok {
+			priorFirst, priorSecond = &entry.First, &entry.Second
+		}
+		firstChanged := bisyncChange(priorFirst, f)
+		secondChanged := bisyncChange(priorSecond, s)
+
+		var op bisyncOp
+		switch {
+		case !firstChanged && !secondChanged:
+			op = bisyncInSync
+		case firstChanged && !secondChanged:
+			op = bisyncCopyToSecond
+			if f == nil {
+				op = bisyncRemoveSecond
+			}
+		case !firstChanged && secondChanged:
+			op = bisyncCopyToFirst
+			if s == nil {
+				op = bisyncRemoveFirst
+			}
+		case f != nil && s != nil && f.Size == s.Size && bisyncSameContent(f, s):
+			// Same change on both sides.
+			op = bisyncInSync
+		default:
+			op = bisyncResolve(policy, f, s)
+		}
+		actions = append(actions, bisyncAction{op: op, key: key})
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].key < actions[j].key
+	})
+	return actions
+}
+
+// bisyncSameContent - whether two objects of the same size are known to
+// have the same content, contents which cannot be compared are conflicts.
+func bisyncSameContent(f, s *ClientContent) bool {
+	differ, known := checksumDiffer(f, s)
+	return known && !differ
+}
+
+// conflictName - insert the conflict suffix before the file extension.
+func conflictName(key, suffix string) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + suffix + ext
+}
+
+// bisyncRoot - one side of a bisync.
+type bisyncRoot struct {
+	alias  string
+	url    string
+	client Client
+}
+
+// newBisyncRoot - expand an aliased folder into a sync root.
+func newBisyncRoot(aliasedURL string) (*bisyncRoot, *probe.Error) {
+	separator := string(newClientURL(aliasedURL).Separator)
+	if !strings.HasSuffix(aliasedURL, separator) {
+		aliasedURL += separator
+	}
+	alias, urlStr, _ := mustExpandAlias(aliasedURL)
+	if alias == "" {
+		// Local folders are identified by their absolute path.
+		if absURL, e := filepath.Abs(urlStr); e == nil {
+			urlStr = absURL + separator
+		}
+	}
+	client, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return nil, err.Trace(aliasedURL)
+	}
+	return &bisyncRoot{alias: alias, url: urlStr, client: client}, nil
+}
+
+// list - the objects under the root, keyed by their name relative to the root.
+func (r *bisyncRoot) list(ctx context.Context) (map[string]*ClientContent, *probe.Error) {
+	contents := make(map[string]*ClientContent)
+	for content := range r.client.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			if _, ok := content.Err.ToGoError().(PathNotFound); ok {
+				// A missing folder is an empty side.
+				continue
+			}
+			return nil, content.Err.Trace(r.url)
+		}
+		if !content.Type.IsRegular() {
+			continue
+		}
+		key := filepath.ToSlash(strings.TrimPrefix(content.URL.String(), r.url))
+		contents[key] = content
+	}
+	return contents, nil
+}
+
+// content - a placeholder for an object under the root.
+func (r *bisyncRoot) content(key string) *ClientContent {
+	return &ClientContent{URL: *newClientURL(urlJoinPath(r.url, key))}
+}
+
+// aliasedPath - the path of an object as typed by the user.
+func (r *bisyncRoot) aliasedPath(key string) string {
+	return filepath.ToSlash(filepath.Join(r.alias, r.content(key).URL.Path))
+}
+
+// bisyncCopy - copy an object from one root to another.
+func bisyncCopy(ctx context.Context, src *bisyncRoot, srcContent *ClientContent, tgt *bisyncRoot, tgtKey string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
+		urls: URLs{
+			SourceAlias:   src.alias,
+			SourceContent: srcContent,
+			TargetAlias:   tgt.alias,
+			TargetContent: tgt.content(tgtKey),
+		},
+		encKeyDB: encKeyDB,
+	})
+	return urls.Error
+}
+
+// bisyncRemove - remove an object under a root.
+func bisyncRemove(ctx context.Context, root *bisyncRoot, key string) *probe.Error {
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- root.content(key)
+	close(contentCh)
+	for result := range removeWithRetry(ctx, root.alias, root.client, false, false, false, false, contentCh) {
+		if result.Err != nil {
+			return result.Err.Trace(key)
+		}
+	}
+	return nil
+}
+
+// applyBisync - apply an action, returns the message describing it.
+func applyBisync(ctx context.Context, action bisyncAction, first, second *bisyncRoot, firstContents, secondContents map[string]*ClientContent, opts bisyncOptions) (bisyncMessage, *probe.Error) {
+	key := action.key
+	switch action.op {
+	case bisyncCopyToSecond:
+		msg := bisyncMessage{Operation: "copy", Key: key, Source: first.aliasedPath(key), Target: second.aliasedPath(key)}
+		if opts.isFake {
+			return msg, nil
+		}
+		return msg, bisyncCopy(ctx, first, firstContents[key], second, key, opts.encKeyDB)
+	case bisyncCopyToFirst:
+		msg := bisyncMessage{Operation: "copy", Key: key, Source: second.aliasedPath(key), Target: first.aliasedPath(key)}
+		if opts.isFake {
+			return msg, nil
+		}
+		return msg, bisyncCopy(ctx, second, secondContents[key], first, key, opts.encKeyDB)
+	case bisyncRemoveFirst:
+		msg := bisyncMessage{Operation: "remove", Key: key, Target: first.aliasedPath(key)}
+		if opts.isFake {
+			return msg, nil
+		}
+		return msg, bisyncRemove(ctx, first, key)
+	case bisyncRemoveSecond:
+		msg := bisyncMessage{Operation: "remove", Key: key, Target: second.aliasedPath(key)}
+		if opts.isFake {
+			return msg, nil
+		}
+		return msg, bisyncRemove(ctx, second, key)
+	case bisyncKeepBoth:
+		renamed := conflictName(key, opts.conflictSuffix)
+		msg := bisyncMessage{Operation: "keep-both", Key: key, Source: second.aliasedPath(key), Target: second.aliasedPath(renamed)}
+		if opts.isFake {
+			return msg, nil
+		}
+		// Save the version of the second side under the conflict name on
+		// both sides, then the version of the first side takes the name.
+		if err := bisyncCopy(ctx, second, secondContents[key], second, renamed, opts.encKeyDB); err != nil {
+			return msg, err
+		}
+		if err := bisyncCopy(ctx, second, secondContents[key], first, renamed, opts.encKeyDB); err != nil {
+			return msg, err
+		}
+		return msg, bisyncCopy(ctx, first, firstContents[key], second, key, opts.encKeyDB)
+	case bisyncConflict:
+		return bisyncMessage{Operation: "conflict", Key: key}, nil
+	}
+	return bisyncMessage{}, nil
+}
+
+// bisyncEntries - the state of the objects synced by the applied actions.
+// An object in sync is recorded as it was compared, the source of a copy as
+// it was read and its target as it is listed after the run. Objects changed
+// during the run differ from their state and are synced by the next run.
+func bisyncEntries(applied []bisyncAction, firstBefore, secondBefore, firstAfter, secondAfter map[string]*ClientContent, conflictSuffix string) map[string]bisyncEntryV1 {
+	entries := make(map[string]bisyncEntryV1, len(applied))
+	record := func(key string, first, second *ClientContent) {
+		if first != nil && second != nil {
+			entries[key] = bisyncEntryV1{First: newBisyncFingerprint(first), Second: newBisyncFingerprint(second)}
+		}
+	}
+	for _, action := range applied {
+		key := action.key
+		switch action.op {
+		case bisyncInSync:
+			record(key, firstBefore[key], secondBefore[key])
+		case bisyncCopyToSecond:
+			record(key, firstBefore[key], secondAfter[key])
+		case bisyncCopyToFirst:
+			record(key, firstAfter[key], secondBefore[key])
+		case bisyncKeepBoth:
+			record(key, firstBefore[key], secondAfter[key])
+			renamed := conflictName(key, conflictSuffix)
+			record(renamed, firstAfter[renamed], secondAfter[renamed])
+		}
+	}
+	return entries
+}
+
+// bisyncOptions - options of a bisync run.
+type bisyncOptions struct {
+	policy         string
+	conflictSuffix string
+	isFake         bool
+	encKeyDB       map[string][]prefixSSEPair
+}
+
+// checkBisyncSyntax - validate all the passed arguments.
+func checkBisyncSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	switch cliCtx.String("conflict") {
+	case bisyncConflictNewer, bisyncConflictKeepBoth, bisyncConflictFail:
+	default:
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("conflict")),
+			"Invalid conflict policy, valid values are 'newer', 'keep-both' and 'fail'.")
+	}
+	if cliCtx.String("conflict") == bisyncConflictKeepBoth && cliCtx.String("conflict-suffix") == "" {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Conflict suffix cannot be empty.")
+	}
+}
+
+// mainBisync is the entry point for bisync command.
+func mainBisync(cliCtx *cli.Context) error {
+	ctx, cancelBisync := context.WithCancel(globalContext)
+	defer cancelBisync()
+
+	checkBisyncSyntax(cliCtx)
+
+	console.SetColor("Bisync", color.New(color.FgGreen, color.Bold))
+	console.SetColor("BisyncConflict", color.New(color.FgRed, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	opts := bisyncOptions{
+		policy:         cliCtx.String("conflict"),
+		conflictSuffix: cliCtx.String("conflict-suffix"),
+		isFake:         cliCtx.Bool("dry-run"),
+		encKeyDB:       encKeyDB,
+	}
+
+	args := cliCtx.Args()
+	first, err := newBisyncRoot(args.Get(0))
+	fatalIf(err.Trace(args...), "Unable to initialize `"+args.Get(0)+"`.")
+	second, err := newBisyncRoot(args.Get(1))
+	fatalIf(err.Trace(args...), "Unable to initialize `"+args.Get(1)+"`.")
+
+	dbFile, err := getBisyncDBFile(first.url, second.url)
+	fatalIf(err.Trace(args...), "Unable to determine the bisync state file.")
+	db, err := loadBisyncDBV1(dbFile, first.url, second.url)
+	fatalIf(err.Trace(dbFile), "Unable to load the bisync state.")
+
+	firstContents, err := first.list(ctx)
+	fatalIf(err.Trace(args.Get(0)), "Unable to list `"+args.Get(0)+"`.")
+	secondContents, err := second.list(ctx)
+	fatalIf(err.Trace(args.Get(1)), "Unable to list `"+args.Get(1)+"`.")
+
+	// Objects whose state must not be recorded as synced, and the actions
+	// applied to the others.
+	unresolved := make(map[string]struct{})
+	var applied []bisyncAction
+	var copied bool
+	var errorDetected bool
+	for _, action := range planBisync(db.Entries, firstContents, secondContents, opts.policy) {
+		if action.op == bisyncInSync {
+			applied = append(applied, action)
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		msg, err := applyBisync(ctx, action, first, second, firstContents, secondContents, opts)
+		if err != nil {
+			errorIf(err.Trace(action.key), "Unable to synchronize `%s`.", action.key)
+			unresolved[action.key] = struct{}{}
+			errorDetected = true
+			continue
+		}
+		switch action.op {
+		case bisyncConflict:
+			unresolved[action.key] = struct{}{}
+			errorDetected = true
+		case bisyncCopyToFirst, bisyncCopyToSecond, bisyncKeepBoth:
+			copied = true
+		}
+		applied = append(applied, action)
+		printMsg(msg)
+	}
+
+	if opts.isFake {
+		return nil
+	}
+
+	if ctx.Err() == nil {
+		// The targets of copies are listed again for their new state.
+		firstAfter, secondAfter := firstContents, secondContents
+		if copied {
+			firstAfter, err = first.list(ctx)
+			fatalIf(err.Trace(args.Get(0)), "Unable to list `"+args.Get(0)+"`.")
+			secondAfter, err = second.list(ctx)
+			fatalIf(err.Trace(args.Get(1)), "Unable to list `"+args.Get(1)+"`.")
+		}
+
+		entries := bisyncEntries(applied, firstContents, secondContents, firstAfter, secondAfter, opts.conflictSuffix)
+		for key := range unresolved {
+			if entry, ok := db.Entries[key]; ok {
+				entries[key] = entry
+			}
+		}
+		db.Entries = entries
+		fatalIf(db.Save(dbFile).Trace(dbFile), "Unable to save the bisync state.")
+	}
+
+	if errorDetected || ctx.Err() != nil {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanBisync(t *testing.T) {
+	synced := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := synced.Add(time.Hour)
+
+	object := func(size int64, modTime time.Time) *ClientContent {
+		return &ClientContent{URL: *newClientURL("https://play.min.io/bucket/object"), Size: size, Time: modTime}
+	}
+	tagged := func(size int64, modTime time.Time, etag string) *ClientContent {
+		content := object(size, modTime)
+		content.ETag = etag
+		return content
+	}
+	entry := bisyncEntryV1{
+		First:  bisyncFingerprint{Size: 1, ModTime: synced},
+		Second: bisyncFingerprint{Size: 1, ModTime: synced},
+	}
+
+	testCases := []struct {
+		name          string
+		prior         bool
+		first, second *ClientContent
+		policy        string
+		op            bisyncOp
+		planned       bool
+	}{
+		{"unchanged", true, object(1, synced), object(1, synced), bisyncConflictFail, bisyncInSync, true},
+		{"created on first", false, object(1, synced), nil, bisyncConflictFail, bisyncCopyToSecond, true},
+		{"created on second", false, nil, object(1, synced), bisyncConflictFail, bisyncCopyToFirst, true},
+		{"modified on first", true, object(2, later), object(1, synced), bisyncConflictFail, bisyncCopyToSecond, true},
+		{"modified on second", true, object(1, synced), object(1, later), bisyncConflictFail, bisyncCopyToFirst, true},
+		{"removed on first", true, nil, object(1, synced), bisyncConflictFail, bisyncRemoveSecond, true},
+		{"removed on second", true, object(1, synced), nil, bisyncConflictFail, bisyncRemoveFirst, true},
+		{"removed on both", true, nil, nil, bisyncConflictFail, bisyncInSync, false},
+		{"modified on both", true, object(2, later), object(3, synced.Add(time.Minute)), bisyncConflictFail, bisyncConflict, true},
+		{"newer first wins", true, object(2, later), object(3, synced.Add(time.Minute)), bisyncConflictNewer, bisyncCopyToSecond, true},
+		{"newer second wins", true, object(2, synced.Add(time.Minute)), object(3, later), bisyncConflictNewer, bisyncCopyToFirst, true},
+		{"modified wins over removed", true, nil, object(3, later), bisyncConflictNewer, bisyncCopyToFirst, true},
+		{"keep both", true, object(2, later), object(3, later), bisyncConflictKeepBoth, bisyncKeepBoth, true},
+		{"created on both with different size", false, object(2, later), object(3, later), bisyncConflictFail, bisyncConflict, true},
+		{"created on both with same content", false, tagged(2, later, "a"), tagged(2, synced, "a"), bisyncConflictFail, bisyncInSync, true},
+		{"created on both with different content", false, tagged(2, later, "a"), tagged(2, synced, "b"), bisyncConflictFail, bisyncConflict, true},
+		// Multipart ETags of an unknown part size cannot be compared.
+		{"created on both without comparable content", false, tagged(2, later, "a-2"), tagged(2, synced, "a-2"), bisyncConflictFail, bisyncConflict, true},
+		{"modified on both without comparable content", true, tagged(2, later, "a-2"), tagged(2, synced.Add(time.Minute), "b-2"), bisyncConflictNewer, bisyncCopyToSecond, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entries := map[string]bisyncEntryV1{}
+			if testCase.prior {
+				entries["key"] = entry
+			}
+			first := map[string]*ClientContent{}
+			if testCase.first != nil {
+				first["key"] = testCase.first
+			}
+			second := map[string]*ClientContent{}
+			if testCase.second != nil {
+				second["key"] = testCase.second
+			}
+
+			var expected []bisyncAction
+			if testCase.planned {
+				expected = []bisyncAction{{op: testCase.op, key: "key"}}
+			}
+			if actions := planBisync(entries, first, second, testCase.policy); !reflect.DeepEqual(actions, expected) {
+				t.Fatalf("expected %v, got %v", expected, actions)
+			}
+		})
+	}
+}
+
+func TestConflictName(t *testing.T) {
+	testCases := []struct {
+		key, expected string
+	}{
+		{"report.csv", "report.conflict.csv"},
+		{"dir/report", "dir/report.conflict"},
+		{"dir.d/report", "dir.d/report.conflict"},
+		{"dir/archive.tar.gz", "dir/archive.tar.conflict.gz"},
+	}
+	for _, testCase := range testCases {
+		if name := conflictName(testCase.key, ".conflict"); name != testCase.expected {
+			t.Errorf("expected %s, got %s", testCase.expected, name)
+		}
+	}
+}
+
+func TestBisyncEntries(t *testing.T) {
+	synced := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	object := func(size int64, modTime time.Time) *ClientContent {
+		return &ClientContent{Size: size, Time: modTime}
+	}
+	entry := func(first, second *ClientContent) bisyncEntryV1 {
+		return bisyncEntryV1{First: newBisyncFingerprint(first), Second: newBisyncFingerprint(second)}
+	}
+
+	// "a" is in sync and edited on the first side during the run, "b" is
+	// copied to the second side and "c" to the first side, "d" is removed
+	// and "e" is not applied.
+	firstBefore := map[string]*ClientContent{"a": object(1, synced), "b": object(2, synced), "e": object(5, synced)}
+	secondBefore := map[string]*ClientContent{"a": object(1, synced), "c": object(3, synced), "d": object(4, synced), "e": object(6, synced)}
+	firstAfter := map[string]*ClientContent{"a": object(10, synced.Add(time.Minute)), "b": object(2, synced), "c": object(3, synced.Add(time.Second)), "e": object(5, synced)}
+	secondAfter := map[string]*ClientContent{"a": object(1, synced), "b": object(2, synced.Add(time.Second)), "c": object(3, synced), "e": object(6, synced)}
+	applied := []bisyncAction{
+		{op: bisyncInSync, key: "a"},
+		{op: bisyncCopyToSecond, key: "b"},
+		{op: bisyncCopyToFirst, key: "c"},
+		{op: bisyncRemoveSecond, key: "d"},
+	}
+	expected := map[string]bisyncEntryV1{
+		"a": entry(firstBefore["a"], secondBefore["a"]),
+		"b": entry(firstBefore["b"], secondAfter["b"]),
+		"c": entry(firstAfter["c"], secondBefore["c"]),
+	}
+	if entries := bisyncEntries(applied, firstBefore, secondBefore, firstAfter, secondAfter, ".conflict"); !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %v, got %v", expected, entries)
+	}
+
+	// Both versions are kept under the conflict name.
+	firstBefore = map[string]*ClientContent{"f": object(7, synced)}
+	secondBefore = map[string]*ClientContent{"f": object(8, synced)}
+	firstAfter = map[string]*ClientContent{"f": object(7, synced), "f.conflict": object(8, synced.Add(time.Second))}
+	secondAfter = map[string]*ClientContent{"f": object(7, synced.Add(time.Second)), "f.conflict": object(8, synced.Add(time.Second))}
+	expected = map[string]bisyncEntryV1{
+		"f":          entry(firstBefore["f"], secondAfter["f"]),
+		"f.conflict": entry(firstAfter["f.conflict"], secondAfter["f.conflict"]),
+	}
+	if entries := bisyncEntries([]bisyncAction{{op: bisyncKeepBoth, key: "f"}}, firstBefore, secondBefore, firstAfter, secondAfter, ".conflict"); !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %v, got %v", expected, entries)
+	}
+}
@@ -68,7 +68,7 @@ var catCmd = cli.Command{
 	Action:       mainCat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(catFlags, encCFlag), globalFlags...),
+	Flags:        append(append(catFlags, encCFlag, encClientFlag, encClientAllowPlaintextFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -101,6 +101,9 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Display an object encrypted on the client from its second MiB on, only that range of the object is downloaded.
+     {{.Prompt}} {{.HelpName}} --enc-client "s3/customer-data/=file:~/.mc/keys/customer.key" --offset 1048576 s3/customer-data/app.log
 `,
 }
 
@@ -113,11 +113,12 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 	if err != nil {
 		return nil, err.Trace(alias, urlStrFull)
 	}
+	setAdminRetryPolicy(alias)
 	return s3Client, nil
 }
 
 func newAnonymousClient(aliasedURL string) (*madmin.AnonymousClient, *probe.Error) {
-	_, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
+	alias, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
 	if err != nil {
 		return nil, err.Trace(aliasedURL)
 	}
@@ -170,6 +171,7 @@ func newAnonymousClient(aliasedURL string) (*madmin.AnonymousClient, *probe.Erro
 		transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
 	}
 	anonClient.SetCustomTransport(transport)
+	setAdminRetryPolicy(alias)
 
 	return anonClient, nil
 }
@@ -221,3 +221,21 @@ type SameFile struct {
 func (e SameFile) Error() string {
 	return fmt.Sprintf("'%s' and '%s' are the same file", e.Source, e.Destination)
 }
+
+// ObjectNameCollision - two source objects are renamed to the same target.
+type ObjectNameCollision struct {
+	First, Second, Target string
+}
+
+func (e ObjectNameCollision) Error() string {
+	return fmt.Sprintf("'%s' and '%s' are renamed to the same object '%s'", e.First, e.Second, e.Target)
+}
+
+// ContentMismatch - the copy of an object differs from its source.
+type ContentMismatch struct {
+	Source, Target string
+}
+
+func (e ContentMismatch) Error() string {
+	return fmt.Sprintf("'%s' does not match the content of '%s'", e.Target, e.Source)
+}
@@ -556,6 +556,102 @@ func (f *fsClient) PutPart(ctx context.Context, reader io.Reader, size int64, pr
 	return f.putN(ctx, reader, size, progress, opts)
 }
 
+// putRanges - create a new file from ranges of a source object fetched
+// concurrently, the file is preallocated and every range is written at
+// its offset.
+func (f *fsClient) putRanges(ctx context.Context, d rangeDownload, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
+	// Extract dir name.
+	objectDir, objectName := filepath.Split(f.PathURL.Path)
+
+	if objectDir != "" {
+		// Create any missing top level directories.
+		if e := os.MkdirAll(objectDir, 0o777); e != nil {
+			err := f.toClientError(e, f.PathURL.Path)
+			return 0, err.Trace(f.PathURL.Path)
+		}
+
+		// Check if object name is empty, it must be an empty directory
+		if objectName == "" {
+			return 0, nil
+		}
+	}
+
+	objectPath := f.PathURL.Path
+
+	if err := checkPathLength(objectPath); err != nil {
+		return 0, err
+	}
+
+	// Write to a temporary file "objectpath/uuid" before commit.
+	objectPartPath := filepath.Join(filepath.Dir(objectPath), uuid.NewString())
+
+	// We cannot resume this operation, then we
+	// should remove any partial download if any.
+	defer os.Remove(objectPartPath)
+
+	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	if e != nil {
+		err := f.toClientError(e, f.PathURL.Path)
+		return 0, err.Trace(f.PathURL.Path)
+	}
+
+	attr := make(map[string]string)
+	if _, ok := opts.metadata[metadataKey]; ok && opts.isPreserve {
+		attr, e = parseAttribute(opts.metadata)
+		if e != nil {
+			tmpFile.Close()
+			return 0, probe.NewError(e)
+		}
+		err := preserveAttributes(tmpFile, attr)
+		if err != nil {
+			console.Println(console.Colorize("Error", fmt.Sprintf("unable to preserve attributes, continuing to copy the content %s\n", err.ToGoError())))
+		}
+	}
+
+	if e = tmpFile.Truncate(d.size); e != nil {
+		tmpFile.Close()
+		return 0, probe.NewError(e)
+	}
+
+	if err := d.writeAt(ctx, tmpFile, progress); err != nil {
+		tmpFile.Close()
+		return 0, err.Trace(objectPath)
+	}
+
+	// Close the file before renaming, we need to do this
+	// specifically for windows users - windows explicitly
+	// disallows renames on Open() fd's by default.
+	if e = tmpFile.Close(); e != nil {
+		return d.size, probe.NewError(e)
+	}
+
+	if d.verify != nil {
+		if err := d.verify(objectPartPath, objectPath); err != nil {
+			return d.size, err.Trace(objectPath)
+		}
+	}
+
+	// Safely completed put. Now commit by renaming to actual filename.
+	if e = os.Rename(objectPartPath, objectPath); e != nil {
+		err := f.toClientError(e, objectPath)
+		return d.size, err.Trace(objectPartPath, objectPath)
+	}
+
+	if len(attr) != 0 && opts.isPreserve {
+		atime, mtime, err := parseAtimeMtime(attr)
+		if err != nil {
+			return d.size, err.Trace()
+		}
+		if !atime.IsZero() && !mtime.IsZero() {
+			if e := os.Chtimes(objectPath, atime, mtime); e != nil {
+				return d.size, probe.NewError(e)
+			}
+		}
+	}
+
+	return d.size, nil
+}
+
 // ShareDownload - share download not implemented for filesystem.
 func (f *fsClient) ShareDownload(_ context.Context, _ string, _ time.Duration) (string, *probe.Error) {
 	return "", probe.NewError(APINotImplemented{
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/minio/mc/pkg/probe"
 	checkv1 "gopkg.in/check.v1"
 )
 
@@ -331,6 +332,48 @@ func (s *TestSuite) TestGetRange(c *checkv1.C) {
 	c.Assert([]byte("hello"), checkv1.DeepEquals, results.Bytes())
 }
 
+// Test a file written from concurrent ranges.
+func (s *TestSuite) TestPutRanges(c *checkv1.C) {
+	root, e := os.MkdirTemp(os.TempDir(), "fs-")
+	c.Assert(e, checkv1.IsNil)
+	defer os.RemoveAll(root)
+
+	objectPath := filepath.Join(root, "object")
+	clnt, err := fsNew(objectPath)
+	c.Assert(err, checkv1.IsNil)
+	fsClnt := clnt.(*fsClient)
+
+	data := []byte("hello world, this object is downloaded in ranges")
+	d := rangeDownload{
+		size:     int64(len(data)),
+		partSize: 5,
+		threads:  3,
+		first:    bytes.NewReader(data),
+		fetch: func(_ context.Context, offset, length int64) (io.ReadCloser, *probe.Error) {
+			return io.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+		},
+	}
+	n, err := fsClnt.putRanges(context.Background(), d, nil, PutOptions{})
+	c.Assert(err, checkv1.IsNil)
+	c.Assert(n, checkv1.Equals, int64(len(data)))
+
+	results, e := os.ReadFile(objectPath)
+	c.Assert(e, checkv1.IsNil)
+	c.Assert(results, checkv1.DeepEquals, data)
+
+	// A failed verification leaves no file behind.
+	c.Assert(os.Remove(objectPath), checkv1.IsNil)
+	d.first = bytes.NewReader(data)
+	d.verify = func(_, target string) *probe.Error {
+		return probe.NewError(ContentMismatch{Source: "source", Target: target})
+	}
+	_, err = fsClnt.putRanges(context.Background(), d, nil, PutOptions{})
+	c.Assert(err, checkv1.NotNil)
+	entries, e := os.ReadDir(root)
+	c.Assert(e, checkv1.IsNil)
+	c.Assert(len(entries), checkv1.Equals, 0)
+}
+
 // Test stat file.
 func (s *TestSuite) TestStatObject(c *checkv1.C) {
 	root, e := os.MkdirTemp(os.TempDir(), "fs-")
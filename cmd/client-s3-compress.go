@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// Objects compressed by mc keep their codec and the size of their
+// content, when it is known, in their metadata.
+const (
+	compressCodecKey = "X-Amz-Meta-Mc-Compression"
+	compressSizeKey  = "X-Amz-Meta-Mc-Original-Size"
+
+	compressZstd = "zstd"
+	compressGzip = "gzip"
+)
+
+// metadataValue - the value of a metadata key of an object, as returned
+// by a HEAD or GET, or by a listing with its metadata.
+func metadataValue(content *ClientContent, key string) string {
+	if v, ok := content.Metadata[key]; ok {
+		return v
+	}
+	if v, ok := content.UserMetadata[key]; ok {
+		return v
+	}
+	return content.UserMetadata[strings.TrimPrefix(key, "X-Amz-Meta-")]
+}
+
+// hasListedMetadata - the object was listed with its metadata, listings
+// of MinIO have at least the content type of objects while listings of
+// S3 have none.
+func hasListedMetadata(content *ClientContent) bool {
+	return len(content.Metadata) > 0 || len(content.UserMetadata) > 0
+}
+
+// isCompressed - the object was compressed by mc.
+func isCompressed(content *ClientContent) bool {
+	return content != nil && metadataValue(content, compressCodecKey) != ""
+}
+
+// originalSize - the size of the content of an object compressed by mc,
+// false when it was not recorded.
+func originalSize(content *ClientContent) (int64, bool) {
+	size, e := strconv.ParseInt(metadataValue(content, compressSizeKey), 10, 64)
+	return size, e == nil && size >= 0
+}
+
+// contentSize - the size of the content of an object, which is not the
+// size of objects compressed by mc.
+func contentSize(content *ClientContent) int64 {
+	if isCompressed(content) {
+		if size, ok := originalSize(content); ok {
+			return size
+		}
+	}
+	return content.Size
+}
+
+// statContentSize - the size of the content of a listed object, read
+// with a HEAD when the listing has no metadata, true when it was read.
+func statContentSize(ctx context.Context, alias string, content *ClientContent, encKeyDB map[string][]prefixSSEPair) (int64, bool) {
+	if content == nil || content.URL.Type != objectStorage || hasListedMetadata(content) {
+		return 0, false
+	}
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return 0, false
+	}
+	path := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+	st, err := clnt.Stat(ctx, StatOptions{sse: getSSE(path, encKeyDB[alias])})
+	if err != nil {
+		return 0, false
+	}
+	return st.Size, true
+}
+
+// sameContentSize - objects listed with different sizes may have the same
+// content when one of them is compressed by mc. Listings of S3 have no
+// metadata, the objects listed without it are read with a HEAD.
+func sameContentSize(ctx context.Context, srcAlias string, src *ClientContent, tgtAlias string, tgt *ClientContent, encKeyDB map[string][]prefixSSEPair) bool {
+	srcSize, srcRead := statContentSize(ctx, srcAlias, src, encKeyDB)
+	if !srcRead {
+		srcSize = contentSize(src)
+	}
+	tgtSize, tgtRead := statContentSize(ctx, tgtAlias, tgt, encKeyDB)
+	if !tgtRead {
+		tgtSize = contentSize(tgt)
+	}
+	return (srcRead || tgtRead) && srcSize == tgtSize
+}
+
+// compressStream - write the compressed stream of r to w, returns the
+// number of bytes read from r.
+func compressStream(w io.Writer, r io.Reader, codec string) (int64, error) {
+	var encoder io.WriteCloser
+	switch codec {
+	case compressZstd:
+		zw, e := zstd.NewWriter(w)
+		if e != nil {
+			return 0, e
+		}
+		encoder = zw
+	case compressGzip:
+		encoder = gzip.NewWriter(w)
+	default:
+		return 0, fmt.Errorf("unsupported compression %s", codec)
+	}
+	n, e := io.Copy(encoder, r)
+	if e != nil {
+		encoder.Close()
+		return n, e
+	}
+	return n, encoder.Close()
+}
+
+// decompressReader - the content of an object compressed by mc.
+type decompressReader struct {
+	io.Reader
+	decoder io.Closer
+	object  io.Closer
+}
+
+func (d *decompressReader) Close() error {
+	d.decoder.Close()
+	return d.object.Close()
+}
+
+// isDecompressed - r reads the content of an object compressed by mc,
+// which has neither the size nor the checksums of the object.
+func isDecompressed(r io.Reader) bool {
+	_, ok := r.(*decompressReader)
+	return ok
+}
+
+// newDecompressReader - decompress the stream of an object compressed
+// with codec.
+func newDecompressReader(object io.ReadCloser, codec string) (*decompressReader, error) {
+	switch codec {
+	case compressZstd:
+		zr, e := zstd.NewReader(object)
+		if e != nil {
+			return nil, e
+		}
+		return &decompressReader{Reader: zr, decoder: zr.IOReadCloser(), object: object}, nil
+	case compressGzip:
+		gz, e := gzip.NewReader(object)
+		if e != nil {
+			return nil, e
+		}
+		return &decompressReader{Reader: gz, decoder: gz, object: object}, nil
+	}
+	return nil, fmt.Errorf("unsupported compression %s", codec)
+}
+
+// isCompressedRange - err may be the failure to read a range of the
+// content of a compressed object beyond the end of the object.
+func isCompressedRange(opts GetOptions, err *probe.Error) bool {
+	return opts.RangeStart > 0 && minio.ToErrorResponse(err.ToGoError()).StatusCode == http.StatusRequestedRangeNotSatisfiable
+}
+
+// getCompressed - decompress an object compressed by mc, read as it is
+// stored by reader, or the failure to read a range of it. Compressed
+// streams are read from their start, ranges skip the content before them.
+func (c *S3Client) getCompressed(ctx context.Context, reader io.ReadCloser, content *ClientContent, rangeErr *probe.Error, opts GetOptions) (io.ReadCloser, *ClientContent, *probe.Error) {
+	if opts.PartNumber > 0 {
+		reader.Close()
+		return nil, nil, probe.NewError(errors.New("parts of objects compressed by mc cannot be read"))
+	}
+	// Objects encrypted by mc are read from their start when they are
+	// compressed, other ranges are read again from the start.
+	offset, length := opts.RangeStart, opts.RangeLength
+	if _, clientEncryption := opts.SSE.(*clientKey); !clientEncryption && (offset != 0 || length > 0) {
+		if rangeErr == nil {
+			reader.Close()
+			if opts.MatchETag == "" {
+				opts.MatchETag = content.ETag
+			}
+		}
+		opts.RangeStart, opts.RangeLength = 0, 0
+		var err *probe.Error
+		reader, content, err = c.getObject(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rangeErr != nil && !isCompressed(content) {
+			reader.Close()
+			return nil, nil, rangeErr
+		}
+	}
+
+	decompressed, e := newDecompressReader(reader, metadataValue(content, compressCodecKey))
+	if e != nil {
+		reader.Close()
+		return nil, nil, probe.NewError(e)
+	}
+	size, ok := originalSize(content)
+	if !ok {
+		size = -1
+	}
+	if offset > 0 {
+		if _, e = io.CopyN(io.Discard, decompressed.Reader, offset); e != nil {
+			decompressed.Close()
+			if e == io.EOF {
+				e = fmt.Errorf("offset %d is beyond the size of the object", offset)
+			}
+			return nil, nil, probe.NewError(e)
+		}
+		if size >= 0 {
+			size -= offset
+		}
+	}
+	if length > 0 {
+		decompressed.Reader = io.LimitReader(decompressed.Reader, length)
+		if size < 0 || length < size {
+			size = length
+		}
+	}
+
+	// The content is not compressed, the metadata of the compression
+	// must not be copied with it.
+	content.Size = size
+	content.Checksum = nil
+	for _, k := range []string{compressCodecKey, compressSizeKey} {
+		delete(content.Metadata, k)
+		delete(content.UserMetadata, strings.TrimPrefix(k, "X-Amz-Meta-"))
+	}
+	return decompressed, content, nil
+}
+
+// putCompressed - compress and upload an object, the progress is the
+// progress of its content.
+func (c *S3Client) putCompressed(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, *probe.Error) {
+	metadata := make(map[string]string, len(putOpts.metadata)+2)
+	for k, v := range putOpts.metadata {
+		metadata[k] = v
+	}
+	metadata[compressCodecKey] = putOpts.compress
+	delete(metadata, compressSizeKey)
+	if size >= 0 {
+		metadata[compressSizeKey] = strconv.FormatInt(size, 10)
+	}
+
+	// The size of the compressed stream is unknown, it is uploaded in
+	// parts sized for its content rather than for the largest object.
+	if putOpts.multipartSize == 0 && size > 0 {
+		if _, partSize, _, e := minio.OptimalPartInfo(size, 0); e == nil {
+			putOpts.multipartSize = uint64(partSize)
+		}
+	}
+
+	pr, pw := io.Pipe()
+	read := make(chan int64, 1)
+	go func(codec string) {
+		n, e := compressStream(pw, hookreader.NewHook(reader, progress), codec)
+		pw.CloseWithError(e)
+		read <- n
+	}(putOpts.compress)
+
+	putOpts.compress = ""
+	putOpts.metadata = metadata
+	_, err := c.Put(ctx, pr, -1, nil, putOpts)
+	if err != nil {
+		// Stop the compression and wait for it, the caller closes the
+		// source once this returns.
+		pr.CloseWithError(err.ToGoError())
+		<-read
+		return 0, err
+	}
+	pr.Close()
+	return <-read, nil
+}
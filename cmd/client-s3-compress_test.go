@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCompressStream(t *testing.T) {
+	data := []byte(strings.Repeat("2025-01-01T00:00:00Z INFO request served\n", 10000))
+	for _, codec := range []string{compressZstd, compressGzip} {
+		var compressed bytes.Buffer
+		n, e := compressStream(&compressed, bytes.NewReader(data), codec)
+		if e != nil {
+			t.Fatalf("%s: %v", codec, e)
+		}
+		if n != int64(len(data)) {
+			t.Fatalf("%s: expected %d bytes read, got %d", codec, len(data), n)
+		}
+		if compressed.Len() >= len(data)/10 {
+			t.Fatalf("%s: expected the logs to compress 10x, got %d bytes of %d", codec, compressed.Len(), len(data))
+		}
+
+		r, e := newDecompressReader(io.NopCloser(&compressed), codec)
+		if e != nil {
+			t.Fatalf("%s: %v", codec, e)
+		}
+		if !isDecompressed(r) {
+			t.Fatalf("%s: expected a decompressed stream", codec)
+		}
+		content, e := io.ReadAll(r)
+		r.Close()
+		if e != nil {
+			t.Fatalf("%s: %v", codec, e)
+		}
+		if !bytes.Equal(content, data) {
+			t.Fatalf("%s: decompressed content differs", codec)
+		}
+	}
+
+	if _, e := compressStream(io.Discard, strings.NewReader("data"), "lz4"); e == nil {
+		t.Fatal("expected an error with an unsupported codec")
+	}
+	if _, e := newDecompressReader(io.NopCloser(strings.NewReader("data")), "lz4"); e == nil {
+		t.Fatal("expected an error with an unsupported codec")
+	}
+}
+
+func TestContentSize(t *testing.T) {
+	testCases := []struct {
+		content *ClientContent
+		size    int64
+	}{
+		// Not compressed.
+		{&ClientContent{Size: 100}, 100},
+		{&ClientContent{Size: 100, Metadata: map[string]string{compressSizeKey: "1000"}}, 100},
+		// HEAD and GET.
+		{&ClientContent{Size: 100, Metadata: map[string]string{compressCodecKey: "zstd", compressSizeKey: "1000"}}, 1000},
+		// Listings with metadata.
+		{&ClientContent{Size: 100, UserMetadata: map[string]string{compressCodecKey: "zstd", compressSizeKey: "1000"}}, 1000},
+		{&ClientContent{Size: 100, UserMetadata: map[string]string{"Mc-Compression": "gzip", "Mc-Original-Size": "1000"}}, 1000},
+		// Compressed without a known size.
+		{&ClientContent{Size: 100, Metadata: map[string]string{compressCodecKey: "zstd"}}, 100},
+		{&ClientContent{Size: 100, Metadata: map[string]string{compressCodecKey: "zstd", compressSizeKey: "-1"}}, 100},
+	}
+	for i, testCase := range testCases {
+		if size := contentSize(testCase.content); size != testCase.size {
+			t.Fatalf("Test %d: expected size %d, got %d", i+1, testCase.size, size)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/secure-io/sio-go"
+)
+
+// clientEncReadSize - decrypted ranges are read in chunks of this size,
+// a multiple of the fragments of the stream.
+const clientEncReadSize = 64 * sio.BufSize
+
+// errNotClientEncrypted - anyone allowed to write to the bucket could
+// replace an encrypted object with another content, objects which are not
+// encrypted by mc are only read when it is allowed.
+var errNotClientEncrypted = errors.New("object is not encrypted on the client, use --enc-client-allow-plaintext to read it as it is")
+
+// getEncrypted - get an object encrypted by mc and decrypt it.
+func (c *S3Client) getEncrypted(ctx context.Context, key *clientKey, opts GetOptions) (io.ReadCloser, *ClientContent, *probe.Error) {
+	opts.SSE = nil
+	if opts.PartNumber > 0 {
+		return nil, nil, probe.NewError(errors.New("parts of objects encrypted on the client cannot be read"))
+	}
+	bucket, object := c.url2BucketAndObject()
+	associatedData := clientEncAssociatedData(bucket, object)
+
+	if opts.RangeStart == 0 && opts.RangeLength == 0 {
+		reader, content, err := c.getObject(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		stream, e := key.objectStream(content.Metadata, associatedData)
+		if e == nil && stream == nil && !key.allowPlaintext {
+			e = errNotClientEncrypted
+		}
+		if e != nil {
+			reader.Close()
+			return nil, nil, probe.NewError(e)
+		}
+		if stream == nil {
+			return reader, content, nil
+		}
+		decryptedContent(content)
+		return struct {
+			io.Reader
+			io.Closer
+		}{stream.DecryptReader(reader, clientEncNonce(stream), associatedData), reader}, content, nil
+	}
+
+	// Ranges need the metadata and the size of the encrypted object.
+	content, err := c.getObjectStat(ctx, bucket, object, minio.StatObjectOptions{VersionID: opts.VersionID})
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, e := key.objectStream(content.Metadata, associatedData)
+	if e == nil && stream == nil && !key.allowPlaintext {
+		e = errNotClientEncrypted
+	}
+	if e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+	if stream == nil {
+		return c.getObject(ctx, opts)
+	}
+	if isCompressed(content) {
+		// Compressed content is decompressed from its start.
+		opts.RangeStart, opts.RangeLength = 0, 0
+		return c.getEncrypted(ctx, key, opts)
+	}
+
+	encryptedSize := content.Size
+	decryptedContent(content)
+	offset, length := opts.RangeStart, content.Size-opts.RangeStart
+	if opts.RangeLength > 0 {
+		length = min(length, opts.RangeLength)
+	}
+	if length < 0 {
+		return nil, nil, probe.NewError(fmt.Errorf("offset %d is beyond the size %d of the object", offset, content.Size))
+	}
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), content, nil
+	}
+
+	start, end := encryptedRange(offset, length, encryptedSize)
+	opts.RangeStart, opts.RangeLength = start, end-start
+	if opts.MatchETag == "" {
+		opts.MatchETag = content.ETag
+	}
+	reader, _, err := c.getObject(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{decryptRange(stream, associatedData, reader, start, offset, length), reader}, content, nil
+}
+
+// encryptedRange - the range of an encrypted object of encryptedSize
+// bytes with length bytes of content from offset. It starts with the
+// fragment of the first byte, fragments are authenticated one by one.
+// sio reads the fragment after the last one when a read ends with a
+// fragment, and a byte more to tell it is not the final fragment.
+func encryptedRange(offset, length, encryptedSize int64) (start, end int64) {
+	start = offset / sio.BufSize * clientEncFragment
+	end = min(((offset+length-1)/sio.BufSize+2)*clientEncFragment+1, encryptedSize)
+	return start, end
+}
+
+// decryptRange - the length bytes of content from offset of the range
+// of an encrypted object read by r from start.
+func decryptRange(stream *sio.Stream, associatedData []byte, r io.Reader, start, offset, length int64) io.Reader {
+	decrypted := stream.DecryptReaderAt(&streamReaderAt{r: r, offset: start}, clientEncNonce(stream), associatedData)
+	return bufio.NewReaderSize(io.NewSectionReader(decrypted, offset, length), clientEncReadSize)
+}
+
+// putEncrypted - encrypt and upload an object, the progress is the
+// progress of its content.
+func (c *S3Client) putEncrypted(ctx context.Context, key *clientKey, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	encrypted, encryptedSize, metadata, e := key.encrypt(hookreader.NewHook(reader, progress), size, clientEncAssociatedData(bucket, object))
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+
+	for k, v := range putOpts.metadata {
+		if _, ok := metadata[k]; !ok {
+			metadata[k] = v
+		}
+	}
+	putOpts.sse = nil
+	putOpts.metadata = metadata
+	n, err := c.Put(ctx, encrypted, encryptedSize, nil, putOpts)
+	return decryptedSize(n), err
+}
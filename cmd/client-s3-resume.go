@@ -0,0 +1,273 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	// absMinPartSize - the smallest part size S3 accepts for all but the last part.
+	absMinPartSize = 5 * 1024 * 1024
+
+	// maxPartsCount - the maximum number of parts of a multipart upload.
+	maxPartsCount = 10000
+)
+
+// canResumeUpload - an upload can be resumed part by part only when the
+// source can be read at arbitrary offsets and needs more than one part.
+func canResumeUpload(reader io.Reader, size int64, putOpts PutOptions) bool {
+	if !putOpts.resumeMultipart || putOpts.disableMultipart || putOpts.ifNotExists {
+		return false
+	}
+	// Trailing checksums are computed over the whole stream by minio-go.
+	if putOpts.checksum.IsSet() {
+		return false
+	}
+	if !isReadAt(reader) {
+		return false
+	}
+	totalParts, _, _, e := minio.OptimalPartInfo(size, putOpts.multipartSize)
+	return e == nil && totalParts > 1
+}
+
+// partLength - returns the length of a part number for an object
+// split in parts of partSize, 0 if the part is out of range.
+func partLength(size, partSize int64, partNumber int) int64 {
+	offset := int64(partNumber-1) * partSize
+	if partNumber < 1 || offset >= size {
+		return 0
+	}
+	return min(partSize, size-offset)
+}
+
+// findIncompleteUpload - returns the most recent incomplete upload ID of object.
+func (c *S3Client) findIncompleteUpload(ctx context.Context, bucket, object string) (uploadID string, e error) {
+	var latest minio.ObjectMultipartInfo
+	for info := range c.api.ListIncompleteUploads(ctx, bucket, object, false) {
+		if info.Err != nil {
+			return "", info.Err
+		}
+		if info.Key != object {
+			continue
+		}
+		if latest.UploadID == "" || info.Initiated.After(latest.Initiated) {
+			latest = info
+		}
+	}
+	return latest.UploadID, nil
+}
+
+// listUploadedParts - returns all parts uploaded so far for uploadID.
+func (c *S3Client) listUploadedParts(ctx context.Context, bucket, object, uploadID string) (map[int]minio.ObjectPart, error) {
+	core := minio.Core{Client: c.api}
+	parts := make(map[int]minio.ObjectPart)
+	partNumberMarker := 0
+	for {
+		result, e := core.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, 1000)
+		if e != nil {
+			return nil, e
+		}
+		for _, part := range result.ObjectParts {
+			parts[part.PartNumber] = part
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
+// guessPartSize - pick the part size of a previous upload attempt, the
+// candidate which explains most of the uploaded parts wins.
+func guessPartSize(size int64, configuredPartSize int64, parts map[int]minio.ObjectPart) int64 {
+	var largestPart int64
+	for _, part := range parts {
+		largestPart = max(largestPart, part.Size)
+	}
+	candidates := []int64{configuredPartSize, largestPart}
+
+	best, bestMatches := configuredPartSize, -1
+	for _, partSize := range candidates {
+		if partSize < absMinPartSize || (size+partSize-1)/partSize > maxPartsCount {
+			continue
+		}
+		matches := 0
+		for _, part := range parts {
+			if part.Size == partLength(size, partSize, part.PartNumber) {
+				matches++
+			}
+		}
+		if matches > bestMatches {
+			best, bestMatches = partSize, matches
+		}
+	}
+	return best
+}
+
+// isPartUploaded - verify an uploaded part against the local data.
+func isPartUploaded(reader io.ReaderAt, offset, length int64, part minio.ObjectPart) bool {
+	if part.Size != length {
+		return false
+	}
+	h := md5.New()
+	if _, e := io.Copy(h, io.NewSectionReader(reader, offset, length)); e != nil {
+		return false
+	}
+	// Parts of encrypted objects do not have an MD5 ETag, they are uploaded again.
+	return hex.EncodeToString(h.Sum(nil)) == strings.Trim(part.ETag, "\"")
+}
+
+// putObjectResumable - upload a seekable source as a multipart upload which
+// survives interruptions. An incomplete upload of the same object is picked
+// up and only the parts missing or not matching the local data are sent.
+func (c *S3Client) putObjectResumable(ctx context.Context, bucket, object string, reader io.ReaderAt, size int64, progress io.Reader, putOpts PutOptions, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	core := minio.Core{Client: c.api}
+
+	_, partSize, _, e := minio.OptimalPartInfo(size, putOpts.multipartSize)
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+
+	uploaded := map[int]minio.ObjectPart{}
+	uploadID, e := c.findIncompleteUpload(ctx, bucket, object)
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+	if uploadID != "" {
+		if uploaded, e = c.listUploadedParts(ctx, bucket, object, uploadID); e != nil {
+			return minio.UploadInfo{}, e
+		}
+		partSize = guessPartSize(size, partSize, uploaded)
+	} else {
+		if uploadID, e = core.NewMultipartUpload(ctx, bucket, object, opts); e != nil {
+			return minio.UploadInfo{}, e
+		}
+	}
+
+	totalParts := int((size + partSize - 1) / partSize)
+
+	var sse encrypt.ServerSide
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Type() == encrypt.SSEC {
+		sse = opts.ServerSideEncryption
+	}
+
+	threads := int(putOpts.multipartThreads)
+	if threads < 1 {
+		threads = 4
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		firstErr error
+	)
+	setErr := func(e error) {
+		mutex.Lock()
+		if firstErr == nil {
+			firstErr = e
+		}
+		mutex.Unlock()
+	}
+	completed := make([]minio.CompletePart, totalParts)
+	partsCh := make(chan int)
+
+	for range threads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partNumber := range partsCh {
+				offset := int64(partNumber-1) * partSize
+				length := partLength(size, partSize, partNumber)
+
+				if part, ok := uploaded[partNumber]; ok && isPartUploaded(reader, offset, length, part) {
+					// Already on the server, only account for it.
+					if progress != nil {
+						io.CopyN(io.Discard, progress, length)
+					}
+					completed[partNumber-1] = minio.CompletePart{PartNumber: partNumber, ETag: part.ETag}
+					continue
+				}
+
+				var partOpts minio.PutObjectPartOptions
+				partOpts.SSE = sse
+				if opts.SendContentMd5 {
+					h := md5.New()
+					if _, e := io.Copy(h, io.NewSectionReader(reader, offset, length)); e != nil {
+						setErr(e)
+						continue
+					}
+					partOpts.Md5Base64 = base64.StdEncoding.EncodeToString(h.Sum(nil))
+				}
+
+				data := hookreader.NewHook(io.NewSectionReader(reader, offset, length), progress)
+				part, e := core.PutObjectPart(ctx, bucket, object, uploadID, partNumber, data, length, partOpts)
+				if e != nil {
+					setErr(e)
+					continue
+				}
+				completed[partNumber-1] = minio.CompletePart{PartNumber: partNumber, ETag: part.ETag}
+			}
+		}()
+	}
+
+sendParts:
+	for partNumber := 1; partNumber <= totalParts; partNumber++ {
+		mutex.Lock()
+		failed := firstErr != nil
+		mutex.Unlock()
+		if failed {
+			break
+		}
+		select {
+		case partsCh <- partNumber:
+		case <-ctx.Done():
+			break sendParts
+		}
+	}
+	close(partsCh)
+	wg.Wait()
+
+	// The incomplete upload is deliberately kept on failure, so
+	// that the next attempt can resume from the uploaded parts.
+	if firstErr != nil {
+		return minio.UploadInfo{}, firstErr
+	}
+	if e = ctx.Err(); e != nil {
+		return minio.UploadInfo{}, e
+	}
+
+	ui, e := core.CompleteMultipartUpload(ctx, bucket, object, uploadID, completed, minio.PutObjectOptions{
+		ServerSideEncryption: opts.ServerSideEncryption,
+	})
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+	ui.Size = size
+	return ui, nil
+}
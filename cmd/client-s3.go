@@ -883,6 +883,19 @@ func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 
 // Get - get object with GET options.
 func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *ClientContent, *probe.Error) {
+	reader, content, err := c.getObject(ctx, opts)
+	if err == nil && !isCompressed(content) || err != nil && !isCompressedRange(opts, err) {
+		return reader, content, err
+	}
+	return c.getCompressed(ctx, reader, content, err, opts)
+}
+
+// getObject - get an object as it is stored, decrypted when it is
+// encrypted by mc.
+func (c *S3Client) getObject(ctx context.Context, opts GetOptions) (io.ReadCloser, *ClientContent, *probe.Error) {
+	if key, ok := opts.SSE.(*clientKey); ok {
+		return c.getEncrypted(ctx, key, opts)
+	}
 	bucket, object := c.url2BucketAndObject()
 	o := minio.GetObjectOptions{
 		ServerSideEncryption: opts.SSE,
@@ -892,12 +905,21 @@ func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *Cl
 	if opts.Zip {
 		o.Set("x-minio-extract", "true")
 	}
-	if opts.RangeStart != 0 {
-		err := o.SetRange(opts.RangeStart, 0)
+	if opts.RangeStart != 0 || opts.RangeLength > 0 {
+		var rangeEnd int64
+		if opts.RangeLength > 0 {
+			rangeEnd = opts.RangeStart + opts.RangeLength - 1
+		}
+		err := o.SetRange(opts.RangeStart, rangeEnd)
 		if err != nil {
 			return nil, nil, probe.NewError(err)
 		}
 	}
+	if opts.MatchETag != "" {
+		if err := o.SetMatchETag(opts.MatchETag); err != nil {
+			return nil, nil, probe.NewError(err)
+		}
+	}
 	if opts.Preserve {
 		o.Set("X-Amz-Tagging-Directive", "ACCESS")
 	}
@@ -953,6 +975,7 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 		Object:     tokens[2],
 		Encryption: opts.srcSSE,
 		VersionID:  opts.versionID,
+		MatchETag:  opts.matchETag,
 	}
 
 	destOpts := minio.CopyDestOptions{
@@ -982,7 +1005,11 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 
 	// Assign metadata after irrelevant parts are delete above
 	destOpts.UserMetadata = metadata
-	destOpts.ReplaceMetadata = len(metadata) > 0
+	destOpts.ReplaceMetadata = len(metadata) > 0 || opts.replaceMetadata
+	if opts.tags != nil {
+		destOpts.UserTags = opts.tags
+		destOpts.ReplaceTags = true
+	}
 
 	var e error
 	if opts.disableMultipart || opts.size < 64*1024*1024 {
@@ -1022,6 +1049,12 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 	if bucket == "" {
 		return 0, probe.NewError(BucketNameEmpty{})
 	}
+	if putOpts.compress != "" {
+		return c.putCompressed(ctx, reader, size, progress, putOpts)
+	}
+	if key, ok := putOpts.sse.(*clientKey); ok {
+		return c.putEncrypted(ctx, key, reader, size, progress, putOpts)
+	}
 
 	metadata := make(map[string]string, len(putOpts.metadata))
 	for k, v := range putOpts.metadata {
@@ -1128,7 +1161,13 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		opts.SetMatchETagExcept("*")
 	}
 
-	ui, e := c.api.PutObject(ctx, bucket, object, reader, size, opts)
+	var ui minio.UploadInfo
+	var e error
+	if canResumeUpload(reader, size, putOpts) {
+		ui, e = c.putObjectResumable(ctx, bucket, object, reader.(io.ReaderAt), size, progress, putOpts, opts)
+	} else {
+		ui, e = c.api.PutObject(ctx, bucket, object, reader, size, opts)
+	}
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
@@ -1170,6 +1209,26 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 	return ui.Size, nil
 }
 
+// PutSnowball - upload the objects of a channel to the bucket of the
+// client in a single request, a tar archive extracted by the target.
+func (c *S3Client) PutSnowball(ctx context.Context, objects <-chan minio.SnowballObject) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	e := c.api.PutObjectsSnowball(ctx, bucket, minio.SnowballOptions{InMemory: true, Compress: true}, objects)
+	if e != nil {
+		switch minio.ToErrorResponse(e).Code {
+		case "AccessDenied":
+			return probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+		case "NoSuchBucket":
+			return probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		}
+		return probe.NewError(e)
+	}
+	return nil
+}
+
 // PutPart - upload an object with custom metadata. (Same as Put)
 func (c *S3Client) PutPart(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, *probe.Error) {
 	return c.Put(ctx, reader, size, progress, putOpts)
@@ -1291,12 +1350,13 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 					for removeStatus := range statusCh {
 						if removeStatus.Err != nil {
 							resultCh <- RemoveResult{
-								BucketName: bucket,
-								Err:        probe.NewError(removeStatus.Err),
+								BucketName:         prevBucket,
+								RemoveObjectResult: removeStatus,
+								Err:                probe.NewError(removeStatus.Err),
 							}
 						} else {
 							resultCh <- RemoveResult{
-								BucketName:         bucket,
+								BucketName:         prevBucket,
 								RemoveObjectResult: removeStatus,
 							}
 						}
@@ -1337,8 +1397,9 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 						case removeStatus := <-statusCh:
 							if removeStatus.Err != nil {
 								resultCh <- RemoveResult{
-									BucketName: bucket,
-									Err:        probe.NewError(removeStatus.Err),
+									BucketName:         bucket,
+									RemoveObjectResult: removeStatus,
+									Err:                probe.NewError(removeStatus.Err),
 								}
 							} else {
 								resultCh <- RemoveResult{
@@ -1377,7 +1438,9 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 					// it is too generic. We have the object's name and vid.
 					// Adding the object's name and version id into the error msg
 					resultCh <- RemoveResult{
-						Err: probe.NewError(removeStatus.Err),
+						BucketName:         prevBucket,
+						RemoveObjectResult: removeStatus,
+						Err:                probe.NewError(removeStatus.Err),
 					}
 				} else {
 					resultCh <- RemoveResult{
@@ -1661,7 +1724,12 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 	// Start with a HEAD request first to return object metadata information.
 	// If the object is not found, continue to look for a directory marker or a prefix
 	if !strings.HasSuffix(path, string(c.targetURL.Separator)) && opts.timeRef.IsZero() {
+		// Objects encrypted or compressed by mc have the size of their content.
+		_, clientEncryption := opts.sse.(*clientKey)
 		o := minio.StatObjectOptions{ServerSideEncryption: opts.sse, VersionID: opts.versionID}
+		if clientEncryption {
+			o.ServerSideEncryption = nil
+		}
 		if opts.isZip {
 			o.Set("x-minio-extract", "true")
 		}
@@ -1669,6 +1737,16 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 		o.Set("x-amz-checksum-mode", "ENABLED")
 		ctnt, err := c.getObjectStat(ctx, bucket, path, o)
 		if err == nil {
+			if clientEncryption && isClientEncrypted(ctnt) {
+				ctnt.Size = decryptedSize(ctnt.Size)
+				ctnt.Checksum = nil
+			}
+			if isCompressed(ctnt) {
+				if size, ok := originalSize(ctnt); ok {
+					ctnt.Size = size
+				}
+				ctnt.Checksum = nil
+			}
 			return ctnt, nil
 		}
 
@@ -21,10 +21,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/minio/minio-go/v7"
 	checkv1 "gopkg.in/check.v1"
@@ -298,3 +304,105 @@ func (s *TestSuite) TestSelectCompressionType(c *checkv1.C) {
 		c.Assert(cType, checkv1.DeepEquals, test.compressionType)
 	}
 }
+
+// multipartHandler is an http.Handler which keeps the state of a single
+// incomplete multipart upload for resume tests.
+type multipartHandler struct {
+	mutex     sync.Mutex
+	resource  string
+	uploadID  string
+	parts     map[int][]byte
+	etags     map[int]string
+	uploaded  []int
+	completed []byte
+}
+
+func (h *multipartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	query := r.URL.Query()
+	writeXML := func(response string) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write([]byte(response))
+	}
+
+	switch {
+	case r.Method == http.MethodGet && query.Has("location"):
+		writeXML("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+	case r.Method == http.MethodGet && query.Has("uploads"):
+		upload := ""
+		if h.uploadID != "" {
+			upload = "<Upload><Key>object</Key><UploadId>" + h.uploadID + "</UploadId><Initiated>2025-01-01T00:00:00.000Z</Initiated></Upload>"
+		}
+		writeXML("<ListMultipartUploadsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><MaxUploads>1000</MaxUploads><IsTruncated>false</IsTruncated>" + upload + "</ListMultipartUploadsResult>")
+	case r.Method == http.MethodGet && query.Has("uploadId"):
+		parts := ""
+		for partNumber := 1; partNumber <= len(h.parts)+1; partNumber++ {
+			if data, ok := h.parts[partNumber]; ok {
+				parts += fmt.Sprintf("<Part><PartNumber>%d</PartNumber><ETag>\"%s\"</ETag><Size>%d</Size></Part>", partNumber, h.etags[partNumber], len(data))
+			}
+		}
+		writeXML("<ListPartsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><UploadId>" + h.uploadID + "</UploadId><IsTruncated>false</IsTruncated>" + parts + "</ListPartsResult>")
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		data, _ := io.ReadAll(r.Body)
+		sum := md5.Sum(data)
+		h.parts[partNumber] = data
+		h.etags[partNumber] = hex.EncodeToString(sum[:])
+		h.uploaded = append(h.uploaded, partNumber)
+		w.Header().Set("ETag", "\""+h.etags[partNumber]+"\"")
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		h.completed = nil
+		for partNumber := 1; partNumber <= len(h.parts); partNumber++ {
+			h.completed = append(h.completed, h.parts[partNumber]...)
+		}
+		writeXML("<CompleteMultipartUploadResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><ETag>\"3858f62230ac3c915f300c664312c11f-3\"</ETag></CompleteMultipartUploadResult>")
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// Test resuming an incomplete multipart upload.
+func (s *TestSuite) TestResumeMultipartUpload(c *checkv1.C) {
+	const partSize = absMinPartSize
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*partSize+partSize/2)/16)
+
+	file, e := os.CreateTemp(c.MkDir(), "object")
+	c.Assert(e, checkv1.IsNil)
+	defer file.Close()
+	_, e = file.Write(data)
+	c.Assert(e, checkv1.IsNil)
+
+	// The first part was uploaded intact, the second one is corrupted.
+	sum := md5.Sum(data[:partSize])
+	handler := &multipartHandler{
+		resource: "/bucket/object",
+		uploadID: "EXAMPLEJZ6e0YupT2h66iePQCc9IEbYbDUy4RTpMeoSMLPRp8Z5o1u8feSRonpvnWsKKG35tI2LB9VDPiCgTy",
+		parts:    map[int][]byte{1: data[:partSize], 2: make([]byte, partSize)},
+		etags:    map[int]string{1: hex.EncodeToString(sum[:]), 2: "9af2f8218b150c351ad802c6f3d66abe"},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + handler.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	// Signature V2 keeps the part payload free of chunk signatures.
+	conf.Signature = "S3v2"
+	s3c, err := S3New(conf)
+	c.Assert(err, checkv1.IsNil)
+
+	n, err := s3c.Put(context.Background(), file, int64(len(data)), nil, PutOptions{
+		metadata:        map[string]string{},
+		multipartSize:   partSize,
+		resumeMultipart: true,
+	})
+	c.Assert(err, checkv1.IsNil)
+	c.Assert(n, checkv1.Equals, int64(len(data)))
+
+	sort.Ints(handler.uploaded)
+	c.Assert(handler.uploaded, checkv1.DeepEquals, []int{2, 3})
+	c.Assert(bytes.Equal(handler.completed, data), checkv1.Equals, true)
+}
@@ -60,6 +60,11 @@ type GetOptions struct {
 	VersionID  string
 	Zip        bool
 	RangeStart int64
+	// RangeLength limits the read to a number of bytes, 0 reads
+	// until the end. Only supported by object storage.
+	RangeLength int64
+	// MatchETag fails the read if the object has changed.
+	MatchETag  string
 	PartNumber int
 	Preserve   bool
 }
@@ -76,6 +81,8 @@ type PutOptions struct {
 	concurrentStream      bool
 	ifNotExists           bool
 	checksum              minio.ChecksumType
+	resumeMultipart       bool
+	compress              string
 }
 
 // StatOptions holds options of the HEAD operation
@@ -113,12 +120,18 @@ type ListOptions struct {
 // CopyOptions holds options for copying operation
 type CopyOptions struct {
 	versionID        string
+	matchETag        string
 	size             int64
 	srcSSE, tgtSSE   encrypt.ServerSide
 	metadata         map[string]string
 	disableMultipart bool
 	isPreserve       bool
 	storageClass     string
+	// replaceMetadata - replace the metadata of the source even with
+	// none, as copies of an object to itself do.
+	replaceMetadata bool
+	// tags - replace the tags of the source when not nil.
+	tags map[string]string
 }
 
 // Client - client interface
@@ -259,8 +272,9 @@ type Config struct {
 	Lookup            minio.BucketLookupType
 	ConnReadDeadline  time.Duration
 	ConnWriteDeadline time.Duration
-	UploadLimit       int64
-	DownloadLimit     int64
+	UploadLimit       *limiter.Limit
+	DownloadLimit     *limiter.Limit
+	RequestLimit      *limiter.Requests
 	Transport         http.RoundTripper
 }
 
@@ -384,7 +398,10 @@ func (config *Config) initTransport(withS3v2 bool) {
 
 	}
 
-	transport = limiter.New(config.UploadLimit, config.DownloadLimit, transport)
+	// Below the limiters, requests waiting for their turn are not measured.
+	transport = statsTransport{RoundTripper: transport}
+	transport = limiter.NewWithLimits(config.UploadLimit, config.DownloadLimit, transport)
+	transport = limiter.NewRequestLimiter(config.RequestLimit, transport)
 
 	if config.Debug {
 		if strings.EqualFold(config.Signature, "S3v4") {
@@ -353,8 +353,16 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias && !uploadOpts.isZip && !uploadOpts.urls.checksum.IsSet() {
+	// Objects encrypted on the client or compressed on upload are copied through mc.
+	_, srcClientKey := srcSSE.(*clientKey)
+	_, tgtClientKey := tgtSSE.(*clientKey)
+	compress := uploadOpts.urls.compress != ""
+
+	// Optimize for server side copy if the host is same, also under
+	// different aliases of the same cluster.
+	serverSide := !uploadOpts.isZip && !uploadOpts.urls.checksum.IsSet() && !srcClientKey && !tgtClientKey && !compress &&
+		(sourceAlias == targetAlias || sameCluster(ctx, uploadOpts.urls))
+	if serverSide {
 		// preserve new metadata and save existing ones.
 		if uploadOpts.preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, uploadOpts.urls)
@@ -389,11 +397,19 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			disableMultipart: uploadOpts.urls.DisableMultipart,
 			isPreserve:       uploadOpts.preserve,
 			storageClass:     uploadOpts.urls.TargetContent.StorageClass,
+			matchETag:        uploadOpts.urls.matchETag,
 		}
 
 		err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
 			legalHold, length, uploadOpts.progress, opts)
-	} else {
+
+		// The credentials of the target alias may not read the source,
+		// the object is streamed with both instead.
+		if sourceAlias != targetAlias && isAccessDenied(err) {
+			serverSide, err = false, nil
+		}
+	}
+	if !serverSide {
 		if uploadOpts.urls.SourceContent.RetentionEnabled {
 			// preserve new metadata and save existing ones.
 			if uploadOpts.preserve {
@@ -426,18 +442,34 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			reader  io.ReadCloser
 		)
 
-		reader, content, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), getSourceOpts{
+		openSource := getSourceStream
+		if uploadOpts.openSource != nil {
+			openSource = uploadOpts.openSource
+		}
+		reader, content, err = openSource(ctx, sourceAlias, sourceURL.String(), getSourceOpts{
 			GetOptions: GetOptions{
 				VersionID: sourceVersion,
 				SSE:       srcSSE,
 				Zip:       uploadOpts.isZip,
 				Preserve:  uploadOpts.preserve,
+				MatchETag: uploadOpts.urls.matchETag,
 			},
 		})
 		if err != nil {
 			return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
 		}
 		defer reader.Close()
+		if srcClientKey || isDecompressed(reader) {
+			// Listings have the size of the encrypted or compressed object,
+			// the size of a compressed content may be unknown.
+			length = content.Size
+		}
+
+		var download *rangeDownload
+		download, err = newRangeDownload(uploadOpts, content, reader, srcSSE)
+		if err != nil {
+			return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+		}
 
 		if uploadOpts.updateProgressTotal {
 			pg, ok := uploadOpts.progress.(*progressBar)
@@ -506,9 +538,13 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			multipartThreads: uint(multipartThreads),
 			ifNotExists:      uploadOpts.ifNotExists,
 			checksum:         uploadOpts.urls.checksum,
+			resumeMultipart:  uploadOpts.resumeMultipart,
+			compress:         uploadOpts.urls.compress,
 		}
 
-		if isReadAt(reader) || length == 0 {
+		if download != nil {
+			_, err = putTargetRanges(ctx, targetAlias, targetURL.String(), *download, uploadOpts.progress, putOpts)
+		} else if isReadAt(reader) || length <= 0 {
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, reader, length, uploadOpts.progress, putOpts)
 		} else {
@@ -520,6 +556,17 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 		return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
 	}
 
+	if uploadOpts.verify {
+		if uploadOpts.isZip {
+			uploadOpts.urls.verify = verifyUnknown
+		} else {
+			uploadOpts.urls.verify, err = verifyCopy(ctx, uploadOpts.urls, srcSSE, tgtSSE)
+			if err != nil {
+				return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+			}
+		}
+	}
+
 	return uploadOpts.urls.WithError(nil)
 }
 
@@ -587,6 +634,12 @@ type uploadSourceToTargetURLOpts struct {
 	preserve, isZip     bool
 	multipartSize       string
 	multipartThreads    string
+	downloadThreads     string
+	downloadPartSize    string
 	updateProgressTotal bool
 	ifNotExists         bool
+	resumeMultipart     bool
+	verify              bool
+	// openSource opens the source stream, getSourceStream when nil.
+	openSource openSourceFunc
 }
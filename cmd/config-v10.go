@@ -47,6 +47,8 @@ type aliasConfigV10 struct {
 	License      string `json:"license,omitempty"`
 	APIKey       string `json:"apiKey,omitempty"`
 	Src          string `json:"src,omitempty"`
+	RequestLimit string `json:"requestLimit,omitempty"`
+	RetryPolicy  string `json:"retryPolicy,omitempty"`
 }
 
 // configV10 config version.
@@ -101,7 +101,18 @@ var (
 			Name:  "max-workers",
 			Usage: "maximum number of concurrent copies (default: autodetect)",
 		},
+		cli.BoolFlag{
+			Name:  "continue, c",
+			Usage: "create or resume copy session, including interrupted multipart uploads",
+		},
 		checksumFlag,
+		filterFromFlag,
+		renameFlag,
+		fanOutFlag,
+		verifyFlag,
+		manifestFlag,
+		extractFlag,
+		compressFlag,
 	}
 )
 
@@ -121,12 +132,13 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, encFlags...), globalFlags...),
+	Flags:        append(append(append(cpFlags, batchSmallFlags...), encFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --manifest FILE [SOURCE] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -135,6 +147,8 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_DOWNLOAD_PARALLEL: number of concurrent range requests per object downloaded to the local filesystem (default: 1).
+  MC_DOWNLOAD_PART_SIZE: size of each range of a parallel download (default: 64MiB).
 
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -194,6 +208,39 @@ EXAMPLES:
   19. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod&type=backup" ./data/ play/another-bucket/
 
+  20. Copy a folder recursively in a resumable session, run the same command again to continue after an interruption.
+      {{.Prompt}} {{.HelpName}} --continue --recursive play/mybucket/myfolder/ s3/mybucket/
+
+  21. Copy a folder recursively, including and excluding objects with the ordered rules of 'rules.txt' such as '- tmp/' or '+ *.log size>1MiB'.
+      {{.Prompt}} {{.HelpName}} --recursive --filter-from rules.txt ./data/ play/mybucket/
+
+  22. Copy a folder recursively to a new layout, 'logs/2024/01/x.gz' is copied to 'year=2024/month=01/x.gz'.
+      {{.Prompt}} {{.HelpName}} --recursive --rename 's#^logs/([0-9]+)/([0-9]+)/#year=$1/month=$2/#' play/mybucket/ s3/mybucket/
+
+  23. Copy a folder recursively to two more sites, every object is read once from the source.
+      {{.Prompt}} {{.HelpName}} --recursive --fan-out site2/backup/ --fan-out site3/backup/ ./data/ site1/backup/
+
+  24. Download large objects with 8 concurrent range requests each.
+      {{.Prompt}} MC_DOWNLOAD_PARALLEL=8 {{.HelpName}} --recursive play/mybucket/images/ /mnt/images/
+
+  25. Copy a folder recursively with SHA256 checksums and verify every copy against its source.
+      {{.Prompt}} {{.HelpName}} --recursive --checksum SHA256 --verify ./archive/ s3/archive/
+
+  26. Copy the object versions listed in 'keys.csv', rows of 'key,versionId,target,metadata' with optional columns, from a bucket without listing it.
+      {{.Prompt}} {{.HelpName}} --manifest keys.csv play/mybucket s3/mybucket/
+
+  27. Copy a folder of many small files recursively to MinIO, uploading the files under 512KiB in batches.
+      {{.Prompt}} {{.HelpName}} --recursive --batch-small --batch-small-size 512KiB ./thumbnails/ play/mybucket/thumbnails/
+
+  28. Extract a remote tarball under a prefix, every file of the archive is uploaded as an object.
+      {{.Prompt}} {{.HelpName}} --extract --preserve play/uploads/dataset.tar.gz play/datasets/2024/
+
+  29. Copy a folder recursively, encrypting the objects on the client with a key derived from a passphrase.
+      {{.Prompt}} {{.HelpName}} --recursive --enc-client "s3/customer-data/=pass:${PASSPHRASE}" backups/ s3/customer-data/
+
+  30. Copy a folder of logs recursively, compressing the objects with zstd. They are decompressed when copied back.
+      {{.Prompt}} {{.HelpName}} --recursive --compress zstd /var/log/app/ s3/logs/app/
+
 `,
 }
 
@@ -262,7 +309,7 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 		})
 	}
 
-	urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
+	urls := uploadWithRetry(ctx, uploadSourceToTargetURLOpts{
 		urls:                copyOpts.cpURLs,
 		progress:            copyOpts.pg,
 		encKeyDB:            copyOpts.encryptionKeys,
@@ -270,8 +317,13 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 		isZip:               copyOpts.isZip,
 		multipartSize:       copyOpts.multipartSize,
 		multipartThreads:    copyOpts.multipartThreads,
+		downloadThreads:     copyOpts.downloadThreads,
+		downloadPartSize:    copyOpts.downloadPartSize,
 		updateProgressTotal: copyOpts.updateProgressTotal,
 		ifNotExists:         copyOpts.ifNotExists,
+		resumeMultipart:     copyOpts.resumeMultipart,
+		verify:              copyOpts.verify,
+		openSource:          copyOpts.openSource,
 	})
 	if copyOpts.isMvCmd && urls.Error == nil {
 		rmManager.add(ctx, sourceAlias, sourceURL.String())
@@ -280,6 +332,42 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 	return urls
 }
 
+// doCopyBatch - Copy a batch of small files from source to destination in
+// a single upload, the files of a failed batch are copied one by one.
+func doCopyBatch(ctx context.Context, batch *smallBatch, copyOpts doCopyOpts) []URLs {
+	for _, cpURLs := range batch.urls {
+		if _, ok := copyOpts.pg.(*progressBar); ok {
+			break
+		}
+		printMsg(copyMessage{
+			Source:     filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path)),
+			Target:     filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path)),
+			Size:       cpURLs.SourceContent.Size,
+			TotalCount: cpURLs.TotalCount,
+			TotalSize:  cpURLs.TotalSize,
+		})
+	}
+
+	uploadOpts := uploadSourceToTargetURLOpts{
+		progress: copyOpts.pg,
+		encKeyDB: copyOpts.encryptionKeys,
+		preserve: copyOpts.preserve,
+		verify:   copyOpts.verify,
+	}
+	results := batch.upload(ctx, uploadOpts, func(cpURLs URLs) URLs {
+		uploadOpts.urls = cpURLs
+		return uploadWithRetry(ctx, uploadOpts)
+	})
+	if copyOpts.isMvCmd {
+		for _, urls := range results {
+			if urls.Error == nil {
+				rmManager.add(ctx, urls.SourceAlias, urls.SourceContent.URL.String())
+			}
+		}
+	}
+	return results
+}
+
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
 func doCopyFake(cpURLs URLs, pg Progress) URLs {
 	if progressReader, ok := pg.(*progressBar); ok {
@@ -306,10 +394,14 @@ func printCopyURLsError(cpURLs *URLs) {
 	}
 }
 
-func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, encryptionKeys map[string][]prefixSSEPair, isMvCmd bool) error {
-	var isCopied func(string) bool
+func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, encryptionKeys map[string][]prefixSSEPair, isMvCmd bool, session *sessionV8) error {
+	var isCopied func(URLs) bool
 	var totalObjects, totalBytes int64
 
+	if session != nil {
+		isCopied = session.IsCompleted
+	}
+
 	cpURLsCh := make(chan URLs, 10000)
 	errSeen := false
 
@@ -324,6 +416,12 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	}
 	sourceURLs := cli.Args()[:len(cli.Args())-1]
 	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
+	var manifest *manifest
+	if len(sourceURLs) > 0 {
+		manifest = parseManifestFlag(cli, sourceURLs[0])
+	} else {
+		manifest = parseManifestFlag(cli, "")
+	}
 
 	// Check if the target path has object locking enabled
 	withLock, _ := isBucketLockEnabled(ctx, targetURL)
@@ -334,6 +432,20 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	rewind := cli.String("rewind")
 	versionID := cli.String("version-id")
 	md5, checksum := parseChecksum(cli)
+	compress := parseCompress(cli)
+	fanOutTargets := cli.StringSlice("fan-out")
+	var fanOut fanOutStats
+	var expandedFanOut []fanOutTarget
+	_, targetRoot, _ := mustExpandAlias(targetURL)
+	if len(fanOutTargets) > 0 {
+		fanOut = newFanOutStats(append([]string{targetURL}, fanOutTargets...))
+		expandedFanOut = expandFanOutTargets(fanOutTargets)
+	}
+	isVerify := cli.Bool("verify")
+	var verify *verifyStats
+	if isVerify {
+		verify = &verifyStats{}
+	}
 	if withLock {
 		// The Content-MD5 header is required for any request to upload an object with a retention period configured using Amazon S3 Object Lock.
 		md5, checksum = true, minio.ChecksumNone
@@ -341,33 +453,62 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 	go func() {
 		totalBytes := int64(0)
-		opts := prepareCopyURLsOpts{
-			sourceURLs:  sourceURLs,
-			targetURL:   targetURL,
-			isRecursive: isRecursive,
-			encKeyDB:    encryptionKeys,
-			olderThan:   olderThan,
-			newerThan:   newerThan,
-			timeRef:     parseRewindFlag(rewind),
-			versionID:   versionID,
-			isZip:       cli.Bool("zip"),
+		listingErr := false
+
+		var urlsCh <-chan URLs
+		if session != nil && session.Header.Planned {
+			// The listing of this session has completed before,
+			// replay the journaled URLs instead of listing again.
+			urlsCh = session.PlannedURLs(ctx)
+		} else if manifest != nil {
+			urlsCh = prepareManifestCopyURLs(ctx, *manifest, targetURL, encryptionKeys)
+		} else {
+			urlsCh = prepareCopyURLs(ctx, prepareCopyURLsOpts{
+				sourceURLs:  sourceURLs,
+				targetURL:   targetURL,
+				isRecursive: isRecursive,
+				encKeyDB:    encryptionKeys,
+				olderThan:   olderThan,
+				newerThan:   newerThan,
+				timeRef:     parseRewindFlag(rewind),
+				versionID:   versionID,
+				isZip:       cli.Bool("zip"),
+				filter:      parseFilterFromFlag(cli),
+				rename:      parseRenameFlag(cli),
+			})
 		}
 
-		for cpURLs := range prepareCopyURLs(ctx, opts) {
-			if cpURLs.Error != nil {
-				errSeen = true
+		for cpURLs := range urlsCh {
+			// The objects of a manifest which cannot be copied fail on their own.
+			if cpURLs.Error != nil && (manifest == nil || cpURLs.SourceContent == nil) {
+				errSeen, listingErr = true, true
 				printCopyURLsError(&cpURLs)
 				break
 			}
 
-			totalBytes += cpURLs.SourceContent.Size
+			if session != nil {
+				if err := session.AddPlanned(cpURLs); err != nil {
+					errSeen, listingErr = true, true
+					errorIf(err.Trace(session.SessionID), "Unable to save session.")
+					break
+				}
+			}
+
+			// Every target of --fan-out is accounted for.
+			totalBytes += cpURLs.SourceContent.Size * int64(1+len(fanOutTargets))
 			pg.SetTotal(totalBytes)
 			totalObjects++
 			cpURLsCh <- cpURLs
 		}
+		if session != nil && !listingErr && ctx.Err() == nil {
+			errorIf(session.SetPlanned(totalObjects, totalBytes).Trace(session.SessionID), "Unable to save session.")
+		}
 		close(cpURLsCh)
 	}()
 
+	batcher, err := newSmallBatcher(cli, encryptionKeys)
+	fatalIf(err, "Unable to parse --batch-small-size.")
+
 	quitCh := make(chan struct{})
 	statusCh := make(chan URLs)
 	parallel := newParallelManager(statusCh, cli.Int("max-workers"))
@@ -377,6 +518,21 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 			parallel.stopAndWait()
 			close(statusCh)
 		}
+		queueBatch := func(batch *smallBatch) {
+			parallel.queueTask(func() URLs {
+				results := doCopyBatch(ctx, batch, doCopyOpts{
+					pg:             pg,
+					encryptionKeys: encryptionKeys,
+					isMvCmd:        isMvCmd,
+					preserve:       cli.Bool("preserve"),
+					verify:         isVerify,
+				})
+				for _, urls := range results[1:] {
+					statusCh <- urls
+				}
+				return results[0]
+			}, batch.size)
+		}
 
 		for {
 			select {
@@ -385,6 +541,11 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				return
 			case cpURLs, ok := <-cpURLsCh:
 				if !ok {
+					if batcher != nil {
+						for _, batch := range batcher.flush() {
+							queueBatch(batch)
+						}
+					}
 					gracefulStop()
 					return
 				}
@@ -398,8 +559,10 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				// Initialize target metadata.
 				cpURLs.TargetContent.Metadata = make(map[string]string)
 
-				// Initialize target user metadata.
-				cpURLs.TargetContent.UserMetadata = make(map[string]string)
+				// Initialize target user metadata, unless set by a manifest.
+				if cpURLs.TargetContent.UserMetadata == nil {
+					cpURLs.TargetContent.UserMetadata = make(map[string]string)
+				}
 
 				// Check and handle storage class if passed in command line args
 				if storageClass := cli.String("storage-class"); storageClass != "" {
@@ -433,23 +596,48 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 				cpURLs.MD5 = md5
 				cpURLs.checksum = checksum
+				cpURLs.compress = compress
 				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
 
-				// Verify if previously copied, notify progress bar.
-				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
+				if len(fanOutTargets) > 0 {
+					// Copy to all targets, reading the source once.
+					cpURLs.fanOut = fanOutURLs(cpURLs, targetRoot, expandedFanOut)[1:]
+					parallel.queueTask(func() URLs {
+						results := doCopyFanOut(ctx, doCopyOpts{
+							cpURLs:         cpURLs,
+							pg:             pg,
+							encryptionKeys: encryptionKeys,
+							preserve:       preserve,
+							isZip:          isZip,
+							verify:         isVerify,
+						})
+						for _, urls := range results[1:] {
+							statusCh <- urls
+						}
+						return results[0]
+					}, cpURLs.SourceContent.Size)
+				} else if isCopied != nil && isCopied(cpURLs) {
+					// Verify if previously copied, notify progress bar.
 					parallel.queueTask(func() URLs {
 						return doCopyFake(cpURLs, pg)
 					}, 0)
+				} else if batch, ok := batcher.add(ctx, cpURLs); ok {
+					// Copied with the other small files of its bucket.
+					if batch != nil {
+						queueBatch(batch)
+					}
 				} else {
 					// Print the copy resume summary once in start
 					parallel.queueTask(func() URLs {
 						return doCopy(ctx, doCopyOpts{
-							cpURLs:         cpURLs,
-							pg:             pg,
-							encryptionKeys: encryptionKeys,
-							isMvCmd:        isMvCmd,
-							preserve:       preserve,
-							isZip:          isZip,
+							cpURLs:          cpURLs,
+							pg:              pg,
+							encryptionKeys:  encryptionKeys,
+							isMvCmd:         isMvCmd,
+							preserve:        preserve,
+							isZip:           isZip,
+							resumeMultipart: session != nil,
+							verify:          isVerify,
 						})
 					}, cpURLs.SourceContent.Size)
 				}
@@ -476,8 +664,17 @@ loop:
 			if !ok {
 				break loop
 			}
+			if fanOut != nil {
+				fanOut.add(cpURLs)
+			}
+			if verify != nil {
+				verify.add(cpURLs)
+			}
 			if cpURLs.Error == nil {
 				cpAllFilesErr = false
+				if session != nil {
+					errorIf(session.SetCompleted(cpURLs).Trace(session.SessionID), "Unable to save session.")
+				}
 			} else {
 
 				// Set exit status for any copy error
@@ -488,8 +685,13 @@ loop:
 				if !globalQuiet && !globalJSON {
 					console.Eraseline()
 				}
-				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
-					"Failed to copy `%s`.", cpURLs.SourceContent.URL)
+				if fanOut != nil {
+					errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
+						"Failed to copy `%s` to `%s`.", cpURLs.SourceContent.URL, cpURLs.TargetContent.URL)
+				} else {
+					errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
+						"Failed to copy `%s`.", cpURLs.SourceContent.URL)
+				}
 				if isErrIgnored(cpURLs.Error) {
 					cpAllFilesErr = false
 					continue loop
@@ -534,11 +736,27 @@ loop:
 		}
 	}
 
+	if fanOut != nil {
+		fanOut.print()
+	}
+	if verify != nil {
+		verify.print()
+	}
+
 	// Source has error
 	if errSeen && totalObjects == 0 && retErr == nil {
 		retErr = exitStatus(globalErrorExitStatus)
 	}
 
+	if session != nil {
+		// Keep the session on disk if anything is left to copy.
+		if retErr == nil && globalContext.Err() == nil {
+			errorIf(session.Delete().Trace(session.SessionID), "Unable to clear session.")
+		} else {
+			session.CloseAndPrint()
+		}
+	}
+
 	return retErr
 }
 
@@ -549,6 +767,7 @@ func mainCopy(cliCtx *cli.Context) error {
 
 	checkCopySyntax(cliCtx)
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SessionSaved", color.New(color.FgYellow, color.Bold))
 
 	var err *probe.Error
 
@@ -559,7 +778,79 @@ func mainCopy(cliCtx *cli.Context) error {
 	}
 	fatalIf(err, "SSE Error")
 
-	return doCopySession(ctx, cancelCopy, cliCtx, encryptionKeyMap, false)
+	if cliCtx.Bool("extract") {
+		md5, checksum := parseChecksum(cliCtx)
+		return extractArchive(ctx, extractOpts{
+			sourceURL:        cliCtx.Args().Get(0),
+			targetURL:        cliCtx.Args().Get(1),
+			encKeyDB:         encryptionKeyMap,
+			preserve:         cliCtx.Bool("preserve"),
+			storageClass:     cliCtx.String("storage-class"),
+			md5:              md5,
+			checksum:         checksum,
+			compress:         parseCompress(cliCtx),
+			disableMultipart: cliCtx.Bool("disable-multipart"),
+			maxWorkers:       cliCtx.Int("max-workers"),
+		})
+	}
+
+	var session *sessionV8
+	if cliCtx.Bool("continue") {
+		session, err = newSessionV8("cp", cliCtx)
+		fatalIf(err.Trace(cliCtx.Args()...), "Unable to create session.")
+	}
+
+	return doCopySession(ctx, cancelCopy, cliCtx, encryptionKeyMap, false, session)
+}
+
+// doCopyFanOut - copy an object to its target and to the targets of
+// --fan-out, the source is read once for all of them. The results are
+// in the order of the targets.
+func doCopyFanOut(ctx context.Context, copyOpts doCopyOpts) []URLs {
+	targets := append([]URLs{copyOpts.cpURLs}, copyOpts.cpURLs.fanOut...)
+	targets[0].fanOut = nil
+
+	results := make([]URLs, len(targets))
+	var uploads []URLs
+	var uploadIndex []int
+	for i, urls := range targets {
+		if urls.Error != nil {
+			results[i] = urls.WithError(urls.Error.Trace())
+			continue
+		}
+		uploads = append(uploads, urls)
+		uploadIndex = append(uploadIndex, i)
+	}
+
+	if progressReader, ok := copyOpts.pg.(*progressBar); ok {
+		progressReader.SetCaption(copyOpts.cpURLs.SourceContent.URL.String() + ":")
+	} else {
+		sourcePath := filepath.ToSlash(filepath.Join(copyOpts.cpURLs.SourceAlias, copyOpts.cpURLs.SourceContent.URL.Path))
+		for _, urls := range uploads {
+			printMsg(copyMessage{
+				Source:     sourcePath,
+				Target:     filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+				Size:       urls.SourceContent.Size,
+				TotalCount: urls.TotalCount,
+				TotalSize:  urls.TotalSize,
+			})
+		}
+	}
+
+	uploaded := uploadSourceToTargetURLs(ctx, uploadSourceToTargetURLOpts{
+		progress:         copyOpts.pg,
+		encKeyDB:         copyOpts.encryptionKeys,
+		preserve:         copyOpts.preserve,
+		isZip:            copyOpts.isZip,
+		multipartSize:    copyOpts.multipartSize,
+		multipartThreads: copyOpts.multipartThreads,
+		ifNotExists:      copyOpts.ifNotExists,
+		verify:           copyOpts.verify,
+	}, uploads)
+	for j, i := range uploadIndex {
+		results[i] = uploaded[j]
+	}
+	return results
 }
 
 type doCopyOpts struct {
@@ -570,5 +861,11 @@ type doCopyOpts struct {
 	updateProgressTotal      bool
 	multipartSize            string
 	multipartThreads         string
+	downloadThreads          string
+	downloadPartSize         string
 	ifNotExists              bool
+	resumeMultipart          bool
+	verify                   bool
+	// openSource opens the source stream, getSourceStream when nil.
+	openSource openSourceFunc
 }
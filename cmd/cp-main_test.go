@@ -18,8 +18,13 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/minio/mc/pkg/probe"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -74,3 +79,78 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyRename(t *testing.T) {
+	testCases := []struct {
+		expr, name, renamed string
+		invalid             bool
+	}{
+		{`s#^logs/([0-9]{4})/([0-9]{2})/#year=$1/month=$2/#`, "logs/2024/01/x.gz", "year=2024/month=01/x.gz", false},
+		{`s#^logs/([0-9]{4})/([0-9]{2})/#year=\1/month=\2/#`, "/logs/2024/01/x.gz", "/year=2024/month=01/x.gz", false},
+		{`s#^logs/#archive/#`, "other/logs/x.gz", "other/logs/x.gz", false},
+		{`s/a/b/`, "a/a/a", "b/a/a", false},
+		{`s/a/b/g`, "a/a/a", "b/b/b", false},
+		{`s|\|+|/|g`, "a|b||c", "a/b/c", false},
+		{`s#/[^/]*$##`, "dir/file", "dir", false},
+		{`s#.*##`, "dir/file", "", true},
+		{`s#file#dir/#`, "file", "", true},
+	}
+	for _, testCase := range testCases {
+		rename, e := parseKeyRename(testCase.expr)
+		if e != nil {
+			t.Fatalf("%s: unexpected error: %v", testCase.expr, e)
+		}
+		renamed, e := rename.Apply(testCase.name)
+		if testCase.invalid {
+			if e == nil {
+				t.Errorf("%s: expected an error renaming %s, got %s", testCase.expr, testCase.name, renamed)
+			}
+			continue
+		}
+		if e != nil || renamed != testCase.renamed {
+			t.Errorf("%s: expected %s, got %s (%v)", testCase.expr, testCase.renamed, renamed, e)
+		}
+	}
+
+	for _, expr := range []string{"", "s#a#b", "y#a#b#", "s#a#b#c#", "s#(#b#", "s#a#b#i"} {
+		if _, e := parseKeyRename(expr); e == nil {
+			t.Errorf("expected an error for `%s`", expr)
+		}
+	}
+
+	var noRename *keyRename
+	if renamed, e := noRename.Apply("a/b"); e != nil || renamed != "a/b" {
+		t.Errorf("expected names to be kept without a rename, got %s", renamed)
+	}
+}
+
+func TestCopyRenameCollision(t *testing.T) {
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+
+	source, target := t.TempDir(), t.TempDir()
+	for _, name := range []string{"a-1.log", "a-2.log"} {
+		if e := os.WriteFile(filepath.Join(source, name), []byte(name), 0o644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	rename, e := parseKeyRename(`s#-[0-9]##`)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var collision bool
+	cc := copyURLsContent{sourceURL: source + string(filepath.Separator), targetURL: target}
+	for cpURLs := range prepareCopyURLsTypeC(context.Background(), cc, prepareCopyURLsOpts{isRecursive: true, rename: rename}) {
+		if cpURLs.Error == nil {
+			continue
+		}
+		if _, ok := cpURLs.Error.ToGoError().(ObjectNameCollision); !ok {
+			t.Fatalf("unexpected error: %v", cpURLs.Error)
+		}
+		collision = true
+	}
+	if !collision {
+		t.Fatal("expected the renamed objects to collide")
+	}
+}
@@ -25,6 +25,17 @@ import (
 )
 
 func checkCopySyntax(cliCtx *cli.Context) {
+	if cliCtx.IsSet("manifest") {
+		checkCopyManifestSyntax(cliCtx)
+		return
+	}
+	if cliCtx.Bool("extract") {
+		checkExtractSyntax(cliCtx, []string{
+			"recursive", "version-id", "rewind", "older-than", "newer-than", "zip", "filter-from", "rename",
+			"continue", "fan-out", "verify", "batch-small", "attr", "tags", rdFlag, rmFlag, lhFlag,
+		})
+		return
+	}
 	if len(cliCtx.Args()) < 2 {
 		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
 	}
@@ -57,6 +68,20 @@ func checkCopySyntax(cliCtx *cli.Context) {
 		}
 	}
 
+	for _, fanOutURL := range cliCtx.StringSlice("fan-out") {
+		url := newClientURL(fanOutURL)
+		if url.Host != "" && url.Path == string(url.Separator) {
+			fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Target `%s` does not contain bucket name.", fanOutURL))
+		}
+	}
+	// Rules are matched against the paths of listed objects.
+	if cliCtx.IsSet("filter-from") && !cliCtx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--filter-from` requires `--recursive`.")
+	}
+	if len(cliCtx.StringSlice("fan-out")) > 0 && cliCtx.Bool("continue") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--fan-out` cannot be used with `--continue`.")
+	}
+
 	if cliCtx.String(rdFlag) != "" && cliCtx.String(rmFlag) == "" {
 		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
 	}
@@ -70,3 +95,27 @@ func checkCopySyntax(cliCtx *cli.Context) {
 		fatalIf(errInvalidArgument().Trace(), "Permissions are not preserved on windows platform.")
 	}
 }
+
+// checkCopyManifestSyntax - validate a copy of the objects of a manifest
+// to TARGET, relative to SOURCE when passed.
+func checkCopyManifestSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) < 1 || len(cliCtx.Args()) > 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	parseChecksum(cliCtx)
+
+	for _, flag := range []string{"recursive", "version-id", "rewind", "older-than", "newer-than", "zip", "filter-from", "rename", "continue"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), fmt.Sprintf("`--manifest` cannot be used with `--%s`.", flag))
+		}
+	}
+
+	tgtURL := cliCtx.Args()[len(cliCtx.Args())-1]
+	url := newClientURL(tgtURL)
+	if url.Host != "" && url.Path == string(url.Separator) {
+		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Target `%s` does not contain bucket name.", tgtURL))
+	}
+	if cliCtx.String(rdFlag) != "" && cliCtx.String(rmFlag) == "" || cliCtx.String(rdFlag) == "" && cliCtx.String(rmFlag) != "" {
+		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/text/unicode/norm"
 )
 
 type copyURLsType uint8
@@ -169,14 +170,18 @@ func prepareCopyURLsTypeB(ctx context.Context, cc copyURLsContent, o prepareCopy
 		}
 	}
 	// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
-	return makeCopyContentTypeB(cc)
+	return makeCopyContentTypeB(cc, o.rename)
 }
 
 // makeCopyContentTypeB - CopyURLs content for copying.
-func makeCopyContentTypeB(cc copyURLsContent) URLs {
+func makeCopyContentTypeB(cc copyURLsContent, rename *keyRename) URLs {
 	// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
+	name, e := rename.Apply(filepath.Base(cc.sourceContent.URL.Path))
+	if e != nil {
+		return URLs{Error: probe.NewError(e).Trace(cc.sourceURL)}
+	}
 	targetURLParse := newClientURL(cc.targetURL)
-	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, filepath.Base(cc.sourceContent.URL.Path)))
+	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, name))
 	cc.targetURL = targetURLParse.String()
 	return makeCopyContentTypeA(cc)
 }
@@ -196,6 +201,8 @@ func prepareCopyURLsTypeC(ctx context.Context, cc copyURLsContent, o prepareCopy
 	if err != nil {
 		return returnErrorAndCloseChannel(err.Trace(cc.sourceURL))
 	}
+	// Objects encrypted on the client are listed with the size of their content.
+	sourceAlias, _, _ := mustExpandAlias(cc.sourceURL)
 
 	if cc.targetContent == nil {
 		_, cc.targetContent, err = url2Stat(ctx, url2StatOptions{urlStr: cc.targetURL, versionID: "", fileAttr: false, encKeyDB: o.encKeyDB, timeRef: time.Time{}, isZip: o.isZip, ignoreBucketExistsCheck: false})
@@ -228,6 +235,9 @@ func prepareCopyURLsTypeC(ctx context.Context, cc copyURLsContent, o prepareCopy
 	go func(sourceClient Client, cc copyURLsContent, o prepareCopyURLsOpts, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 
+		// Renamed target objects and their source, to not overwrite
+		// an object copied from another source.
+		renamed := make(map[string]string)
 		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: o.isRecursive, TimeRef: o.timeRef, ShowDir: DirNone, ListZip: o.isZip}) {
 			if sourceContent.Err != nil {
 				// Listing failed.
@@ -240,25 +250,51 @@ func prepareCopyURLsTypeC(ctx context.Context, cc copyURLsContent, o prepareCopy
 				continue
 			}
 
+			if !o.filter.Match(sourceSuffixTypeC(sourceContent.URL, sourceClient.GetURL()), sourceContent) {
+				continue
+			}
+
 			// Clone cc
 			newCC := cc
 			newCC.sourceContent = sourceContent
 			// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
-			copyURLsCh <- makeCopyContentTypeC(newCC, sourceClient.GetURL())
+			cpURLs := makeCopyContentTypeC(newCC, sourceClient.GetURL(), o.rename)
+			if o.rename != nil && cpURLs.Error == nil {
+				target := norm.NFC.String(cpURLs.TargetContent.URL.String())
+				if first, ok := renamed[target]; ok {
+					copyURLsCh <- URLs{Error: probe.NewError(ObjectNameCollision{
+						First:  first,
+						Second: sourceContent.URL.String(),
+						Target: target,
+					})}
+					return
+				}
+				renamed[target] = sourceContent.URL.String()
+			}
+			copyURLsCh <- cpURLs
 		}
-	}(c, cc, o, copyURLsCh)
+	}(withClientEncryption(c, sourceAlias, o.encKeyDB[sourceAlias]), cc, o, copyURLsCh)
 
 	return copyURLsCh
 }
 
-// makeCopyContentTypeC - CopyURLs content for copying.
-func makeCopyContentTypeC(cc copyURLsContent, sourceClientURL ClientURL) URLs {
-	newSourceURL := cc.sourceContent.URL
+// sourceSuffixTypeC - the path of a listed object relative to the parent
+// of the source, or to the source itself when it ends with a separator.
+func sourceSuffixTypeC(sourceURL, sourceClientURL ClientURL) string {
 	pathSeparatorIndex := strings.LastIndex(sourceClientURL.Path, string(sourceClientURL.Separator))
-	newSourceSuffix := filepath.ToSlash(newSourceURL.Path)
+	sourceSuffix := filepath.ToSlash(sourceURL.Path)
 	if pathSeparatorIndex > 1 {
 		sourcePrefix := filepath.ToSlash(sourceClientURL.Path[:pathSeparatorIndex])
-		newSourceSuffix = strings.TrimPrefix(newSourceSuffix, sourcePrefix)
+		sourceSuffix = strings.TrimPrefix(sourceSuffix, sourcePrefix)
+	}
+	return sourceSuffix
+}
+
+// makeCopyContentTypeC - CopyURLs content for copying.
+func makeCopyContentTypeC(cc copyURLsContent, sourceClientURL ClientURL, rename *keyRename) URLs {
+	newSourceSuffix, e := rename.Apply(sourceSuffixTypeC(cc.sourceContent.URL, sourceClientURL))
+	if e != nil {
+		return URLs{Error: probe.NewError(e).Trace(cc.sourceContent.URL.String())}
 	}
 	newTargetURL := urlJoinPath(cc.targetURL, newSourceSuffix)
 	cc.targetURL = newTargetURL
@@ -316,6 +352,8 @@ type prepareCopyURLsOpts struct {
 	versionID               string
 	isZip                   bool
 	ignoreBucketExistsCheck bool
+	filter                  filterRules
+	rename                  *keyRename
 }
 
 type copyURLsContent struct {
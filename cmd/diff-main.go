@@ -32,7 +32,12 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "compare-checksum",
+			Usage: "compare objects of the same size by checksum, local files are hashed",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -53,12 +58,15 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 DESCRIPTION:
-  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents.
+  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents,
+  unless --compare-checksum is specified. Objects of the same size are then compared by their checksums
+  or ETags, local files are read to compute them.
 
 LEGEND:
   < - object is only in source.
   > - object is only in destination.
   ! - newer object is in source.
+  # - object content differs, only with --compare-checksum.
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -66,6 +74,9 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      {{.Prompt}} {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare a local folder with a folder on Amazon S3 cloud storage, including the content of the files.
+     {{.Prompt}} {{.HelpName}} --compare-checksum ~/Photos s3/mybucket/Photos
 `,
 }
 
@@ -96,6 +107,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffMetadata", "! "+d.SecondURL)
 	case differInAASourceMTime:
 		msg = console.Colorize("DiffMMSourceMTime", "! "+d.SecondURL)
+	case differInChecksum:
+		msg = console.Colorize("DiffChecksum", "# "+d.SecondURL)
 	case differInNone:
 		msg = console.Colorize("DiffInNone", "= "+d.FirstURL)
 	default:
@@ -156,7 +169,7 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 }
 
 // doDiffMain runs the diff.
-func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
+func doDiffMain(ctx context.Context, firstURL, secondURL string, compareChecksum bool) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -184,14 +197,20 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 	}
 
 	// Diff first and second urls.
-	for diffMsg := range bucketObjectDifference(ctx, firstClient, secondClient) {
+	uncompared := &compareStats{}
+	for diffMsg := range bucketObjectDifference(ctx, firstClient, secondClient, compareChecksum, uncompared) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
 			continue
 		}
+		if diffMsg.Diff == differInSize && sameContentSize(ctx, firstAlias, diffMsg.firstContent, secondAlias, diffMsg.secondContent, nil) {
+			// Compressed objects listed without their metadata.
+			continue
+		}
 		printMsg(diffMsg)
 	}
+	uncompared.print()
 
 	return nil
 }
@@ -216,10 +235,11 @@ func mainDiff(cliCtx *cli.Context) error {
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMetadata", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMMSourceMTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffChecksum", color.New(color.FgYellow, color.Bold))
 
 	URLs := cliCtx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(ctx, firstURL, secondURL)
+	return doDiffMain(ctx, firstURL, secondURL, cliCtx.Bool("compare-checksum"))
 }
@@ -0,0 +1,321 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/env"
+)
+
+// checksumTypes maps the keys of ClientContent.Checksum to their type.
+var checksumTypes = map[string]minio.ChecksumType{
+	"CRC32":     minio.ChecksumCRC32,
+	"CRC32C":    minio.ChecksumCRC32C,
+	"SHA1":      minio.ChecksumSHA1,
+	"SHA256":    minio.ChecksumSHA256,
+	"CRC64NVME": minio.ChecksumCRC64NVME,
+}
+
+// checksumDiffer - compare the content of two regular files of the same
+// size. Checksums known on both sides are preferred over ETags, local files
+// are hashed to match what the other side provides. known is false when
+// the contents cannot be compared, e.g. objects uploaded with an unknown
+// part size or encrypted objects.
+func checksumDiffer(src, tgt *ClientContent) (differ, known bool) {
+	srcLocal := src.URL.Type == fileSystem
+	tgtLocal := tgt.URL.Type == fileSystem
+
+	switch {
+	case srcLocal && tgtLocal:
+		srcSum, e := hashFile(src.URL.Path, md5.New())
+		if e != nil {
+			return true, true
+		}
+		tgtSum, e := hashFile(tgt.URL.Path, md5.New())
+		if e != nil {
+			return true, true
+		}
+		return !bytes.Equal(srcSum, tgtSum), true
+	case srcLocal || tgtLocal:
+		local, remote := src, tgt
+		if tgtLocal {
+			local, remote = tgt, src
+		}
+		for _, key := range verifyChecksumOrder {
+			value, ok := fullObjectChecksum(remote, key)
+			if !ok {
+				continue
+			}
+			sum, e := hashFile(local.URL.Path, checksumTypes[key].Hasher())
+			if e != nil {
+				return true, true
+			}
+			return base64.StdEncoding.EncodeToString(sum) != value, true
+		}
+		if isEncrypted(remote) {
+			return false, false
+		}
+		equal, known := localETagEqual(local.URL.Path, local.Size, remote.ETag)
+		return known && !equal, known
+	default:
+		for _, key := range verifyChecksumOrder {
+			srcValue, ok := fullObjectChecksum(src, key)
+			if !ok {
+				continue
+			}
+			if tgtValue, ok := fullObjectChecksum(tgt, key); ok {
+				return srcValue != tgtValue, true
+			}
+		}
+		srcETag, tgtETag := trimETag(src.ETag), trimETag(tgt.ETag)
+		if srcETag == "" || tgtETag == "" || isEncrypted(src) || isEncrypted(tgt) {
+			return false, false
+		}
+		// ETags of multipart uploads depend on the part size.
+		if etagPartsCount(srcETag) != 0 || etagPartsCount(tgtETag) != 0 {
+			return false, false
+		}
+		return srcETag != tgtETag, true
+	}
+}
+
+// contentDiffer - checksumDiffer, accounting the target in stats when
+// the contents cannot be compared.
+func contentDiffer(src, tgt *ClientContent, stats *compareStats) bool {
+	differ, known := checksumDiffer(src, tgt)
+	if !known {
+		stats.add(tgt)
+	}
+	return differ
+}
+
+// compareStats - the objects of the same size on both sides whose
+// contents could not be compared by --compare-checksum.
+type compareStats struct {
+	mu  sync.Mutex
+	msg compareMessage
+}
+
+// add - account for an object which could not be compared, nil stats
+// account for nothing.
+func (s *compareStats) add(content *ClientContent) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msg.Uncompared = append(s.msg.Uncompared, content.URL.String())
+}
+
+// count - the number of objects which could not be compared.
+func (s *compareStats) count() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.msg.Uncompared)
+}
+
+// print - show the objects which could not be compared, if any.
+func (s *compareStats) print() {
+	if s == nil {
+		return
+	}
+	console.SetColor("CompareUnknown", color.New(color.FgYellow, color.Bold))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.msg.Uncompared) > 0 {
+		printMsg(s.msg)
+	}
+}
+
+// compareMessage - summary of the objects --compare-checksum could not compare.
+type compareMessage struct {
+	Status     string   `json:"status"`
+	Uncompared []string `json:"uncompared"`
+}
+
+func (m compareMessage) String() string {
+	msg := console.Colorize("CompareUnknown", fmt.Sprintf("Unable to compare the content of %d objects without a comparable checksum, they are considered equal:", len(m.Uncompared)))
+	for _, object := range m.Uncompared {
+		msg += "\n  " + object
+	}
+	return msg
+}
+
+func (m compareMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// trimETag - remove the quotes around an ETag.
+func trimETag(etag string) string {
+	return strings.Trim(etag, "\"")
+}
+
+// etagPartsCount - returns the number of parts encoded in a multipart
+// ETag, 0 for single part ETags.
+func etagPartsCount(etag string) int {
+	_, parts, ok := strings.Cut(etag, "-")
+	if !ok {
+		return 0
+	}
+	n, e := strconv.Atoi(parts)
+	if e != nil {
+		return -1
+	}
+	return n
+}
+
+// hashFile - hash the content of a local file.
+func hashFile(path string, h hash.Hash) ([]byte, error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	if _, e = io.Copy(h, f); e != nil {
+		return nil, e
+	}
+	return h.Sum(nil), nil
+}
+
+// multipartETag - compute the ETag S3 assigns to a file uploaded in parts of partSize.
+func multipartETag(path string, partSize int64) (string, error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return "", e
+	}
+	defer f.Close()
+
+	var parts int
+	whole := md5.New()
+	for {
+		h := md5.New()
+		n, e := io.CopyN(h, f, partSize)
+		if e != nil && e != io.EOF {
+			return "", e
+		}
+		if n == 0 {
+			break
+		}
+		whole.Write(h.Sum(nil))
+		parts++
+		if n < partSize {
+			break
+		}
+	}
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(whole.Sum(nil)), parts), nil
+}
+
+// localETagEqual - compare a local file with an S3 ETag. Multipart ETags
+// are computed for the part sizes mc and common S3 tools upload with,
+// known is false when no such part size explains the number of parts.
+func localETagEqual(path string, size int64, etag string) (equal, known bool) {
+	return localETagMatch(size, etag, func(partSize int64) (string, error) {
+		return localETag(path, partSize)
+	})
+}
+
+// localETag - the ETag of a local file uploaded in parts of partSize, or
+// in a single part when partSize is 0.
+func localETag(path string, partSize int64) (string, error) {
+	if partSize == 0 {
+		sum, e := hashFile(path, md5.New())
+		if e != nil {
+			return "", e
+		}
+		return hex.EncodeToString(sum), nil
+	}
+	return multipartETag(path, partSize)
+}
+
+// localETagMatch - compare the content of size bytes with an S3 ETag,
+// the ETags of the content are computed by localETag for a part size.
+func localETagMatch(size int64, etag string, localETag func(partSize int64) (string, error)) (equal, known bool) {
+	etag = trimETag(etag)
+	if etag == "" {
+		return false, false
+	}
+
+	parts := etagPartsCount(etag)
+	if parts == 0 {
+		sum, e := localETag(0)
+		if e != nil {
+			return false, true
+		}
+		return sum == etag, true
+	}
+
+	for _, partSize := range etagPartSizes(size, parts) {
+		known = true
+		sum, e := localETag(partSize)
+		if e != nil {
+			return false, true
+		}
+		if sum == etag {
+			return true, true
+		}
+	}
+	return false, known
+}
+
+// etagPartSizes - the part sizes mc and common S3 tools upload content of
+// size bytes with, which result in the given number of parts.
+func etagPartSizes(size int64, parts int) []int64 {
+	var partSizes []int64
+	if v := env.Get("MC_UPLOAD_MULTIPART_SIZE", ""); v != "" {
+		if partSize, e := humanize.ParseBytes(v); e == nil {
+			partSizes = append(partSizes, int64(partSize))
+		}
+	}
+	if _, partSize, _, e := minio.OptimalPartInfo(size, 0); e == nil {
+		partSizes = append(partSizes, partSize)
+	}
+	// Default part size of the AWS CLI.
+	partSizes = append(partSizes, 8*humanize.MiByte)
+
+	var matching []int64
+	for _, partSize := range partSizes {
+		if partSize > 0 && (size+partSize-1)/partSize == int64(parts) {
+			matching = append(matching, partSize)
+		}
+	}
+	return matching
+}
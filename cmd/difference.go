@@ -42,6 +42,7 @@ const (
 	differInFirst                    // only in source (FIRST)
 	differInSecond                   // only in target (SECOND)
 	differInAASourceMTime            // differs in active-active source modtime
+	differInChecksum                 // differs in content checksum
 )
 
 func (d differType) String() string {
@@ -54,6 +55,8 @@ func (d differType) String() string {
 		return "metadata"
 	case differInAASourceMTime:
 		return "mm-source-mtime"
+	case differInChecksum:
+		return "checksum"
 	case differInType:
 		return "type"
 	case differInFirst:
@@ -161,23 +164,39 @@ func metadataEqual(m1, m2 map[string]string) bool {
 	return true
 }
 
-func bucketObjectDifference(ctx context.Context, sourceClnt, targetClnt Client) (diffCh chan diffMessage) {
+func bucketObjectDifference(ctx context.Context, sourceClnt, targetClnt Client, compareChecksum bool, uncompared *compareStats) (diffCh chan diffMessage) {
 	return objectDifference(ctx, sourceClnt, targetClnt, mirrorOptions{
-		isMetadata: false,
+		isMetadata:      false,
+		compareChecksum: compareChecksum,
+		uncompared:      uncompared,
 	})
 }
 
 func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, opts mirrorOptions) (diffCh chan diffMessage) {
+	// Objects compressed by mc have the size of their content in their
+	// metadata, downloaded sources and compressed targets are listed with it.
+	sourceMetadata := opts.isMetadata ||
+		sourceClnt.GetURL().Type == objectStorage && targetClnt.GetURL().Type == fileSystem
+	targetMetadata := opts.isMetadata ||
+		opts.compress != "" && targetClnt.GetURL().Type == objectStorage
+
 	sourceURL := sourceClnt.GetURL().String()
-	sourceCh := sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.isMetadata, ShowDir: DirNone})
+	sourceCh := sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: sourceMetadata, ShowDir: DirNone})
 
 	targetURL := targetClnt.GetURL().String()
-	targetCh := targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.isMetadata, ShowDir: DirNone})
+	targetCh := targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: targetMetadata, ShowDir: DirNone})
+
+	if opts.rename != nil {
+		sourceCh = renameSourceListing(sourceURL, targetURL, opts.rename, sourceCh)
+	}
 
 	return difference(sourceURL, sourceCh, targetURL, targetCh, opts, false)
 }
 
 func bucketDifference(ctx context.Context, sourceClnt, targetClnt Client, opts mirrorOptions) (diffCh chan diffMessage) {
+	// Bucket names are never renamed.
+	opts.rename = nil
+
 	sourceURL := sourceClnt.GetURL().String()
 	sourceCh := make(chan *ClientContent)
 
@@ -289,6 +308,17 @@ func differenceInternal(sourceURL string,
 		current := urlJoinPath(targetURL, srcSuffix)
 		expected := urlJoinPath(targetURL, tgtSuffix)
 
+		if opts.rename != nil {
+			// Compare the source with its renamed target.
+			renamed, e := opts.rename.Apply(srcSuffix)
+			if e != nil {
+				diffCh <- diffMessage{Error: probe.NewError(e).Trace(srcCtnt.URL.String())}
+				srcCtnt, srcOk = <-srcCh
+				continue
+			}
+			current = urlJoinPath(targetURL, renamed)
+		}
+
 		if !utf8.ValidString(srcSuffix) {
 			// Error. Keys must be valid UTF-8.
 			diffCh <- diffMessage{Error: errInvalidSource(current).Trace()}
@@ -319,7 +349,7 @@ func differenceInternal(sourceURL string,
 		}
 		if normalizedExpected == normalizedCurrent {
 			srcType, tgtType := srcCtnt.Type, tgtCtnt.Type
-			srcSize, tgtSize := srcCtnt.Size, tgtCtnt.Size
+			srcSize, tgtSize := contentSize(srcCtnt), contentSize(tgtCtnt)
 			if srcType.IsRegular() && !tgtType.IsRegular() ||
 				!srcType.IsRegular() && tgtType.IsRegular() {
 				// Type differs. Source is never a directory.
@@ -349,6 +379,15 @@ func differenceInternal(sourceURL string,
 					firstContent:  srcCtnt,
 					secondContent: tgtCtnt,
 				}
+			} else if opts.compareChecksum && contentDiffer(srcCtnt, tgtCtnt, opts.uncompared) {
+				// Regular files of the same size with different content.
+				diffCh <- diffMessage{
+					FirstURL:      srcCtnt.URL.String(),
+					SecondURL:     tgtCtnt.URL.String(),
+					Diff:          differInChecksum,
+					firstContent:  srcCtnt,
+					secondContent: tgtCtnt,
+				}
 			} else if opts.isMetadata &&
 				!metadataEqual(srcCtnt.UserMetadata, tgtCtnt.UserMetadata) &&
 				!metadataEqual(srcCtnt.Metadata, tgtCtnt.Metadata) {
@@ -402,7 +441,7 @@ func difference(sourceURL string, sourceCh <-chan *ClientContent, targetURL stri
 		if err != nil {
 			// handle this specifically for filesystem related errors.
 			switch v := err.ToGoError().(type) {
-			case PathNotFound, PathInsufficientPermission, PathNotADirectory:
+			case PathNotFound, PathInsufficientPermission, PathNotADirectory, ObjectNameCollision:
 				diffCh <- diffMessage{
 					Error: err,
 				}
@@ -18,6 +18,11 @@
 package cmd
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -61,3 +66,205 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestChecksumDiffer(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) *ClientContent {
+		path := filepath.Join(dir, name)
+		if e := os.WriteFile(path, []byte(data), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		return &ClientContent{URL: *newClientURL(path), Size: int64(len(data))}
+	}
+	remote := func(etag string, checksum map[string]string) *ClientContent {
+		return &ClientContent{
+			URL:      *newClientURL("https://play.min.io/bucket/object"),
+			Size:     8,
+			ETag:     etag,
+			Checksum: checksum,
+		}
+	}
+
+	encrypted := func(etag string) *ClientContent {
+		content := remote(etag, nil)
+		content.Metadata = map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}
+		return content
+	}
+
+	first := writeFile("first", "01234567")
+	second := writeFile("second", "01234568")
+	sum := md5.Sum([]byte("01234567"))
+	etag := hex.EncodeToString(sum[:])
+
+	testCases := []struct {
+		src, tgt      *ClientContent
+		differ, known bool
+	}{
+		{first, writeFile("copy", "01234567"), false, true},
+		{first, second, true, true},
+		{first, remote(etag, nil), false, true},
+		{second, remote("\""+etag+"\"", nil), true, true},
+		// CRC32C of "01234567".
+		{first, remote("", map[string]string{"CRC32C": "rCIjIA=="}), false, true},
+		{second, remote("", map[string]string{"CRC32C": "rCIjIA=="}), true, true},
+		// Unknown part layouts cannot be compared.
+		{second, remote(etag+"-3", nil), false, false},
+		{remote(etag, nil), remote(etag, nil), false, true},
+		{remote(etag, nil), remote(etag+"-1", nil), false, false},
+		{remote(etag, nil), remote("9af2f8218b150c351ad802c6f3d66abe", nil), true, true},
+		{remote(etag+"-2", nil), remote("9af2f8218b150c351ad802c6f3d66abe-2", nil), false, false},
+		{remote("", nil), remote(etag, nil), false, false},
+		// The strongest checksum known on both sides is compared.
+		{
+			remote("", map[string]string{"SHA256": "a", "CRC32C": "b", "CRC32": "c"}),
+			remote("", map[string]string{"SHA256": "a", "CRC32C": "x", "CRC32": "y"}), false, true,
+		},
+		{
+			remote("", map[string]string{"SHA256": "a-2", "CRC32C": "b"}),
+			remote("", map[string]string{"SHA256": "a-2", "CRC32C": "x"}), true, true,
+		},
+		// ETags of encrypted objects are not the MD5 sum of their content.
+		{second, encrypted(etag), false, false},
+		{remote(etag, nil), encrypted("9af2f8218b150c351ad802c6f3d66abe"), false, false},
+	}
+	for i, testCase := range testCases {
+		differ, known := checksumDiffer(testCase.src, testCase.tgt)
+		if differ != testCase.differ || known != testCase.known {
+			t.Errorf("Test %d: expected differ %t and known %t, got %t and %t", i+1, testCase.differ, testCase.known, differ, known)
+		}
+	}
+}
+
+func TestCompareStats(t *testing.T) {
+	var stats *compareStats
+	stats.add(&ClientContent{URL: *newClientURL("https://play.min.io/bucket/object")})
+	if count := stats.count(); count != 0 {
+		t.Fatalf("expected nil stats to account for nothing, got %d", count)
+	}
+
+	stats = &compareStats{}
+	src := &ClientContent{URL: *newClientURL("https://play.min.io/bucket/object"), Size: 8, ETag: "a-2"}
+	tgt := &ClientContent{URL: *newClientURL("https://play.min.io/target/object"), Size: 8, ETag: "b-2"}
+	if contentDiffer(src, tgt, stats) {
+		t.Fatal("expected contents which cannot be compared to be considered equal")
+	}
+	if len(stats.msg.Uncompared) != 1 || stats.msg.Uncompared[0] != tgt.URL.String() {
+		t.Fatalf("expected %s to be accounted, got %v", tgt.URL.String(), stats.msg.Uncompared)
+	}
+}
+
+func TestMultipartETag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "object")
+	if e := os.WriteFile(path, []byte("0123456789"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	var parts []byte
+	for _, part := range []string{"0123", "4567", "89"} {
+		sum := md5.Sum([]byte(part))
+		parts = append(parts, sum[:]...)
+	}
+	sum := md5.Sum(parts)
+	expected := hex.EncodeToString(sum[:]) + "-3"
+
+	etag, e := multipartETag(path, 4)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if etag != expected {
+		t.Fatalf("expected %s, got %s", expected, etag)
+	}
+}
+
+func TestMatchRenames(t *testing.T) {
+	dir := t.TempDir()
+	newFile := func(name, data string) URLs {
+		path := filepath.Join(dir, name)
+		if e := os.WriteFile(path, []byte(data), 0o600); e != nil {
+			t.Fatal(e)
+		}
+		return URLs{
+			SourceContent: &ClientContent{URL: *newClientURL(path), Size: int64(len(data)), Type: 0o600},
+			TargetContent: &ClientContent{URL: *newClientURL("https://play.min.io/bucket/new/" + name)},
+		}
+	}
+	object := func(name, data string) *ClientContent {
+		sum := md5.Sum([]byte(data))
+		return &ClientContent{
+			URL:  *newClientURL("https://play.min.io/bucket/old/" + name),
+			Size: int64(len(data)),
+			Type: 0o664,
+			ETag: "\"" + hex.EncodeToString(sum[:]) + "\"",
+		}
+	}
+
+	created := []URLs{
+		newFile("renamed", "hello world"),
+		newFile("copy", "hello world"),
+		newFile("changed", "hello there"),
+		newFile("empty", ""),
+	}
+	vanished := []*ClientContent{
+		object("original", "hello world"),
+		object("other", "hello wrld!"),
+		object("empty", ""),
+	}
+
+	moves, copies, removals := matchRenames(created, vanished)
+	if len(moves) != 1 || moves[0].MoveFrom != vanished[0] || moves[0].SourceContent != created[0].SourceContent {
+		t.Fatalf("expected a single move of the original object, got %v", moves)
+	}
+	if len(copies) != 3 {
+		t.Fatalf("expected 3 copies, got %d", len(copies))
+	}
+	if len(removals) != 2 || removals[0] != vanished[1] || removals[1] != vanished[2] {
+		t.Fatalf("expected the unmatched objects to be removed, got %v", removals)
+	}
+}
+
+func TestMatchRenamesRemote(t *testing.T) {
+	object := func(bucket, name string, size int64, etag string, checksum map[string]string) *ClientContent {
+		return &ClientContent{
+			URL:      *newClientURL("https://play.min.io/" + bucket + "/" + name),
+			Size:     size,
+			Type:     0o664,
+			ETag:     etag,
+			Checksum: checksum,
+		}
+	}
+	created := func(content *ClientContent) URLs {
+		return URLs{SourceContent: content}
+	}
+
+	vanished := []*ClientContent{
+		object("target", "a", 10, "etag-a", nil),
+		object("target", "b", 10, "etag-a", nil),
+		object("target", "c", 10, "", map[string]string{"CRC32C": "x"}),
+		// Same checksum of another type, same ETag but another size.
+		object("target", "d", 10, "", map[string]string{"CRC32": "x"}),
+		object("target", "e", 11, "etag-a", nil),
+	}
+	moves, copies, removals := matchRenames([]URLs{
+		created(object("source", "1", 10, "etag-a", nil)),
+		created(object("source", "2", 10, "etag-a", nil)),
+		created(object("source", "3", 10, "etag-a", nil)),
+		created(object("source", "4", 10, "other", map[string]string{"CRC32C": "x"})),
+		// Only the checksums known on both sides are compared.
+		created(object("source", "5", 10, "", map[string]string{"SHA256": "y", "CRC32": "x"})),
+	}, vanished)
+
+	var moved []string
+	for _, urls := range moves {
+		moved = append(moved, urls.SourceContent.URL.Path+"<"+urls.MoveFrom.URL.Path)
+	}
+	expected := []string{"/source/1</target/a", "/source/2</target/b", "/source/4</target/c", "/source/5</target/d"}
+	if !reflect.DeepEqual(moved, expected) {
+		t.Fatalf("expected moves %v, got %v", expected, moved)
+	}
+	if len(copies) != 1 || copies[0].SourceContent.URL.Path != "/source/3" {
+		t.Fatalf("expected /source/3 to be copied, got %v", copies)
+	}
+	if len(removals) != 1 || removals[0] != vanished[4] {
+		t.Fatalf("expected /target/e to be removed, got %v", removals)
+	}
+}
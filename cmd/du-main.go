@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -52,6 +53,8 @@ var (
 			Name:  "versions",
 			Usage: "include all object versions",
 		},
+		filterFromFlag,
+		inventoryFlag,
 	}
 )
 
@@ -85,6 +88,12 @@ EXAMPLES:
 
   4. Summarize disk usage of 'jazz-songs' bucket with all objects versions
      {{.Prompt}} {{.HelpName}} --versions s3/jazz-songs/
+
+  5. Summarize disk usage of 'jazz-songs' bucket, counting only the objects included by the rules of 'rules.txt'.
+     {{.Prompt}} {{.HelpName}} --filter-from rules.txt s3/jazz-songs/
+
+  6. Summarize disk usage of 'jazz-songs' bucket per prefix from its last S3 Inventory report, without listing the bucket.
+     {{.Prompt}} {{.HelpName}} --recursive --inventory s3/reports/jazz-songs/daily/2025-01-01T01-00Z/manifest.json s3/jazz-songs/
 `,
 }
 
@@ -119,7 +128,9 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int) (sz, objs int64, err error) {
+// du - summarize the disk usage of urlStr, rootPath is the path of the
+// command line argument which --filter-from rules are relative to.
+func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int, filter filterRules, rootPath string) (sz, objs int64, err error) {
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 
 	if !strings.HasSuffix(targetURL, "/") {
@@ -137,6 +148,9 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	recursive := depth == 1
 
 	targetAbsolutePath := path.Clean(clnt.GetURL().String())
+	if rootPath == "" {
+		rootPath = clnt.GetURL().Path
+	}
 
 	contentCh := clnt.List(ctx, ListOptions{
 		TimeRef:           timeRef,
@@ -164,6 +178,10 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			continue
 		}
 
+		if !filter.Match(strings.TrimPrefix(content.URL.Path, rootPath), content) {
+			continue
+		}
+
 		if content.Type.IsDir() && !recursive {
 			depth := depth
 			if depth > 0 {
@@ -174,7 +192,7 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth)
+			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth, filter, rootPath)
 			if err != nil {
 				return 0, 0, err
 			}
@@ -206,6 +224,72 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	return size, objects, nil
 }
 
+// duInventory - summarize the disk usage of urlStr from the S3 Inventory
+// report of manifestURL, the totals of all folder prefixes down to depth
+// are computed in a single pass over the report.
+func duInventory(ctx context.Context, urlStr, manifestURL string, withVersions bool, depth int, filter filterRules) error {
+	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
+
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `%s`.", urlStr)
+		return exitStatus(globalErrorExitStatus) // End of journey.
+	}
+	rootPath := clnt.GetURL().Path
+
+	usage := map[string]*duMessage{"": {}}
+	for content := range listInventory(ctx, manifestURL, clnt, ListOptions{WithOlderVersions: withVersions, Recursive: true}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `%s` from the inventory report.", urlStr)
+			return exitStatus(globalErrorExitStatus)
+		}
+		key := strings.TrimPrefix(content.URL.Path, rootPath)
+		if !filter.Match(key, content) {
+			continue
+		}
+		for _, prefix := range append([]string{""}, inventoryPrefixes(key, depth-1)...) {
+			msg, ok := usage[prefix]
+			if !ok {
+				msg = &duMessage{}
+				usage[prefix] = msg
+			}
+			msg.Size += content.Size
+			msg.Objects++
+		}
+	}
+
+	// Print the prefixes after the prefixes below them, like du.
+	prefixes := make([]string, 0, len(usage))
+	for prefix := range usage {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	printUsage := func(prefix string) {
+		msg := usage[prefix]
+		msg.Prefix = strings.Trim(rootPath+prefix, "/")
+		msg.Status = "success"
+		msg.IsVersions = withVersions
+		printMsg(*msg)
+	}
+	var stack []string
+	for _, prefix := range prefixes {
+		for len(stack) > 0 && !strings.HasPrefix(prefix, stack[len(stack)-1]) {
+			printUsage(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, prefix)
+	}
+	for len(stack) > 0 {
+		printUsage(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+	}
+	return nil
+}
+
 // main for du command.
 func mainDu(cliCtx *cli.Context) error {
 	if !cliCtx.Args().Present() {
@@ -235,6 +319,11 @@ func mainDu(cliCtx *cli.Context) error {
 
 	withVersions := cliCtx.Bool("versions")
 	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+	filter := parseFilterFromFlag(cliCtx)
+	manifestURL := cliCtx.String("inventory")
+	if manifestURL != "" && !timeRef.IsZero() {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--inventory` cannot be used with `--rewind`.")
+	}
 
 	var duErr error
 	var isDir bool
@@ -244,7 +333,13 @@ func mainDu(cliCtx *cli.Context) error {
 			fatalIf(errInvalidArgument().Trace(urlStr), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by 'du' command.", urlStr))
 		}
 
-		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth); duErr == nil {
+		if manifestURL != "" {
+			if err := duInventory(ctx, urlStr, manifestURL, withVersions, depth, filter); duErr == nil {
+				duErr = err
+			}
+			continue
+		}
+		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth, filter, ""); duErr == nil {
 			duErr = err
 		}
 	}
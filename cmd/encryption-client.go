@@ -0,0 +1,389 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/mitchellh/go-homedir"
+	"github.com/secure-io/sio-go"
+	"golang.org/x/crypto/argon2"
+)
+
+// Objects encrypted by mc are encrypted with a new key of their own,
+// sealed with the client key of their prefix and kept in their metadata
+// along with the ID of the client key, and the salt of the client key
+// when it is derived from a passphrase.
+const (
+	clientEncAlgorithm = "X-Amz-Meta-Mc-Enc-Algorithm"
+	clientEncKeyID     = "X-Amz-Meta-Mc-Enc-Key-Id"
+	clientEncSealedKey = "X-Amz-Meta-Mc-Enc-Sealed-Key"
+	clientEncSalt      = "X-Amz-Meta-Mc-Enc-Salt"
+
+	// clientEncFragment - the size of an encrypted fragment of the
+	// stream, each fragment of sio.BufSize bytes is authenticated.
+	clientEncFragment = sio.BufSize + clientEncTagSize
+	clientEncTagSize  = 16
+
+	// clientEncSaltSize - the size of the random salt of passphrases.
+	clientEncSaltSize = 16
+)
+
+// clientKey - a key of client-side encryption. It is kept with the keys
+// of server-side encryption of a prefix but nothing is sent to the
+// server, mc encrypts and decrypts the objects.
+type clientKey struct {
+	id  string
+	key []byte
+
+	// A passphrase derives a key with a random salt kept in the metadata
+	// of the objects. argon2 is expensive by design, the salt is drawn
+	// once per run and the keys of other salts are derived once.
+	passphrase []byte
+	salt       string
+	mu         sync.Mutex
+	derived    map[string][]byte
+
+	// allowPlaintext - objects which are not encrypted by mc are read
+	// as they are instead of failing.
+	allowPlaintext bool
+}
+
+// Type - implements encrypt.ServerSide.
+func (k *clientKey) Type() encrypt.Type { return "client" }
+
+// Marshal - implements encrypt.ServerSide, the server is not involved.
+func (k *clientKey) Marshal(http.Header) {}
+
+// newClientKey - a client key read from a key file with 'file:PATH' or
+// derived from a passphrase with 'pass:PASSPHRASE'.
+func newClientKey(spec string) (*clientKey, error) {
+	var key []byte
+	switch {
+	case strings.HasPrefix(spec, "file:"):
+		path, e := homedir.Expand(strings.TrimPrefix(spec, "file:"))
+		if e != nil {
+			return nil, e
+		}
+		data, e := os.ReadFile(filepath.Clean(path))
+		if e != nil {
+			return nil, e
+		}
+		encoded := strings.TrimSpace(string(data))
+		if len(encoded) == 64 {
+			key, e = hex.DecodeString(encoded)
+		} else {
+			key, e = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+		}
+		if e != nil || len(key) != 32 {
+			return nil, fmt.Errorf("key file %s should have a 32 bytes key, hex or base64 encoded", path)
+		}
+	case strings.HasPrefix(spec, "pass:") && len(spec) > len("pass:"):
+		salt := make([]byte, clientEncSaltSize)
+		if _, e := rand.Read(salt); e != nil {
+			return nil, e
+		}
+		passphrase := []byte(strings.TrimPrefix(spec, "pass:"))
+		key = passphraseKey(passphrase, salt)
+		return &clientKey{
+			id:         clientKeyID(key),
+			key:        key,
+			passphrase: passphrase,
+			salt:       base64.StdEncoding.EncodeToString(salt),
+		}, nil
+	default:
+		return nil, errors.New("client key should be 'file:PATH' or 'pass:PASSPHRASE'")
+	}
+	return &clientKey{id: clientKeyID(key), key: key}, nil
+}
+
+// passphraseKey - the key derived from a passphrase with salt, argon2
+// makes guesses expensive.
+func passphraseKey(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, 3, 64*1024, 4, 32)
+}
+
+// clientKeyID - the ID of a key kept with the objects it encrypts, to
+// tell which key an object needs.
+func clientKeyID(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("mc client-side encryption key ID"))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// keyOf - the key and the key ID of the objects encrypted with salt.
+func (k *clientKey) keyOf(salt string) ([]byte, string, error) {
+	switch {
+	case salt == k.salt:
+		return k.key, k.id, nil
+	case k.passphrase == nil:
+		return nil, "", errors.New("object is encrypted with a passphrase, not a key file")
+	case salt == "":
+		return nil, "", errors.New("object is encrypted with a key file, not a passphrase")
+	}
+	decoded, e := base64.StdEncoding.DecodeString(salt)
+	if e != nil || len(decoded) != clientEncSaltSize {
+		return nil, "", errors.New("invalid passphrase salt")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.derived[salt]
+	if !ok {
+		key = passphraseKey(k.passphrase, decoded)
+		if k.derived == nil {
+			k.derived = make(map[string][]byte)
+		}
+		k.derived[salt] = key
+	}
+	return key, clientKeyID(key), nil
+}
+
+// clientEncNonce - the nonce of the stream of an object. Every object
+// has a new key, the nonce does not need to change.
+func clientEncNonce(stream *sio.Stream) []byte {
+	return make([]byte, stream.NonceSize())
+}
+
+// clientEncAssociatedData - the object key and its stream are bound to
+// the bucket and the name of the object, encrypted objects cannot be
+// swapped. Copies of encrypted objects are decrypted and encrypted again.
+func clientEncAssociatedData(bucket, object string) []byte {
+	return []byte(bucket + "/" + object)
+}
+
+// encrypt - encrypt a stream of size bytes, -1 when unknown, with a new
+// object key, for the object of associatedData. The metadata to keep with
+// the object is returned with the encrypted stream and its size.
+func (k *clientKey) encrypt(r io.Reader, size int64, associatedData []byte) (io.Reader, int64, map[string]string, error) {
+	objectKey := make([]byte, 32)
+	if _, e := rand.Read(objectKey); e != nil {
+		return nil, 0, nil, e
+	}
+	block, e := aes.NewCipher(k.key)
+	if e != nil {
+		return nil, 0, nil, e
+	}
+	gcm, e := cipher.NewGCM(block)
+	if e != nil {
+		return nil, 0, nil, e
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, e = rand.Read(nonce); e != nil {
+		return nil, 0, nil, e
+	}
+	stream, e := sio.AES_256_GCM.Stream(objectKey)
+	if e != nil {
+		return nil, 0, nil, e
+	}
+	if size >= 0 {
+		size += stream.Overhead(size)
+	}
+	metadata := map[string]string{
+		clientEncAlgorithm: sio.AES_256_GCM.String(),
+		clientEncKeyID:     k.id,
+		clientEncSealedKey: base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, objectKey, associatedData)),
+	}
+	if k.salt != "" {
+		metadata[clientEncSalt] = k.salt
+	}
+	return stream.EncryptReader(r, clientEncNonce(stream), associatedData), size, metadata, nil
+}
+
+// objectStream - the stream to decrypt an object with its metadata, nil
+// when the object was not encrypted by mc.
+func (k *clientKey) objectStream(metadata map[string]string, associatedData []byte) (*sio.Stream, error) {
+	switch algorithm := metadata[clientEncAlgorithm]; algorithm {
+	case "":
+		return nil, nil
+	case sio.AES_256_GCM.String():
+	default:
+		return nil, fmt.Errorf("unsupported client-side encryption %s", algorithm)
+	}
+	key, keyID, e := k.keyOf(metadata[clientEncSalt])
+	if e != nil {
+		return nil, e
+	}
+	if id := metadata[clientEncKeyID]; id != keyID {
+		return nil, fmt.Errorf("object is encrypted with the client key %s, not %s", id, keyID)
+	}
+
+	sealed, e := base64.StdEncoding.DecodeString(metadata[clientEncSealedKey])
+	if e != nil {
+		return nil, e
+	}
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, e
+	}
+	gcm, e := cipher.NewGCM(block)
+	if e != nil {
+		return nil, e
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("invalid sealed object key")
+	}
+	objectKey, e := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], associatedData)
+	if e != nil {
+		return nil, errors.New("unable to unseal the object key, the object was modified or moved")
+	}
+	return sio.AES_256_GCM.Stream(objectKey)
+}
+
+// isClientEncrypted - the object was encrypted by mc, by the metadata of
+// a HEAD or GET or of a listing with metadata.
+func isClientEncrypted(content *ClientContent) bool {
+	return metadataValue(content, clientEncAlgorithm) != ""
+}
+
+// decryptedSize - the size of the content of an object of size bytes
+// encrypted by mc.
+func decryptedSize(size int64) int64 {
+	fragments := (size + clientEncFragment - 1) / clientEncFragment
+	return size - fragments*clientEncTagSize
+}
+
+// decryptedContent - the object info of the content of an object
+// encrypted by mc. Its checksums are those of the encrypted object and
+// the metadata of the encryption must not be copied with the content.
+func decryptedContent(content *ClientContent) {
+	content.Size = decryptedSize(content.Size)
+	content.Checksum = nil
+	for _, k := range []string{clientEncAlgorithm, clientEncKeyID, clientEncSealedKey, clientEncSalt} {
+		delete(content.Metadata, k)
+		delete(content.UserMetadata, strings.TrimPrefix(k, "X-Amz-Meta-"))
+	}
+}
+
+// streamReaderAt - an io.ReaderAt of a stream starting at offset. The
+// stream is read in order, reads can only go back to the data kept in
+// tail. Used to decrypt ranges, sio goes back to the start of fragments.
+type streamReaderAt struct {
+	r      io.Reader
+	offset int64
+	tail   []byte
+}
+
+func (s *streamReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	start := s.offset - int64(len(s.tail))
+	if off < start || off > s.offset {
+		return 0, fmt.Errorf("unexpected read at offset %d of a stream at %d", off, s.offset)
+	}
+	n := copy(p, s.tail[off-start:])
+	if n == len(p) {
+		return n, nil
+	}
+
+	m, e := io.ReadFull(s.r, p[n:])
+	s.offset += int64(m)
+	read := p[n : n+m]
+	if len(read) >= 2*clientEncFragment {
+		s.tail = append(s.tail[:0], read[len(read)-2*clientEncFragment:]...)
+	} else {
+		s.tail = append(s.tail, read...)
+		if extra := len(s.tail) - 2*clientEncFragment; extra > 0 {
+			s.tail = append(s.tail[:0], s.tail[extra:]...)
+		}
+	}
+	if e == io.ErrUnexpectedEOF {
+		e = io.EOF
+	}
+	return n + m, e
+}
+
+// clientEncryptedLister - a client listing the objects encrypted by mc
+// with the size of their content, mirror compares them with sources.
+type clientEncryptedLister struct {
+	Client
+	alias string
+	keys  []prefixSSEPair
+}
+
+// withClientEncryption - clnt listing the objects of the prefixes of
+// client keys with the size of their content.
+func withClientEncryption(clnt Client, alias string, keys []prefixSSEPair) Client {
+	for _, k := range keys {
+		if _, ok := k.SSE.(*clientKey); ok {
+			return clientEncryptedLister{Client: clnt, alias: alias, keys: keys}
+		}
+	}
+	return clnt
+}
+
+func (l clientEncryptedLister) List(ctx context.Context, opts ListOptions) <-chan *ClientContent {
+	// Objects not encrypted by mc may be kept under the prefix of a client
+	// key, they are told apart by their metadata.
+	opts.WithMetadata = true
+	contentCh := make(chan *ClientContent)
+	go func() {
+		defer close(contentCh)
+		for content := range l.Client.List(ctx, opts) {
+			if content.Err == nil && content.Type.IsRegular() {
+				path := filepath.ToSlash(filepath.Join(l.alias, content.URL.Path))
+				if _, ok := getSSE(path, l.keys).(*clientKey); ok {
+					encrypted, err := l.isClientEncrypted(ctx, content)
+					if err != nil {
+						content = &ClientContent{URL: content.URL, Err: err.Trace(path)}
+					} else if encrypted {
+						content.Size = decryptedSize(content.Size)
+					}
+				}
+			}
+			select {
+			case contentCh <- content:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return contentCh
+}
+
+// isClientEncrypted - the listed object was encrypted by mc. Listings of
+// S3 have no metadata, the object is read with a HEAD.
+func (l clientEncryptedLister) isClientEncrypted(ctx context.Context, content *ClientContent) (bool, *probe.Error) {
+	if hasListedMetadata(content) {
+		return isClientEncrypted(content), nil
+	}
+	clnt, err := newClientFromAlias(l.alias, content.URL.String())
+	if err != nil {
+		return false, err
+	}
+	st, err := clnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		return false, err
+	}
+	return isClientEncrypted(st), nil
+}
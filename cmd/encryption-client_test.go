@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secure-io/sio-go"
+)
+
+func TestNewClientKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{0x2a}, 32)
+	dir := t.TempDir()
+	hexFile := filepath.Join(dir, "hex.key")
+	base64File := filepath.Join(dir, "base64.key")
+	shortFile := filepath.Join(dir, "short.key")
+	for path, data := range map[string]string{
+		hexFile:    hex.EncodeToString(raw) + "\n",
+		base64File: base64.StdEncoding.EncodeToString(raw),
+		shortFile:  hex.EncodeToString(raw[:16]),
+	} {
+		if e := os.WriteFile(path, []byte(data), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	hexKey, e := newClientKey("file:" + hexFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+	base64Key, e := newClientKey("file:" + base64File)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(hexKey.key, raw) || hexKey.id != base64Key.id {
+		t.Fatalf("expected the same key from hex and base64 key files, got IDs %s and %s", hexKey.id, base64Key.id)
+	}
+
+	passKey, e := newClientKey("pass:correct horse")
+	if e != nil {
+		t.Fatal(e)
+	}
+	samePassKey, _ := newClientKey("pass:correct horse")
+	otherPassKey, _ := newClientKey("pass:battery staple")
+	if passKey.salt == samePassKey.salt || passKey.id == samePassKey.id {
+		t.Fatalf("expected passphrases to derive keys with random salts, got salts %s and %s", passKey.salt, samePassKey.salt)
+	}
+	_, sameID, e := samePassKey.keyOf(passKey.salt)
+	if e != nil {
+		t.Fatal(e)
+	}
+	_, otherID, e := otherPassKey.keyOf(passKey.salt)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if sameID != passKey.id || otherID == passKey.id {
+		t.Fatalf("expected passphrases to derive stable and distinct keys with a salt, got IDs %s, %s and %s", passKey.id, sameID, otherID)
+	}
+	if _, _, e = hexKey.keyOf(passKey.salt); e == nil {
+		t.Fatal("expected an error with the salt of a passphrase and a key file")
+	}
+	if _, _, e = passKey.keyOf(""); e == nil {
+		t.Fatal("expected an error without the salt of a passphrase")
+	}
+
+	for _, spec := range []string{"", "pass:", "key:abc", "file:" + shortFile, "file:" + filepath.Join(dir, "missing.key")} {
+		if _, e := newClientKey(spec); e == nil {
+			t.Fatalf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestClientKeyEncrypt(t *testing.T) {
+	key, e := newClientKey("pass:correct horse")
+	if e != nil {
+		t.Fatal(e)
+	}
+	otherKey, _ := newClientKey("pass:battery staple")
+	samePassKey, _ := newClientKey("pass:correct horse")
+	associatedData := clientEncAssociatedData("customer-data", "app.log")
+
+	for _, size := range []int{0, 1, sio.BufSize - 1, sio.BufSize, sio.BufSize + 1, 3*sio.BufSize + 7} {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		encrypted, encryptedSize, metadata, e := key.encrypt(bytes.NewReader(data), int64(size), associatedData)
+		if e != nil {
+			t.Fatalf("%d: %v", size, e)
+		}
+		ciphertext, e := io.ReadAll(encrypted)
+		if e != nil {
+			t.Fatalf("%d: %v", size, e)
+		}
+		if int64(len(ciphertext)) != encryptedSize {
+			t.Fatalf("%d: expected %d encrypted bytes, got %d", size, encryptedSize, len(ciphertext))
+		}
+		if n := decryptedSize(encryptedSize); n != int64(size) {
+			t.Fatalf("%d: expected the decrypted size %d, got %d", size, size, n)
+		}
+
+		// Another run with the same passphrase has another salt.
+		stream, e := samePassKey.objectStream(metadata, associatedData)
+		if e != nil {
+			t.Fatalf("%d: %v", size, e)
+		}
+		plaintext, e := io.ReadAll(stream.DecryptReader(bytes.NewReader(ciphertext), clientEncNonce(stream), associatedData))
+		if e != nil {
+			t.Fatalf("%d: %v", size, e)
+		}
+		if !bytes.Equal(plaintext, data) {
+			t.Fatalf("%d: decrypted content differs", size)
+		}
+
+		if _, e = otherKey.objectStream(metadata, associatedData); e == nil {
+			t.Fatalf("%d: expected an error with another key", size)
+		}
+		if _, e = key.objectStream(metadata, clientEncAssociatedData("customer-data", "other.log")); e == nil {
+			t.Fatalf("%d: expected an error with another object", size)
+		}
+	}
+
+	if stream, e := key.objectStream(map[string]string{"X-Amz-Meta-Owner": "alice"}, associatedData); stream != nil || e != nil {
+		t.Fatalf("expected no stream for an object not encrypted, got %v, %v", stream, e)
+	}
+}
+
+func TestDecryptRange(t *testing.T) {
+	key, e := newClientKey("pass:correct horse")
+	if e != nil {
+		t.Fatal(e)
+	}
+	data := make([]byte, 5*sio.BufSize+123)
+	rand.Read(data)
+	associatedData := clientEncAssociatedData("customer-data", "app.log")
+	encrypted, _, metadata, e := key.encrypt(bytes.NewReader(data), int64(len(data)), associatedData)
+	if e != nil {
+		t.Fatal(e)
+	}
+	ciphertext, e := io.ReadAll(encrypted)
+	if e != nil {
+		t.Fatal(e)
+	}
+	stream, e := key.objectStream(metadata, associatedData)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		offset, length int64
+	}{
+		{0, 1},
+		{0, int64(len(data))},
+		{1, sio.BufSize},
+		{sio.BufSize, sio.BufSize},
+		{sio.BufSize - 1, 2},
+		{2*sio.BufSize + 17, 2*sio.BufSize + 5},
+		{int64(len(data)) - 100, 100},
+		{5 * sio.BufSize, 123},
+	}
+	for i, testCase := range testCases {
+		start, end := encryptedRange(testCase.offset, testCase.length, int64(len(ciphertext)))
+		if start < 0 || end > int64(len(ciphertext)) || start >= end {
+			t.Fatalf("Test %d: invalid encrypted range %d-%d", i+1, start, end)
+		}
+		r := decryptRange(stream, associatedData, bytes.NewReader(ciphertext[start:end]), start, testCase.offset, testCase.length)
+		plaintext, e := io.ReadAll(r)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if !bytes.Equal(plaintext, data[testCase.offset:testCase.offset+testCase.length]) {
+			t.Fatalf("Test %d: decrypted range differs", i+1)
+		}
+	}
+}
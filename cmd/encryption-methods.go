@@ -38,6 +38,7 @@ const (
 	sseC
 	sseKMS
 	sseS3
+	sseClient
 )
 
 // struct representing object prefix and sse keys association.
@@ -97,6 +98,15 @@ func validateAndCreateEncryptionKeys(ctx *cli.Context) (encMap map[string][]pref
 		encMap[alias] = append(encMap[alias], *prefixPair)
 	}
 
+	for _, v := range ctx.StringSlice("enc-client") {
+		prefixPair, alias, err := validateAndParseKey(ctx, v, sseClient)
+		if err != nil {
+			return nil, err
+		}
+		prefixPair.SSE.(*clientKey).allowPlaintext = ctx.Bool("enc-client-allow-plaintext")
+		encMap[alias] = append(encMap[alias], *prefixPair)
+	}
+
 	for i := range encMap {
 		err = validateOverLappingSSEKeys(encMap[i])
 		if err != nil {
@@ -129,7 +139,7 @@ func validateAndParseKey(ctx *cli.Context, key string, keyType sseKeyType) (SSEP
 		return nil, "", errSSEInvalidAlias(prefix).Trace(key)
 	}
 
-	if (keyType == sseKMS || keyType == sseC) && encKey == "" {
+	if (keyType == sseKMS || keyType == sseC || keyType == sseClient) && encKey == "" {
 		return nil, "", errSSEClientKeyFormat("SSE-C/KMS key should be of the form alias/prefix=key,... ").Trace(key)
 	}
 
@@ -157,6 +167,8 @@ func validateAndParseKey(ctx *cli.Context, key string, keyType sseKeyType) (SSEP
 		sse, err = encrypt.NewSSEKMS(encKey, nil)
 	case sseS3:
 		sse = encrypt.NewSSE()
+	case sseClient:
+		sse, err = newClientKey(encKey)
 	}
 
 	if err != nil {
@@ -201,6 +213,10 @@ func parseSSEKey(sseKey string, keyType sseKeyType) (
 	sseKeyBytes := []byte(sseKey)
 
 	separatorIndex := bytes.LastIndex(sseKeyBytes, []byte("="))
+	if keyType == sseClient {
+		// Passphrases and paths of key files can have '='.
+		separatorIndex = bytes.Index(sseKeyBytes, []byte("="))
+	}
 	if separatorIndex < 0 {
 		if keyType == sseS3 {
 			alias, prefix = splitKey(sseKey)
@@ -216,6 +232,10 @@ func parseSSEKey(sseKey string, keyType sseKeyType) (
 
 	encodedKey := string(sseKeyBytes[separatorIndex+1:])
 	alias, prefix = splitKey(string(sseKeyBytes[:separatorIndex]))
+	if keyType == sseClient {
+		key = encodedKey
+		return
+	}
 	if keyType == sseKMS {
 		if !validKMSKeyName(encodedKey) {
 			err = errSSEKMSKeyFormat(fmt.Sprintf("Key (%s) is badly formatted.", encodedKey)).Trace(sseKey)
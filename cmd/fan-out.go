@@ -0,0 +1,314 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// fanOutBufferSize - size of the chunks broadcast to all targets.
+const fanOutBufferSize = 1 << 20
+
+// broadcast - copy a reader to all writers, a writer failing to accept
+// a chunk (its reader was closed) is dropped while the others continue.
+// Reading stops early when no writer is left.
+func broadcast(src io.ReadCloser, writers []*io.PipeWriter) {
+	defer src.Close()
+
+	live := append([]*io.PipeWriter(nil), writers...)
+	buf := make([]byte, fanOutBufferSize)
+	for len(live) > 0 {
+		n, e := src.Read(buf)
+		if n > 0 {
+			kept := live[:0]
+			for _, w := range live {
+				if _, we := w.Write(buf[:n]); we == nil {
+					kept = append(kept, w)
+				}
+			}
+			live = kept
+		}
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			for _, w := range live {
+				w.CloseWithError(e)
+			}
+			return
+		}
+	}
+	for _, w := range live {
+		w.Close()
+	}
+}
+
+// teeReaders - broadcast a reader to n readers, every reader must
+// be read until the end or closed for the others to progress.
+func teeReaders(src io.ReadCloser, n int) []*io.PipeReader {
+	readers := make([]*io.PipeReader, n)
+	writers := make([]*io.PipeWriter, n)
+	for i := range readers {
+		readers[i], writers[i] = io.Pipe()
+	}
+	go broadcast(src, writers)
+	return readers
+}
+
+// openSourceFunc - opens the stream of a source object.
+type openSourceFunc func(ctx context.Context, alias, urlStr string, opts getSourceOpts) (io.ReadCloser, *ClientContent, *probe.Error)
+
+// sourceTee - shares one stream of a source object between the uploads
+// to several targets. The source is opened when the first upload reads
+// it, targets copied on the server side never open it.
+type sourceTee struct {
+	once    sync.Once
+	content *ClientContent
+	err     *probe.Error
+
+	mu       sync.Mutex
+	readers  []*io.PipeReader
+	opened   []bool
+	finished []bool
+}
+
+func newSourceTee(n int) *sourceTee {
+	return &sourceTee{
+		opened:   make([]bool, n),
+		finished: make([]bool, n),
+	}
+}
+
+// opener - the openSourceFunc of the i-th target. A retried upload
+// cannot rewind the shared stream, it reads the source on its own.
+func (t *sourceTee) opener(i int) openSourceFunc {
+	return func(ctx context.Context, alias, urlStr string, opts getSourceOpts) (io.ReadCloser, *ClientContent, *probe.Error) {
+		t.mu.Lock()
+		reopen := t.opened[i]
+		t.opened[i] = true
+		t.mu.Unlock()
+		if reopen {
+			return getSourceStream(ctx, alias, urlStr, opts)
+		}
+
+		t.once.Do(func() {
+			var reader io.ReadCloser
+			reader, t.content, t.err = getSourceStream(ctx, alias, urlStr, opts)
+			if t.err != nil {
+				return
+			}
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.readers = teeReaders(reader, len(t.opened))
+			for j, done := range t.finished {
+				if done {
+					t.readers[j].Close()
+				}
+			}
+		})
+		if t.err != nil {
+			return nil, nil, t.err
+		}
+		return t.readers[i], t.content, nil
+	}
+}
+
+// done - the upload to the i-th target returned, stop streaming to it.
+func (t *sourceTee) done(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.finished[i] = true
+	if t.readers != nil {
+		t.readers[i].Close()
+	}
+}
+
+// uploadSourceToTargetURLs - upload a source to several targets at once,
+// the source is read once and streamed to all targets concurrently. The
+// results are in the order of the targets. A failed target is retried on
+// its own with its retry policy.
+func uploadSourceToTargetURLs(ctx context.Context, uploadOpts uploadSourceToTargetURLOpts, targets []URLs) []URLs {
+	tee := newSourceTee(len(targets))
+	results := make([]URLs, len(targets))
+
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opts := uploadOpts
+			opts.urls = targets[i]
+			opts.openSource = tee.opener(i)
+			urlsRetryPolicy(targets[i]).do(ctx, nil, printRetry(targets[i]), func() *probe.Error {
+				results[i] = uploadSourceToTargetURL(ctx, opts)
+				// Do not hold the other targets while waiting to retry.
+				tee.done(i)
+				return results[i].Error
+			})
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// fanOutSuffix - the path of a target URL relative to the root of its
+// target, empty when the URL is the root itself.
+func fanOutSuffix(root, target ClientURL) (string, bool) {
+	rootPath, targetPath := root.Path, target.Path
+	if root.Type == fileSystem {
+		var e error
+		if rootPath, e = filepath.Abs(rootPath); e != nil {
+			return "", false
+		}
+		if targetPath, e = filepath.Abs(targetPath); e != nil {
+			return "", false
+		}
+	}
+	rootPath = path.Clean(filepath.ToSlash(rootPath))
+	targetPath = path.Clean(filepath.ToSlash(targetPath))
+	if targetPath == rootPath {
+		return "", true
+	}
+	prefix := strings.TrimSuffix(rootPath, "/") + "/"
+	if !strings.HasPrefix(targetPath, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(targetPath, prefix), true
+}
+
+// fanOutTarget - an expanded target of --fan-out.
+type fanOutTarget struct {
+	alias string
+	url   string
+}
+
+// expandFanOutTargets - expand the aliases of the targets of --fan-out.
+func expandFanOutTargets(targetURLs []string) []fanOutTarget {
+	targets := make([]fanOutTarget, len(targetURLs))
+	for i, targetURL := range targetURLs {
+		targets[i].alias, targets[i].url, _ = mustExpandAlias(targetURL)
+	}
+	return targets
+}
+
+// fanOutURLs - the copies of an object to the other targets, laid out
+// in each target the way they are in the first one.
+func fanOutURLs(urls URLs, root string, targets []fanOutTarget) []URLs {
+	all := []URLs{urls}
+	suffix, ok := fanOutSuffix(*newClientURL(root), urls.TargetContent.URL)
+	for i, target := range targets {
+		fanOut := urls
+		fanOut.targetIndex = i + 1
+		if !ok {
+			fanOut.Error = errInvalidTarget(target.url).Trace(urls.TargetContent.URL.String())
+			all = append(all, fanOut)
+			continue
+		}
+		targetContent := *urls.TargetContent
+		if suffix == "" {
+			targetContent.URL = *newClientURL(target.url)
+		} else {
+			targetContent.URL = *newClientURL(urlJoinPath(target.url, suffix))
+		}
+		fanOut.TargetAlias = target.alias
+		fanOut.TargetContent = &targetContent
+		all = append(all, fanOut)
+	}
+	return all
+}
+
+// fanOutMessage - outcome of a command on one of its targets.
+type fanOutMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	Removed int64  `json:"removed,omitempty"`
+	Errors  int64  `json:"errors"`
+}
+
+func (m fanOutMessage) String() string {
+	msg := fmt.Sprintf("`%s`: %d objects, %s", m.Target, m.Objects, humanize.IBytes(uint64(m.Size)))
+	if m.Removed > 0 {
+		msg += fmt.Sprintf(", %d removed", m.Removed)
+	}
+	if m.Errors > 0 {
+		return console.Colorize("FanOutError", msg+fmt.Sprintf(", %d errors", m.Errors))
+	}
+	return console.Colorize("FanOut", msg)
+}
+
+func (m fanOutMessage) JSON() string {
+	m.Status = "success"
+	if m.Errors > 0 {
+		m.Status = "error"
+	}
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// fanOutStats - per target accounting of a fan-out command.
+type fanOutStats []fanOutMessage
+
+func newFanOutStats(targets []string) fanOutStats {
+	stats := make(fanOutStats, len(targets))
+	for i, target := range targets {
+		stats[i].Target = target
+	}
+	return stats
+}
+
+// add - account for the outcome of an operation on a target.
+func (s fanOutStats) add(urls URLs) {
+	if urls.targetIndex >= len(s) {
+		return
+	}
+	stat := &s[urls.targetIndex]
+	switch {
+	case urls.Error != nil:
+		stat.Errors++
+	case urls.SourceContent != nil:
+		stat.Objects++
+		stat.Size += urls.SourceContent.Size
+	case urls.TargetContent != nil:
+		stat.Removed++
+	}
+}
+
+// print - show the summary of every target.
+func (s fanOutStats) print() {
+	console.SetColor("FanOut", color.New(color.FgGreen, color.Bold))
+	console.SetColor("FanOutError", color.New(color.FgRed, color.Bold))
+	for _, stat := range s {
+		printMsg(stat)
+	}
+}
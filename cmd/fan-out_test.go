@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestTeeReaders(t *testing.T) {
+	data := make([]byte, 3*fanOutBufferSize+123)
+	if _, e := rand.Read(data); e != nil {
+		t.Fatal(e)
+	}
+
+	readers := teeReaders(io.NopCloser(bytes.NewReader(data)), 3)
+	results := make([][]byte, len(readers))
+	var wg sync.WaitGroup
+	for i, r := range readers {
+		wg.Add(1)
+		go func(i int, r *io.PipeReader) {
+			defer wg.Done()
+			if i == 1 {
+				// A failing target must not block the others.
+				buf := make([]byte, 10)
+				io.ReadFull(r, buf)
+				r.Close()
+				results[i] = buf
+				return
+			}
+			results[i], _ = io.ReadAll(r)
+		}(i, r)
+	}
+	wg.Wait()
+
+	for _, i := range []int{0, 2} {
+		if !bytes.Equal(results[i], data) {
+			t.Errorf("reader %d: expected %d bytes, got %d", i, len(data), len(results[i]))
+		}
+	}
+	if !bytes.Equal(results[1], data[:10]) {
+		t.Errorf("reader 1: unexpected content %x", results[1])
+	}
+}
+
+func TestFanOutSuffix(t *testing.T) {
+	testCases := []struct {
+		root, target string
+		suffix       string
+		ok           bool
+	}{
+		{"https://s3.example.com/bucket/dir/", "https://s3.example.com/bucket/dir/a/b.txt", "a/b.txt", true},
+		{"https://s3.example.com/bucket/dir", "https://s3.example.com/bucket/dir/b.txt", "b.txt", true},
+		{"https://s3.example.com/bucket/file.txt", "https://s3.example.com/bucket/file.txt", "", true},
+		{"https://s3.example.com/bucket/dir", "https://s3.example.com/bucket/dir2/b.txt", "", false},
+		{"data", "data/x/y", "x/y", true},
+		{"./data/", "data/y", "y", true},
+	}
+	for i, tc := range testCases {
+		suffix, ok := fanOutSuffix(*newClientURL(tc.root), *newClientURL(tc.target))
+		if suffix != tc.suffix || ok != tc.ok {
+			t.Errorf("test %d: expected (%q, %v), got (%q, %v)", i+1, tc.suffix, tc.ok, suffix, ok)
+		}
+	}
+}
+
+func TestFanOutURLs(t *testing.T) {
+	urls := URLs{
+		SourceContent: &ClientContent{URL: *newClientURL("src/a/b.txt"), Size: 10},
+		TargetContent: &ClientContent{URL: *newClientURL("dst/a/b.txt")},
+	}
+	all := fanOutURLs(urls, "dst/", []fanOutTarget{{url: "other"}, {url: "/abs/dir/"}})
+	expected := []string{"dst/a/b.txt", "other/a/b.txt", "/abs/dir/a/b.txt"}
+	if len(all) != len(expected) {
+		t.Fatalf("expected %d targets, got %d", len(expected), len(all))
+	}
+	for i, u := range all {
+		if u.Error != nil {
+			t.Fatalf("target %d: unexpected error %v", i, u.Error)
+		}
+		if u.TargetContent.URL.String() != expected[i] || u.targetIndex != i {
+			t.Errorf("target %d: expected %s, got %s (index %d)", i, expected[i], u.TargetContent.URL.String(), u.targetIndex)
+		}
+	}
+	if urls.TargetContent.URL.String() != "dst/a/b.txt" {
+		t.Errorf("the first target was modified: %s", urls.TargetContent.URL.String())
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// filterPredicate - a condition on the size or the age of an object.
+type filterPredicate struct {
+	field string // "size" or "age"
+	less  bool
+	size  int64
+	age   time.Duration
+}
+
+// matches - verify if an object satisfies the predicate.
+func (p filterPredicate) matches(content *ClientContent) bool {
+	var value, ref int64
+	switch p.field {
+	case "size":
+		value, ref = content.Size, p.size
+	case "age":
+		value, ref = int64(time.Since(content.Time)), int64(p.age)
+	}
+	if p.less {
+		return value < ref
+	}
+	return value > ref
+}
+
+// filterRule - a single include or exclude rule.
+type filterRule struct {
+	include    bool
+	pattern    *regexp.Regexp
+	dirOnly    bool
+	predicates []filterPredicate
+}
+
+// filterRules - ordered include/exclude rules, the first rule matching
+// an object decides whether it is included. Objects matching no rule
+// are included.
+type filterRules []filterRule
+
+// globToRegexp - convert a rsync style pattern to a regular expression,
+// '*' and '?' do not match a '/' while '**' matches any path.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var re strings.Builder
+	if strings.HasPrefix(glob, "/") {
+		// Anchored to the root of the operation.
+		glob = glob[1:]
+		re.WriteString("^")
+	} else {
+		re.WriteString("(^|/)")
+	}
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				re.WriteString(".*")
+				i++
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				re.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			re.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+			}
+			re.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
+
+// parseFilterPredicate - parse a predicate such as 'size>10MiB' or 'age<7d'.
+func parseFilterPredicate(s string) (p filterPredicate, e error) {
+	idx := strings.IndexAny(s, "<>")
+	if idx < 0 {
+		return p, fmt.Errorf("unknown predicate `%s`", s)
+	}
+	p.field, p.less = s[:idx], s[idx] == '<'
+	value := s[idx+1:]
+	switch p.field {
+	case "size":
+		size, e := humanize.ParseBytes(value)
+		if e != nil {
+			return p, fmt.Errorf("invalid size in `%s`: %w", s, e)
+		}
+		p.size = int64(size)
+	case "age":
+		age, e := ParseDuration(value)
+		if e != nil {
+			return p, fmt.Errorf("invalid age in `%s`: %w", s, e)
+		}
+		p.age = time.Duration(age)
+	default:
+		return p, fmt.Errorf("unknown predicate `%s`, expected size or age", s)
+	}
+	return p, nil
+}
+
+// parseFilterRules - parse rules, one per line, in the form
+//
+//	+|- PATTERN [size>SIZE|size<SIZE|age>AGE|age<AGE]...
+//
+// Empty lines and lines starting with '#' or ';' are ignored.
+func parseFilterRules(r io.Reader) (filterRules, error) {
+	var rules filterRules
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || (fields[0] != "+" && fields[0] != "-") {
+			return nil, fmt.Errorf("line %d: expected `+ PATTERN` or `- PATTERN`, found `%s`", lineNum, line)
+		}
+
+		rule := filterRule{include: fields[0] == "+"}
+		pattern := fields[1]
+		if len(pattern) > 1 && strings.HasSuffix(pattern, "/") {
+			rule.dirOnly = true
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		re, e := globToRegexp(pattern)
+		if e != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern `%s`: %w", lineNum, fields[1], e)
+		}
+		rule.pattern = re
+
+		for _, field := range fields[2:] {
+			p, e := parseFilterPredicate(field)
+			if e != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, e)
+			}
+			rule.predicates = append(rule.predicates, p)
+		}
+		rules = append(rules, rule)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+	return rules, nil
+}
+
+// loadFilterRules - read the rules file of --filter-from.
+func loadFilterRules(filename string) (filterRules, *probe.Error) {
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	defer f.Close()
+
+	rules, e := parseFilterRules(f)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	return rules, nil
+}
+
+// parseFilterFromFlag - load the rules of --filter-from, if any.
+func parseFilterFromFlag(cliCtx *cli.Context) filterRules {
+	filename := cliCtx.String("filter-from")
+	if filename == "" {
+		return nil
+	}
+	rules, err := loadFilterRules(filename)
+	fatalIf(err, "Unable to load filter rules from `%s`.", filename)
+	return rules
+}
+
+// matchRule - the decision of the first rule matching name, true if none matches.
+func (rules filterRules) matchRule(name string, isDir bool, content *ClientContent) bool {
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if len(rule.predicates) > 0 && (isDir || content == nil) {
+			// Predicates only apply to objects.
+			continue
+		}
+		if !rule.pattern.MatchString(name) {
+			continue
+		}
+		matched := true
+		for _, p := range rule.predicates {
+			if !p.matches(content) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.include
+		}
+	}
+	return true
+}
+
+// Match - verify if an object, named relative to the root of the operation,
+// is included by the rules. Like rsync, objects in an excluded folder are
+// excluded as well, whatever the rules for the object itself.
+func (rules filterRules) Match(name string, content *ClientContent) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	name = strings.TrimPrefix(filepath.ToSlash(name), "/")
+	isDir := strings.HasSuffix(name, "/") || (content != nil && content.Type.IsDir())
+	name = strings.TrimSuffix(name, "/")
+	if name == "" {
+		return true
+	}
+
+	for i := strings.IndexByte(name, '/'); i >= 0; {
+		if !rules.matchRule(name[:i], true, nil) {
+			return false
+		}
+		next := strings.IndexByte(name[i+1:], '/')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return rules.matchRule(name, isDir, content)
+}
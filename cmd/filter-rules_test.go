@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilterRules(t *testing.T) {
+	rules, e := parseFilterRules(strings.NewReader(`
+# comments and empty lines are ignored
+
+- tmp/
++ *.log size>1KiB
+- *.log
+- /build/**.o
++ /docs/*.md age<7d
+- /docs/*.md
+- ?.bak
+- [!a-c]*.swp
+`))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	now := time.Now()
+	file := func(size int64, modTime time.Time) *ClientContent {
+		return &ClientContent{Size: size, Time: modTime, Type: os.FileMode(0o644)}
+	}
+
+	testCases := []struct {
+		name    string
+		content *ClientContent
+		match   bool
+	}{
+		{"tmp", file(1, now), true},
+		{"tmp/", nil, false},
+		{"a/tmp/file.txt", file(1, now), false},
+		{"/a/tmp/file.txt", file(1, now), false},
+		{"a/tmpfile.txt", file(1, now), true},
+		{"app.log", file(1, now), false},
+		{"logs/app.log", file(4096, now), true},
+		{"build/main.o", file(1, now), false},
+		{"build/cmd/main.o", file(1, now), false},
+		{"src/build/main.o", file(1, now), true},
+		{"docs/readme.md", file(1, now), true},
+		{"docs/old.md", file(1, now.Add(-30*24*time.Hour)), false},
+		{"docs/sub/old.md", file(1, now.Add(-30*24*time.Hour)), true},
+		{"x.bak", file(1, now), false},
+		{"xy.bak", file(1, now), true},
+		{"d.swp", file(1, now), false},
+		{"a.swp", file(1, now), true},
+	}
+	for _, testCase := range testCases {
+		if match := rules.Match(testCase.name, testCase.content); match != testCase.match {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.match, match)
+		}
+	}
+}
+
+func TestFilterRulesOrder(t *testing.T) {
+	rules, e := parseFilterRules(strings.NewReader("+ */\n+ *.txt\n- *\n"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	for name, match := range map[string]bool{
+		"a.txt":     true,
+		"a/b/c.txt": true,
+		"a/b/c.jpg": false,
+		"a.jpg":     false,
+	} {
+		if rules.Match(name, &ClientContent{Type: os.FileMode(0o644)}) != match {
+			t.Errorf("%s: expected %v", name, match)
+		}
+	}
+
+	var noRules filterRules
+	if !noRules.Match("anything", nil) {
+		t.Error("expected objects to be included without rules")
+	}
+}
+
+func TestParseFilterRulesErrors(t *testing.T) {
+	for _, rules := range []string{
+		"tmp/",
+		"* tmp/",
+		"+",
+		"+ *.log size>abc",
+		"+ *.log age<forever",
+		"+ *.log owner=root",
+	} {
+		if _, e := parseFilterRules(strings.NewReader(rules)); e == nil {
+			t.Errorf("expected an error for `%s`", rules)
+		}
+	}
+}
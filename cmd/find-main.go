@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -93,6 +94,7 @@ var (
 			Name:  "tags",
 			Usage: "match tags with RE2 regex pattern. Specify each with key=regex. MinIO server only.",
 		},
+		inventoryFlag,
 	}
 )
 
@@ -171,6 +173,9 @@ EXAMPLES:
 
   11. Copy all versions of all objects in bucket in the local machine
       {{.Prompt}} {{.HelpName}} s3/bucket --versions --exec "mc cp --version-id {version} {} /tmp/dir/{}.{version}"
+
+  12. Find all objects larger than 1 GB under "s3/bucket" from its last S3 Inventory report, without listing the bucket.
+      {{.Prompt}} {{.HelpName}} s3/bucket --larger 1GB --inventory s3/reports/bucket/daily/2025-01-01T01-00Z/manifest.json
 `,
 }
 
@@ -189,6 +194,14 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
+	if cliCtx.String("inventory") != "" {
+		for _, flag := range []string{"watch", "metadata", "tags"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(args...), fmt.Sprintf("`--inventory` cannot be used with `--%s`.", flag))
+			}
+		}
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(ctx, url2StatOptions{urlStr: url, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false})
@@ -222,6 +235,7 @@ type findContext struct {
 	withVersions  bool
 	matchMeta     map[string]*regexp.Regexp
 	matchTags     map[string]*regexp.Regexp
+	inventory     string
 
 	// Internal values
 	targetAlias   string
@@ -315,5 +329,6 @@ func mainFind(cliCtx *cli.Context) error {
 		clnt:          clnt,
 		matchMeta:     getRegexMap(cliCtx, "metadata"),
 		matchTags:     getRegexMap(cliCtx, "tags"),
+		inventory:     cliCtx.String("inventory"),
 	})
 }
@@ -280,8 +280,13 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 		WithMetadata:      len(ctx.matchMeta) > 0 || len(ctx.matchTags) > 0,
 	}
 
+	contentCh := ctx.clnt.List(globalContext, lstOptions)
+	if ctx.inventory != "" {
+		contentCh = listInventory(globalContext, ctx.inventory, ctx.clnt, lstOptions)
+	}
+
 	// iterate over all content which is within the given directory
-	for content := range ctx.clnt.List(globalContext, lstOptions) {
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
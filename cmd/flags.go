@@ -76,14 +76,24 @@ var globalFlags = []cli.Flag{
 	},
 	cli.StringFlag{
 		Name:   "limit-upload",
-		Usage:  "limits uploads to a maximum rate in KiB/s, MiB/s, GiB/s. (default: unlimited)",
+		Usage:  "limits uploads to a maximum rate in KiB/s, MiB/s, GiB/s, or to a schedule like '08:00-18:00 20MiB/s, otherwise unlimited'. (default: unlimited)",
 		EnvVar: envPrefix + "LIMIT_UPLOAD",
 	},
 	cli.StringFlag{
 		Name:   "limit-download",
-		Usage:  "limits downloads to a maximum rate in KiB/s, MiB/s, GiB/s. (default: unlimited)",
+		Usage:  "limits downloads to a maximum rate in KiB/s, MiB/s, GiB/s, or to a schedule like '08:00-18:00 20MiB/s, otherwise unlimited'. (default: unlimited)",
 		EnvVar: envPrefix + "LIMIT_DOWNLOAD",
 	},
+	cli.StringFlag{
+		Name:   "limit-requests",
+		Usage:  "limits requests per second to each alias, in total like '100' or per API class like 'list=10,read=100,write=50,delete=20'. (default: unlimited)",
+		EnvVar: envPrefix + "LIMIT_REQUESTS",
+	},
+	cli.StringFlag{
+		Name:   "retry-policy",
+		Usage:  "retry transient errors of each operation, 'off' or like 'attempts=3,backoff=1s,max-backoff=30s,max-elapsed=5m'. (default: the policy of the alias, else the example)",
+		EnvVar: envPrefix + "RETRY_POLICY",
+	},
 	cli.DurationFlag{
 		Name:   "conn-read-deadline",
 		Usage:  "custom connection READ deadline",
@@ -107,6 +117,8 @@ var encFlags = []cli.Flag{
 	encCFlag,
 	encKSMFlag,
 	encS3Flag,
+	encClientFlag,
+	encClientAllowPlaintextFlag,
 }
 
 var encCFlag = cli.StringSliceFlag{
@@ -126,12 +138,65 @@ var encS3Flag = cli.StringSliceFlag{
 	EnvVar: envPrefix + "ENC_S3",
 }
 
+var encClientFlag = cli.StringSliceFlag{
+	Name:   "enc-client",
+	Usage:  "encrypt/decrypt objects on the client with keys of a file or a passphrase, 'alias/prefix=file:PATH' or 'alias/prefix=pass:PASSPHRASE'. (multiple keys can be provided)",
+	EnvVar: envPrefix + "ENC_CLIENT",
+}
+
+var encClientAllowPlaintextFlag = cli.BoolFlag{
+	Name:  "enc-client-allow-plaintext",
+	Usage: "read objects not encrypted on the client under the prefixes of --enc-client as they are",
+}
+
 var checksumFlag = cli.StringFlag{
 	Name:  "checksum",
 	Usage: "Add checksum to uploaded object. Values: CRC64NVME, CRC32, CRC32C, SHA1 or SHA256. Requires server trailing headers (AWS, MinIO)",
 	Value: "",
 }
 
+var filterFromFlag = cli.StringFlag{
+	Name:  "filter-from",
+	Usage: "include or exclude objects with the ordered '+ PATTERN' and '- PATTERN' rules of a file",
+}
+
+var fanOutFlag = cli.StringSliceFlag{
+	Name:  "fan-out",
+	Usage: "also write to TARGET, every source object is read once for all targets",
+}
+
+var manifestFlag = cli.StringFlag{
+	Name:  "manifest",
+	Usage: "act on the objects listed in a CSV or JSON lines file, '-' for STDIN, instead of listing them",
+}
+
+var verifyFlag = cli.BoolFlag{
+	Name:  "verify",
+	Usage: "compare a full object checksum of every copy with its source, copy it again on mismatch",
+}
+
+var extractFlag = cli.BoolFlag{
+	Name:  "extract",
+	Usage: "upload the files of a .zip, .tar, .tar.gz or .tar.zst SOURCE as objects under TARGET",
+}
+
+var compressFlag = cli.StringFlag{
+	Name:  "compress",
+	Usage: "compress uploaded objects with 'zstd' or 'gzip', they are decompressed when read by mc",
+}
+
+var batchSmallFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "batch-small",
+		Usage: "upload small objects in batches extracted by the target, when it supports it, buffering up to 64MiB in memory per upload",
+	},
+	cli.StringFlag{
+		Name:  "batch-small-size",
+		Usage: "largest object uploaded in a batch with --batch-small",
+		Value: "1MiB",
+	},
+}
+
 func parseChecksum(ctx *cli.Context) (useMD5 bool, ct minio.ChecksumType) {
 	useMD5 = ctx.Bool("md5")
 	if cs := ctx.String("checksum"); cs != "" {
@@ -166,3 +231,20 @@ func parseChecksum(ctx *cli.Context) (useMD5 bool, ct minio.ChecksumType) {
 	}
 	return
 }
+
+// parseCompress - the codec of --compress, empty without it.
+func parseCompress(ctx *cli.Context) string {
+	codec := strings.ToLower(ctx.String("compress"))
+	switch codec {
+	case "", compressZstd, compressGzip:
+	default:
+		err := fmt.Errorf("unknown compression: %s. Should be one of zstd or gzip", ctx.String("compress"))
+		fatalIf(probe.NewError(err), "")
+	}
+	return codec
+}
+
+var inventoryFlag = cli.StringFlag{
+	Name:  "inventory",
+	Usage: "read the objects from the S3 Inventory report of a manifest.json instead of listing them",
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/minio/cli"
@@ -32,6 +33,16 @@ var (
 			Name:  "version-id, vid",
 			Usage: "get a specific version of an object",
 		},
+		cli.IntFlag{
+			Name:  "parallel, P",
+			Usage: "download number of ranges in parallel",
+			Value: 1,
+		},
+		cli.StringFlag{
+			Name:  "part-size, s",
+			Usage: "each range size",
+			Value: defaultDownloadPartSize,
+		},
 	}
 )
 
@@ -42,7 +53,7 @@ var getCmd = cli.Command{
 	Action:       mainGet,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(globalFlags, encCFlag), getFlags...),
+	Flags:        append(append(globalFlags, encCFlag, encClientFlag, encClientAllowPlaintextFlag), getFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -59,6 +70,9 @@ EXAMPLES:
 
   2. Get an object from MinIO storage using encryption
      {{.Prompt}} {{.HelpName}} --enc-c "play/mybucket/object=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA" play/mybucket/object path-to/object
+
+  3. Get a large object with 16 concurrent range requests of 128MiB, verified against its checksum or ETag
+     {{.Prompt}} {{.HelpName}} --parallel 16 --part-size 128MiB play/mybucket/disk.img path-to/disk.img
 `,
 }
 
@@ -132,6 +146,8 @@ func mainGet(cliCtx *cli.Context) (e error) {
 				pg:                  pg,
 				encryptionKeys:      encryptionKeys,
 				updateProgressTotal: true,
+				downloadThreads:     strconv.Itoa(cliCtx.Int("parallel")),
+				downloadPartSize:    cliCtx.String("part-size"),
 			})
 			if urls.Error != nil {
 				e = urls.Error.ToGoError()
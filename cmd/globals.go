@@ -32,9 +32,9 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/limiter"
 	"github.com/minio/pkg/v3/console"
 	"github.com/muesli/termenv"
 	"golang.org/x/net/http/httpguts"
@@ -52,6 +52,9 @@ const (
 	globalSharedURLsDataDir    = "share"
 	globalSessionConfigVersion = "8"
 
+	// bisync state databases.
+	globalBisyncDir = "bisync"
+
 	// Profile directory for dumping profiler outputs.
 	globalProfileDir = "profile"
 
@@ -91,8 +94,17 @@ var (
 	globalConnReadDeadline  time.Duration
 	globalConnWriteDeadline time.Duration
 
-	globalLimitUpload   uint64
-	globalLimitDownload uint64
+	// Shared by the clients of all aliases, nil when unlimited.
+	globalLimitUpload   *limiter.Limit
+	globalLimitDownload *limiter.Limit
+
+	// Requests per second of each alias, overrides the limit in the
+	// config file, nil when not set.
+	globalLimitRequests *limiter.RequestRates
+
+	// Retry policy of all aliases, overrides the policy in the
+	// config file, nil when not set.
+	globalRetryPolicy *retryPolicy
 
 	globalContext, globalCancel = context.WithCancel(context.Background())
 
@@ -162,11 +174,11 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 		limitUploadStr = ctx.GlobalString("limit-upload")
 	}
 	if limitUploadStr != "" {
-		var e error
-		globalLimitUpload, e = humanize.ParseBytes(limitUploadStr)
+		schedule, e := limiter.ParseSchedule(limitUploadStr)
 		if e != nil {
 			return e
 		}
+		globalLimitUpload = limiter.NewLimit(schedule)
 	}
 
 	limitDownloadStr := ctx.String("limit-download")
@@ -175,11 +187,35 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	}
 
 	if limitDownloadStr != "" {
-		var e error
-		globalLimitDownload, e = humanize.ParseBytes(limitDownloadStr)
+		schedule, e := limiter.ParseSchedule(limitDownloadStr)
+		if e != nil {
+			return e
+		}
+		globalLimitDownload = limiter.NewLimit(schedule)
+	}
+
+	limitRequestsStr := ctx.String("limit-requests")
+	if limitRequestsStr == "" {
+		limitRequestsStr = ctx.GlobalString("limit-requests")
+	}
+	if limitRequestsStr != "" {
+		rates, e := limiter.ParseRequestRates(limitRequestsStr)
+		if e != nil {
+			return e
+		}
+		globalLimitRequests = &rates
+	}
+
+	retryPolicyStr := ctx.String("retry-policy")
+	if retryPolicyStr == "" {
+		retryPolicyStr = ctx.GlobalString("retry-policy")
+	}
+	if retryPolicyStr != "" {
+		policy, e := parseRetryPolicy(retryPolicyStr)
 		if e != nil {
 			return e
 		}
+		globalRetryPolicy = &policy
 	}
 
 	dnsEntries := ctx.StringSlice("resolve")
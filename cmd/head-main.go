@@ -60,7 +60,7 @@ var headCmd = cli.Command{
 	Action:       mainHead,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(headFlags, encCFlag), globalFlags...),
+	Flags:        append(append(headFlags, encCFlag, encClientFlag, encClientAllowPlaintextFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
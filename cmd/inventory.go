@@ -0,0 +1,256 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/inventory"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// errStopInventory - stops reading a report when the listing is canceled.
+var errStopInventory = errors.New("inventory listing canceled")
+
+// inventoryReport - an S3 Inventory report, read from the alias of
+// its manifest or from a local copy of the report.
+type inventoryReport struct {
+	manifestURL string
+	alias       string
+	manifest    *inventory.Manifest
+}
+
+// openInventoryReport - read the manifest.json of a report.
+func openInventoryReport(ctx context.Context, manifestURL string) (*inventoryReport, *probe.Error) {
+	alias, _, _ := mustExpandAlias(manifestURL)
+	clnt, err := newClient(manifestURL)
+	if err != nil {
+		return nil, err.Trace(manifestURL)
+	}
+	reader, _, err := clnt.Get(ctx, GetOptions{})
+	if err != nil {
+		return nil, err.Trace(manifestURL)
+	}
+	defer reader.Close()
+	m, e := inventory.ParseManifest(reader)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(manifestURL)
+	}
+	return &inventoryReport{manifestURL: manifestURL, alias: alias, manifest: m}, nil
+}
+
+// fileURL - the URL of a data file of the report. The keys of data
+// files are relative to the destination bucket, for a local copy of a
+// report the first parent folder of the manifest containing the key.
+func (r *inventoryReport) fileURL(key string) (string, *probe.Error) {
+	if r.alias != "" {
+		bucket := r.manifest.DestinationBucket
+		if bucket == "" {
+			_, manifestPath := url2Alias(r.manifestURL)
+			bucket, _, _ = strings.Cut(strings.TrimPrefix(filepath.ToSlash(manifestPath), "/"), "/")
+		}
+		return r.alias + "/" + bucket + "/" + key, nil
+	}
+	for dir := filepath.Dir(r.manifestURL); ; dir = filepath.Dir(dir) {
+		fileURL := filepath.Join(dir, filepath.FromSlash(key))
+		if _, e := os.Stat(fileURL); e == nil {
+			return fileURL, nil
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	return "", probe.NewError(ObjectMissing{}).Trace(r.manifestURL, key)
+}
+
+// read - call fn for every row of a data file of the report.
+func (r *inventoryReport) read(ctx context.Context, key string, fn func(inventory.Object) error) *probe.Error {
+	fileURL, err := r.fileURL(key)
+	if err != nil {
+		return err
+	}
+	clnt, err := newClient(fileURL)
+	if err != nil {
+		return err.Trace(fileURL)
+	}
+
+	if r.manifest.FileFormat == inventory.FormatCSV {
+		reader, _, err := clnt.Get(ctx, GetOptions{})
+		if err != nil {
+			return err.Trace(fileURL)
+		}
+		defer reader.Close()
+		if e := inventory.ReadCSV(reader, r.manifest.FileSchema, fn); e != nil && e != errStopInventory {
+			return probe.NewError(e).Trace(fileURL)
+		}
+		return nil
+	}
+
+	// Parquet is read from its end, a remote file is downloaded first.
+	f, e := os.Open(fileURL)
+	if r.alias != "" {
+		f, e = os.CreateTemp("", "mc-inventory-*.parquet")
+		if e == nil {
+			defer os.Remove(f.Name())
+			reader, _, err := clnt.Get(ctx, GetOptions{})
+			if err != nil {
+				f.Close()
+				return err.Trace(fileURL)
+			}
+			_, e = io.Copy(f, reader)
+			reader.Close()
+		}
+	}
+	if e != nil {
+		return probe.NewError(e).Trace(fileURL)
+	}
+	defer f.Close()
+	st, e := f.Stat()
+	if e != nil {
+		return probe.NewError(e).Trace(fileURL)
+	}
+	if e = inventory.ReadParquet(f, st.Size(), fn); e != nil && e != errStopInventory {
+		return probe.NewError(e).Trace(fileURL)
+	}
+	return nil
+}
+
+// listInventory - list the objects of clnt from the report of
+// manifestURL instead of the bucket. Like a recursive listing it sends
+// the latest version of every object below the prefix of clnt, older
+// versions and delete markers as requested by opts, no folders.
+func listInventory(ctx context.Context, manifestURL string, clnt Client, opts ListOptions) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent)
+	go func() {
+		defer close(contentCh)
+		send := func(content *ClientContent) bool {
+			select {
+			case contentCh <- content:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		s3Clnt, ok := clnt.(*S3Client)
+		if !ok {
+			send(&ClientContent{Err: probe.NewError(APINotImplemented{
+				API:     "--inventory",
+				APIType: clnt.GetURL().String(),
+			})})
+			return
+		}
+		bucket, prefix := s3Clnt.url2BucketAndObject()
+
+		report, err := openInventoryReport(ctx, manifestURL)
+		if err != nil {
+			send(&ClientContent{Err: err})
+			return
+		}
+		if bucket != "" && report.manifest.SourceBucket != bucket {
+			send(&ClientContent{Err: probe.NewError(fmt.Errorf("the inventory report of bucket `%s` does not list bucket `%s`", report.manifest.SourceBucket, bucket)).Trace(manifestURL)})
+			return
+		}
+
+		for _, file := range report.manifest.Files {
+			err = report.read(ctx, file.Key, func(obj inventory.Object) error {
+				switch {
+				case obj.Bucket != report.manifest.SourceBucket && obj.Bucket != "":
+					return nil
+				case !strings.HasPrefix(obj.Key, prefix):
+					return nil
+				case obj.IsDeleteMarker && !opts.WithDeleteMarkers:
+					return nil
+				case obj.VersionID != "" && !obj.IsLatest && !opts.WithOlderVersions:
+					return nil
+				}
+				info := minio.ObjectInfo{
+					Key:            obj.Key,
+					Size:           obj.Size,
+					ETag:           obj.ETag,
+					LastModified:   obj.LastModified,
+					StorageClass:   obj.StorageClass,
+					IsLatest:       obj.IsLatest,
+					IsDeleteMarker: obj.IsDeleteMarker,
+				}
+				// Like a listing of the latest versions, which has
+				// no version ids, act on the object and not the version.
+				if opts.WithOlderVersions {
+					info.VersionID = obj.VersionID
+				}
+				if !send(s3Clnt.objectInfo2ClientContent(report.manifest.SourceBucket, info)) {
+					return errStopInventory
+				}
+				return nil
+			})
+			if err != nil {
+				send(&ClientContent{Err: err})
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return contentCh
+}
+
+// inventoryPrefixes - the folder prefixes of key down to depth levels
+// below its first, all of them for a negative depth.
+func inventoryPrefixes(key string, depth int) []string {
+	var prefixes []string
+	for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		prefixes = append([]string{dir + "/"}, prefixes...)
+	}
+	if depth >= 0 && len(prefixes) > depth {
+		prefixes = prefixes[:depth]
+	}
+	return prefixes
+}
+
+// checkInventoryObject - the object listed by a report is still the
+// latest version of its key. Reports may be a day old and objects
+// overwritten since must not be removed by their key.
+func checkInventoryObject(ctx context.Context, alias string, content *ClientContent) *probe.Error {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return err
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return nil
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	info, e := s3Clnt.api.StatObject(ctx, bucket, object, minio.StatObjectOptions{})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	// HEAD has the modification time to the second.
+	if trimETag(info.ETag) != trimETag(content.ETag) || info.LastModified.Unix() != content.Time.Unix() {
+		return probe.NewError(errors.New("object was modified after the inventory report"))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/minio/mc/pkg/inventory"
+)
+
+func TestInventoryPrefixes(t *testing.T) {
+	testCases := []struct {
+		key      string
+		depth    int
+		expected []string
+	}{
+		{key: "a.txt", depth: -1, expected: nil},
+		{key: "a/b/c/d.txt", depth: -1, expected: []string{"a/", "a/b/", "a/b/c/"}},
+		{key: "a/b/c/d.txt", depth: 2, expected: []string{"a/", "a/b/"}},
+		{key: "a/b/c/d.txt", depth: 0, expected: []string{}},
+	}
+
+	for i, testCase := range testCases {
+		if prefixes := inventoryPrefixes(testCase.key, testCase.depth); !reflect.DeepEqual(prefixes, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, prefixes)
+		}
+	}
+}
+
+func TestInventoryReportFileURL(t *testing.T) {
+	dir := t.TempDir()
+	manifestDir := filepath.Join(dir, "src", "config", "2025-01-01T01-00Z")
+	dataFile := filepath.Join(dir, "src", "config", "data", "file.csv.gz")
+	for _, p := range []string{manifestDir, filepath.Dir(dataFile)} {
+		if e := os.MkdirAll(p, 0o755); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := os.WriteFile(dataFile, nil, 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	// A local report is found in a parent folder of its manifest.
+	report := inventoryReport{manifestURL: filepath.Join(manifestDir, "manifest.json"), manifest: &inventory.Manifest{}}
+	fileURL, err := report.fileURL("src/config/data/file.csv.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileURL != dataFile {
+		t.Fatalf("expected %s, got %s", dataFile, fileURL)
+	}
+	if _, err = report.fileURL("src/config/data/missing.csv.gz"); err == nil {
+		t.Fatal("expected an error for a missing data file")
+	}
+
+	// A remote report is in the destination bucket.
+	report = inventoryReport{manifestURL: "s3/reports/src/config/manifest.json", alias: "s3", manifest: &inventory.Manifest{DestinationBucket: "dst"}}
+	if fileURL, _ = report.fileURL("src/config/data/file.csv.gz"); fileURL != "s3/dst/src/config/data/file.csv.gz" {
+		t.Fatalf("unexpected URL %s", fileURL)
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/v3/console"
+)
+
+var joinCmd = cli.Command{
+	Name:         "join",
+	Usage:        "reassemble the chunks of 'mc split' into a file or an object",
+	Action:       mainJoin,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(encFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] PREFIX TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+DESCRIPTION:
+  The chunks are listed by PREFIX followed by 'manifest.json', as written by 'mc split'.
+
+  Chunks on the server of TARGET are concatenated by the server, which only
+  reads them while they have the ETag recorded by 'mc split'. Other chunks
+  are streamed in order and verified with their SHA-256 checksum, TARGET is
+  not written when one of them does not match.
+
+EXAMPLES:
+  01. Reassemble a disk image into a local file.
+      {{.Prompt}} {{.HelpName}} play/backups/disk.img. ~/images/disk.img
+
+  02. Reassemble a dataset into an object of the same site, without reading it.
+      {{.Prompt}} {{.HelpName}} play/partners/census-2020/ play/datasets/census-2020.csv
+`,
+}
+
+// joinMessage - chunks reassembled.
+type joinMessage struct {
+	Status     string `json:"status"`
+	Prefix     string `json:"prefix"`
+	Target     string `json:"target"`
+	Chunks     int    `json:"chunks"`
+	Size       int64  `json:"size"`
+	ServerSide bool   `json:"serverSide"`
+}
+
+// String colorized join message
+func (m joinMessage) String() string {
+	msg := fmt.Sprintf("Joined %d chunks of `%s` into `%s`, %s.", m.Chunks, m.Prefix, m.Target, humanize.IBytes(uint64(m.Size)))
+	if m.ServerSide {
+		msg = fmt.Sprintf("Joined %d chunks of `%s` into `%s` on the server, %s.", m.Chunks, m.Prefix, m.Target, humanize.IBytes(uint64(m.Size)))
+	}
+	return console.Colorize("Join", msg)
+}
+
+// JSON jsonified join message
+func (m joinMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// readSplitManifest - read the manifest of the chunks named after prefix.
+func readSplitManifest(ctx context.Context, prefix string, encKeyDB map[string][]prefixSSEPair) (splitManifest, *probe.Error) {
+	var manifest splitManifest
+	manifestURL := prefix + splitManifestName
+	reader, err := getSourceStreamFromURL(ctx, manifestURL, encKeyDB, getSourceOpts{})
+	if err != nil {
+		return manifest, err.Trace(manifestURL)
+	}
+	defer reader.Close()
+	data, e := io.ReadAll(reader)
+	if e != nil {
+		return manifest, probe.NewError(e).Trace(manifestURL)
+	}
+	if e = gojson.Unmarshal(data, &manifest); e != nil {
+		return manifest, probe.NewError(e).Trace(manifestURL)
+	}
+
+	if manifest.Version != 1 {
+		return manifest, probe.NewError(fmt.Errorf("unsupported version %d of the manifest", manifest.Version)).Trace(manifestURL)
+	}
+	var size int64
+	for _, chunk := range manifest.Chunks {
+		if chunk.Name == "" || strings.ContainsAny(chunk.Name, `/\`) || strings.Contains(chunk.Name, "..") {
+			return manifest, probe.NewError(fmt.Errorf("invalid chunk name `%s` in the manifest", chunk.Name)).Trace(manifestURL)
+		}
+		size += chunk.Size
+	}
+	if size != manifest.Size {
+		return manifest, probe.NewError(fmt.Errorf("the chunks of the manifest have %d bytes, expected %d", size, manifest.Size)).Trace(manifestURL)
+	}
+	return manifest, nil
+}
+
+// writeChunks - write the chunks of a manifest named after prefix to w in
+// order, each one is verified with its checksum once written.
+func writeChunks(ctx context.Context, w io.Writer, manifest splitManifest, prefix string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	for _, chunk := range manifest.Chunks {
+		chunkURL := prefix + chunk.Name
+		reader, err := getSourceStreamFromURL(ctx, chunkURL, encKeyDB, getSourceOpts{})
+		if err != nil {
+			return err.Trace(chunkURL)
+		}
+		sum := sha256.New()
+		n, e := io.Copy(io.MultiWriter(w, sum), io.LimitReader(reader, chunk.Size+1))
+		reader.Close()
+		if e != nil {
+			return probe.NewError(e).Trace(chunkURL)
+		}
+		if n != chunk.Size || hex.EncodeToString(sum.Sum(nil)) != chunk.SHA256 {
+			return probe.NewError(fmt.Errorf("`%s` does not match its checksum in the manifest", chunkURL))
+		}
+	}
+	return nil
+}
+
+// isComposable - whether the chunks of a manifest can be concatenated by
+// the server: they were uploaded to object storage, and all but the last
+// one are large enough to be parts of a multipart upload.
+func isComposable(manifest splitManifest) bool {
+	if len(manifest.Chunks) == 0 || len(manifest.Chunks) > 10000 || manifest.Size > 5*humanize.TiByte {
+		return false
+	}
+	for i, chunk := range manifest.Chunks {
+		if chunk.ETag == "" || (i < len(manifest.Chunks)-1 && chunk.Size < 5*humanize.MiByte) {
+			return false
+		}
+	}
+	return true
+}
+
+// compose - concatenate objects of the same server into this object.
+func (c *S3Client) compose(ctx context.Context, sources []minio.CopySrcOptions, sse encrypt.ServerSide, metadata map[string]string) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	_, e := c.api.ComposeObject(ctx, minio.CopyDestOptions{
+		Bucket:          bucket,
+		Object:          object,
+		Encryption:      sse,
+		UserMetadata:    metadata,
+		ReplaceMetadata: len(metadata) > 0,
+	}, sources...)
+	if e != nil {
+		// The multipart upload of a failed composition is not aborted.
+		c.api.RemoveIncompleteUpload(ctx, bucket, object)
+		switch minio.ToErrorResponse(e).Code {
+		case "AccessDenied":
+			return probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+		case "NoSuchBucket":
+			return probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		case "NoSuchKey":
+			return probe.NewError(ObjectMissing{})
+		case "PreconditionFailed":
+			return probe.NewError(errors.New("a chunk changed since it was split"))
+		}
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// chunkChecksumMatch - compare the full object SHA-256 checksum of a chunk
+// uploaded to object storage with the manifest, known is false when the
+// server has no such checksum.
+func chunkChecksumMatch(content *ClientContent, chunk splitChunk) (match, known bool) {
+	value, ok := fullObjectChecksum(content, "SHA256")
+	if !ok {
+		return false, false
+	}
+	sum, e := hex.DecodeString(chunk.SHA256)
+	if e != nil {
+		return false, true
+	}
+	return content.Size == chunk.Size && base64.StdEncoding.EncodeToString(sum) == value, true
+}
+
+// joinOnServer - concatenate the chunks of a manifest into targetURL on
+// the server, false when they are not on the server of targetURL or the
+// server does not have their SHA-256 checksum. Chunks are only read while
+// they have the ETag of the manifest.
+func joinOnServer(ctx context.Context, manifest splitManifest, prefix, targetURL string, encKeyDB map[string][]prefixSSEPair) (bool, *probe.Error) {
+	if !isComposable(manifest) {
+		return false, nil
+	}
+	targetAlias, targetURLFull, _, err := expandAlias(targetURL)
+	if err != nil {
+		return false, err.Trace(targetURL)
+	}
+	clnt, err := newClientFromAlias(targetAlias, targetURLFull)
+	if err != nil {
+		return false, err.Trace(targetURL)
+	}
+	target, ok := clnt.(*S3Client)
+	tgtSSE := getSSE(targetURL, encKeyDB[targetAlias])
+	if _, clientKey := tgtSSE.(*clientKey); !ok || clientKey {
+		return false, nil
+	}
+
+	urls := URLs{TargetAlias: targetAlias, TargetContent: &ClientContent{URL: target.GetURL()}}
+	sources := make([]minio.CopySrcOptions, 0, len(manifest.Chunks))
+	for _, chunk := range manifest.Chunks {
+		chunkURL := prefix + chunk.Name
+		alias, urlStrFull, _, err := expandAlias(chunkURL)
+		if err != nil {
+			return false, err.Trace(chunkURL)
+		}
+		clnt, err := newClientFromAlias(alias, urlStrFull)
+		if err != nil {
+			return false, err.Trace(chunkURL)
+		}
+		source, ok := clnt.(*S3Client)
+		srcSSE := getSSE(chunkURL, encKeyDB[alias])
+		if _, clientKey := srcSSE.(*clientKey); !ok || clientKey {
+			return false, nil
+		}
+		// Chunks are only composed when the server has the checksum of
+		// the manifest, they are streamed and verified otherwise.
+		content, err := source.Stat(ctx, StatOptions{sse: srcSSE})
+		if err != nil {
+			return false, err.Trace(chunkURL)
+		}
+		match, known := chunkChecksumMatch(content, chunk)
+		if !known {
+			return false, nil
+		}
+		if !match {
+			return false, probe.NewError(fmt.Errorf("`%s` does not match its checksum in the manifest", chunkURL))
+		}
+		bucket, object := source.url2BucketAndObject()
+		sources = append(sources, minio.CopySrcOptions{
+			Bucket:     bucket,
+			Object:     object,
+			MatchETag:  chunk.ETag,
+			Encryption: srcSSE,
+		})
+		urls.SourceAlias, urls.SourceContent = alias, &ClientContent{URL: source.GetURL()}
+	}
+	if urls.SourceAlias != targetAlias && !sameCluster(ctx, urls) {
+		return false, nil
+	}
+
+	err = target.compose(ctx, sources, tgtSSE, map[string]string{"Content-Type": guessURLContentType(targetURL)})
+	// The credentials of the target alias may not read the chunks.
+	if urls.SourceAlias != targetAlias && isAccessDenied(err) {
+		return false, nil
+	}
+	return err == nil, err.Trace(targetURL)
+}
+
+// joinStream - stream the chunks of a manifest into targetURL. The size of
+// the upload is not set, the target is only written once the last chunk
+// is verified.
+func joinStream(ctx context.Context, manifest splitManifest, prefix, targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	pr, pw := io.Pipe()
+	var joinErr *probe.Error
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		joinErr = writeChunks(ctx, pw, manifest, prefix, encKeyDB)
+		if joinErr != nil {
+			pw.CloseWithError(joinErr.ToGoError())
+		} else {
+			pw.Close()
+		}
+	}()
+
+	alias, targetURLFull, _, err := expandAlias(targetURL)
+	if err != nil {
+		pr.CloseWithError(io.ErrClosedPipe)
+		<-doneCh
+		return err.Trace(targetURL)
+	}
+	opts := PutOptions{
+		sse:      getSSE(targetURL, encKeyDB[alias]),
+		metadata: map[string]string{"Content-Type": guessURLContentType(targetURL)},
+	}
+	if _, partSize, _, e := minio.OptimalPartInfo(manifest.Size, 0); e == nil {
+		opts.multipartSize = uint64(partSize)
+	}
+	size, err := putTargetStream(ctx, alias, targetURLFull, "", "", "", pr, -1, nil, opts)
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-doneCh
+	// A chunk which does not match fails the upload as well, while a
+	// failed upload only closes the pipe of the chunks.
+	if joinErr != nil && !errors.Is(joinErr.ToGoError(), io.ErrClosedPipe) {
+		return joinErr
+	}
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	if size != manifest.Size {
+		return probe.NewError(UnexpectedEOF{TotalSize: manifest.Size, TotalWritten: size}).Trace(targetURL)
+	}
+	return nil
+}
+
+// mainJoin is the handle for "mc join" command.
+func mainJoin(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	console.SetColor("Join", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelJoin := context.WithCancel(globalContext)
+	defer cancelJoin()
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	prefix := cliCtx.Args().Get(0)
+	targetURL := cliCtx.Args().Get(1)
+
+	manifest, err := readSplitManifest(ctx, prefix, encKeyDB)
+	fatalIf(err.Trace(prefix), "Unable to read the manifest of `%s`.", prefix)
+
+	serverSide, err := joinOnServer(ctx, manifest, prefix, targetURL, encKeyDB)
+	if err == nil && !serverSide {
+		err = joinStream(ctx, manifest, prefix, targetURL, encKeyDB)
+	}
+	fatalIf(err, "Unable to join the chunks of `%s`.", prefix)
+
+	printMsg(joinMessage{
+		Prefix:     prefix,
+		Target:     targetURL,
+		Chunks:     len(manifest.Chunks),
+		Size:       manifest.Size,
+		ServerSide: serverSide,
+	})
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/text/unicode/norm"
+)
+
+var renameFlag = cli.StringFlag{
+	Name:  "rename",
+	Usage: "rewrite target object names with a sed like expression, e.g. 's#^logs/([0-9]+)/#year=$1/#'",
+}
+
+// keyRename - a sed like 's/regex/replacement/[g]' substitution
+// applied to object names relative to the target.
+type keyRename struct {
+	re     *regexp.Regexp
+	repl   string
+	global bool
+}
+
+// sedBackReference matches the \N back references of sed replacements.
+var sedBackReference = regexp.MustCompile(`\\([0-9])`)
+
+// splitSedExpression - split on unescaped delimiters, escape sequences
+// are kept as they are.
+func splitSedExpression(expr string, delim byte) []string {
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && i+1 < len(expr):
+			part.WriteString(expr[i : i+2])
+			i++
+		case expr[i] == delim:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(expr[i])
+		}
+	}
+	return append(parts, part.String())
+}
+
+// parseKeyRename - parse a 's#regex#replacement#flags' expression, any
+// character following 's' is the delimiter. The replacement accepts both
+// '$1' and '\1' back references, the 'g' flag replaces all matches.
+func parseKeyRename(expr string) (*keyRename, error) {
+	if len(expr) < 4 || expr[0] != 's' {
+		return nil, fmt.Errorf("invalid rename expression `%s`, expected 's#regex#replacement#'", expr)
+	}
+	delim := expr[1]
+	if delim == '\\' || delim == '\n' {
+		return nil, fmt.Errorf("invalid delimiter in rename expression `%s`", expr)
+	}
+
+	parts := splitSedExpression(expr[2:], delim)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid rename expression `%s`, expected 's%cregex%creplacement%c'", expr, delim, delim, delim)
+	}
+
+	rename := &keyRename{}
+	for _, flag := range parts[2] {
+		switch flag {
+		case 'g':
+			rename.global = true
+		default:
+			return nil, fmt.Errorf("unknown flag `%c` in rename expression `%s`", flag, expr)
+		}
+	}
+
+	// An escaped delimiter is a literal character in both parts.
+	escapedDelim := `\` + string(delim)
+	re, e := regexp.Compile(strings.ReplaceAll(parts[0], escapedDelim, regexp.QuoteMeta(string(delim))))
+	if e != nil {
+		return nil, fmt.Errorf("invalid regular expression in `%s`: %w", expr, e)
+	}
+	rename.re = re
+	rename.repl = sedBackReference.ReplaceAllString(strings.ReplaceAll(parts[1], escapedDelim, string(delim)), "$${$1}")
+	return rename, nil
+}
+
+// parseRenameFlag - parse the expression of --rename, if any.
+func parseRenameFlag(cliCtx *cli.Context) *keyRename {
+	expr := cliCtx.String("rename")
+	if expr == "" {
+		return nil
+	}
+	rename, e := parseKeyRename(expr)
+	fatalIf(probe.NewError(e), "Unable to parse --rename expression.")
+	return rename
+}
+
+// Apply - rewrite an object name relative to the target, names without
+// a match are kept as they are. A nil keyRename keeps all names.
+func (r *keyRename) Apply(name string) (string, error) {
+	if r == nil {
+		return name, nil
+	}
+
+	name = filepath.ToSlash(name)
+	prefix := ""
+	if strings.HasPrefix(name, "/") {
+		prefix, name = "/", name[1:]
+	}
+
+	var renamed string
+	if r.global {
+		renamed = r.re.ReplaceAllString(name, r.repl)
+	} else if loc := r.re.FindStringSubmatchIndex(name); loc != nil {
+		renamed = name[:loc[0]] + string(r.re.ExpandString(nil, r.repl, name, loc)) + name[loc[1]:]
+	} else {
+		renamed = name
+	}
+
+	if renamed == "" || strings.HasSuffix(renamed, "/") {
+		return "", fmt.Errorf("`%s` is renamed to an invalid object name `%s`", name, renamed)
+	}
+	return prefix + renamed, nil
+}
+
+// renameSourceListing - sort a source listing by renamed target URL, the
+// order the difference of a source and a target is computed in. The whole
+// listing is held in memory, names renamed to the same target are an error
+// reported before any object is sent.
+func renameSourceListing(sourceURL, targetURL string, rename *keyRename, srcCh <-chan *ClientContent) <-chan *ClientContent {
+	sortedCh := make(chan *ClientContent)
+
+	go func() {
+		defer close(sortedCh)
+
+		type renamedContent struct {
+			key     string
+			content *ClientContent
+		}
+		var contents []renamedContent
+		for content := range srcCh {
+			if content.Err != nil {
+				sortedCh <- content
+				return
+			}
+			// Invalid names keep an empty key, they are reported
+			// by the comparison which renames them again.
+			var key string
+			if renamed, e := rename.Apply(strings.TrimPrefix(content.URL.String(), sourceURL)); e == nil {
+				key = norm.NFC.String(urlJoinPath(targetURL, renamed))
+			}
+			contents = append(contents, renamedContent{key: key, content: content})
+		}
+
+		sort.SliceStable(contents, func(i, j int) bool {
+			return contents[i].key < contents[j].key
+		})
+		for i := 1; i < len(contents); i++ {
+			if contents[i].key != "" && contents[i-1].key == contents[i].key {
+				sortedCh <- &ClientContent{Err: probe.NewError(ObjectNameCollision{
+					First:  contents[i-1].content.URL.String(),
+					Second: contents[i].content.URL.String(),
+					Target: contents[i].key,
+				})}
+				return
+			}
+		}
+		for _, c := range contents {
+			sortedCh <- c.content
+		}
+	}()
+
+	return sortedCh
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/console"
 )
@@ -95,8 +96,11 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 	}
 	prefixPath = strings.TrimPrefix(prefixPath, "./")
 
+	alias, _, _ := mustExpandAlias(urlStr)
 	if !recursive && !withVersions {
-		err = clnt.PutObjectLegalHold(ctx, versionID, lhold)
+		err = aliasRetryPolicy(alias).do(ctx, nil, nil, func() *probe.Error {
+			return clnt.PutObjectLegalHold(ctx, versionID, lhold)
+		})
 		if err != nil {
 			errorIf(err.Trace(urlStr), "Failed to set legal hold on `%s` successfully", urlStr)
 		} else {
@@ -114,7 +118,6 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 		return nil
 	}
 
-	alias, _, _ := mustExpandAlias(urlStr)
 	var cErr error
 	objectsFound := false
 	lstOptions := ListOptions{Recursive: recursive, ShowDir: DirNone}
@@ -141,7 +144,9 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 			continue
 		}
 
-		probeErr := newClnt.PutObjectLegalHold(ctx, content.VersionID, lhold)
+		probeErr := aliasRetryPolicy(alias).do(ctx, nil, nil, func() *probe.Error {
+			return newClnt.PutObjectLegalHold(ctx, content.VersionID, lhold)
+		})
 		if probeErr != nil {
 			errorIf(probeErr.Trace(content.URL.Path), "Failed to set legal hold on `%s` successfully", content.URL.Path)
 		} else {
@@ -418,7 +418,9 @@ var appCmds = []cli.Command{
 	aliasCmd,
 	adminCmd,
 	anonymousCmd,
+	archiveCmd,
 	batchCmd,
+	bisyncCmd,
 	cpCmd,
 	catCmd,
 	corsCmd,
@@ -431,10 +433,12 @@ var appCmds = []cli.Command{
 	headCmd,
 	ilmCmd,
 	idpCmd,
+	joinCmd,
 	licenseCmd,
 	legalHoldCmd,
 	lsCmd,
 	mbCmd,
+	metaCmd,
 	mvCmd,
 	mirrorCmd,
 	odCmd,
@@ -451,7 +455,10 @@ var appCmds = []cli.Command{
 	sqlCmd,
 	statCmd,
 	supportCmd,
+	syncCmd,
+	sessionCmd,
 	shareCmd,
+	splitCmd,
 	treeCmd,
 	tagCmd,
 	undoCmd,
@@ -0,0 +1,274 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// manifestStatWorkers - the number of objects of a manifest looked up concurrently.
+const manifestStatWorkers = 16
+
+// manifestEntry - a row of a manifest, in CSV the columns are in this
+// order and the metadata is formatted like --attr.
+type manifestEntry struct {
+	Source    string            `json:"source"`
+	VersionID string            `json:"versionId,omitempty"`
+	Target    string            `json:"target,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// manifest - a file listing the objects a command acts on. Sources are
+// aliased URLs or local paths, relative to base when it is set.
+type manifest struct {
+	filename string
+	base     string
+}
+
+// parseManifestFlag - the manifest of --manifest, nil if not set.
+func parseManifestFlag(cliCtx *cli.Context, base string) *manifest {
+	filename := cliCtx.String("manifest")
+	if filename == "" {
+		return nil
+	}
+	return &manifest{filename: filename, base: base}
+}
+
+// source - the URL of the source of an entry.
+func (m manifest) source(entry manifestEntry) string {
+	if m.base == "" {
+		return entry.Source
+	}
+	return urlJoinPath(m.base, entry.Source)
+}
+
+// targetKey - the path of the copy of an entry below the target: the
+// target of the entry if set, else its source relative to the base, the
+// object key of the source or the name of a local file.
+func (m manifest) targetKey(entry manifestEntry) string {
+	switch {
+	case entry.Target != "":
+		return entry.Target
+	case m.base != "":
+		return entry.Source
+	}
+	alias, _, hostCfg := mustExpandAlias(entry.Source)
+	if alias == "" || hostCfg == nil {
+		return filepath.Base(entry.Source)
+	}
+	_, object := url2Alias(entry.Source)
+	_, key, _ := strings.Cut(filepath.ToSlash(object), "/")
+	return key
+}
+
+// read - call fn for every entry of the manifest until it returns false.
+func (m manifest) read(fn func(manifestEntry) bool) *probe.Error {
+	r := io.Reader(os.Stdin)
+	if m.filename != "-" {
+		f, e := os.Open(m.filename)
+		if e != nil {
+			return probe.NewError(e).Trace(m.filename)
+		}
+		defer f.Close()
+		r = f
+	}
+	if e := parseManifest(r, fn); e != nil {
+		return probe.NewError(e).Trace(m.filename)
+	}
+	return nil
+}
+
+// parseManifest - parse a manifest in CSV or JSON lines, a JSON
+// manifest starts with '{'. A first CSV row starting with a 'source'
+// column is a header.
+func parseManifest(r io.Reader, fn func(manifestEntry) bool) error {
+	br := bufio.NewReader(r)
+	for {
+		c, _, e := br.ReadRune()
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+		if !unicode.IsSpace(c) {
+			br.UnreadRune()
+			if c == '{' {
+				return parseManifestJSON(br, fn)
+			}
+			return parseManifestCSV(br, fn)
+		}
+	}
+}
+
+func parseManifestJSON(r io.Reader, fn func(manifestEntry) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry manifestEntry
+		if e := json.Unmarshal([]byte(text), &entry); e != nil {
+			return fmt.Errorf("line %d: %w", line, e)
+		}
+		if entry.Source == "" {
+			return fmt.Errorf("line %d: missing source", line)
+		}
+		if !fn(entry) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func parseManifestCSV(r io.Reader, fn func(manifestEntry) bool) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	for first := true; ; first = false {
+		record, e := reader.Read()
+		if errors.Is(e, io.EOF) {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+		line, _ := reader.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "source") {
+			continue
+		}
+		if len(record) > 4 {
+			return fmt.Errorf("line %d: expected at most 4 columns 'source,versionId,target,metadata', got %d", line, len(record))
+		}
+		record = append(record, make([]string, 4-len(record))...)
+		entry := manifestEntry{
+			Source:    record[0],
+			VersionID: strings.TrimSpace(record[1]),
+			Target:    record[2],
+		}
+		if entry.Source == "" {
+			return fmt.Errorf("line %d: missing source", line)
+		}
+		if record[3] != "" {
+			metadata, err := getMetaDataEntry(record[3])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err.ToGoError())
+			}
+			entry.Metadata = metadata
+		}
+		if !fn(entry) {
+			return nil
+		}
+	}
+}
+
+// prepareManifestCopyURLs - the copies of the objects of a manifest to
+// targetURL, the sources are looked up concurrently without listing.
+// An object which cannot be copied is sent with its error and its
+// source, errors of the manifest itself have no source.
+func prepareManifestCopyURLs(ctx context.Context, m manifest, targetURL string, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+	urlsCh := make(chan URLs)
+	entryCh := make(chan manifestEntry)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(entryCh)
+		err := m.read(func(entry manifestEntry) bool {
+			select {
+			case entryCh <- entry:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil {
+			select {
+			case urlsCh <- URLs{Error: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	targetAlias, expandedTarget, _ := mustExpandAlias(targetURL)
+	for i := 0; i < manifestStatWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range entryCh {
+				urls := m.copyURLs(ctx, entry, targetAlias, expandedTarget, encKeyDB)
+				select {
+				case urlsCh <- urls:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(urlsCh)
+	}()
+	return urlsCh
+}
+
+// copyURLs - the copy of an entry of a manifest.
+func (m manifest) copyURLs(ctx context.Context, entry manifestEntry, targetAlias, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+	sourceURL := m.source(entry)
+	sourceAlias, _, _ := mustExpandAlias(sourceURL)
+	targetContent := ClientContent{
+		URL:          *newClientURL(urlJoinPath(targetURL, strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(m.targetKey(entry))), "/"))),
+		UserMetadata: entry.Metadata,
+	}
+	urls := URLs{
+		SourceAlias:   sourceAlias,
+		SourceContent: &ClientContent{URL: *newClientURL(sourceURL), VersionID: entry.VersionID},
+		TargetAlias:   targetAlias,
+		TargetContent: &targetContent,
+	}
+
+	_, content, err := url2Stat(ctx, url2StatOptions{urlStr: sourceURL, versionID: entry.VersionID, encKeyDB: encKeyDB, headOnly: true})
+	if err != nil {
+		return urls.WithError(err.Trace(sourceURL))
+	}
+	if !content.Type.IsRegular() {
+		return urls.WithError(errInvalidSource(sourceURL).Trace(sourceURL))
+	}
+	urls.SourceContent = content
+	return urls
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	testCases := []struct {
+		manifest string
+		entries  []manifestEntry
+		err      bool
+	}{
+		{
+			manifest: "source,versionId,target,metadata\n" +
+				"a/b.txt\n" +
+				"# comment\n" +
+				"c.txt,v1\n" +
+				"\"d,e.txt\",,new/d.txt,\"k1=v1;k2=v2\"\n",
+			entries: []manifestEntry{
+				{Source: "a/b.txt"},
+				{Source: "c.txt", VersionID: "v1"},
+				{Source: "d,e.txt", Target: "new/d.txt", Metadata: map[string]string{"K1": "v1", "K2": "v2"}},
+			},
+		},
+		{
+			manifest: "\n  {\"source\": \"a/b.txt\", \"versionId\": \"v1\"}\n\n" +
+				"{\"source\": \"c.txt\", \"target\": \"d.txt\", \"metadata\": {\"k\": \"v\"}}\n",
+			entries: []manifestEntry{
+				{Source: "a/b.txt", VersionID: "v1"},
+				{Source: "c.txt", Target: "d.txt", Metadata: map[string]string{"k": "v"}},
+			},
+		},
+		{manifest: ""},
+		{manifest: "a,b,c,d,e\n", err: true},
+		{manifest: ",v1\n", err: true},
+		{manifest: "a,,,novalue\n", err: true},
+		{manifest: "{\"source\": \"a\"}\n{\"target\": \"b\"}\n", err: true},
+		{manifest: "{\"source\": \n", err: true},
+	}
+
+	for i, testCase := range testCases {
+		var entries []manifestEntry
+		e := parseManifest(strings.NewReader(testCase.manifest), func(entry manifestEntry) bool {
+			entries = append(entries, entry)
+			return true
+		})
+		if testCase.err {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if !reflect.DeepEqual(entries, testCase.entries) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.entries, entries)
+		}
+	}
+}
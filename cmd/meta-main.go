@@ -0,0 +1,512 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/v3/console"
+	"golang.org/x/net/http/httpguts"
+)
+
+var metaSubcommands = []cli.Command{
+	metaSetCmd,
+	metaRemoveCmd,
+}
+
+var metaCmd = cli.Command{
+	Name:            "meta",
+	Usage:           "edit the metadata of objects in place",
+	Action:          mainMeta,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     metaSubcommands,
+}
+
+// mainMeta is the handle for "mc meta" command.
+func mainMeta(ctx *cli.Context) error {
+	commandNotFound(ctx, metaSubcommands)
+	return nil
+}
+
+// metaWorkers - the default number of objects updated concurrently.
+const metaWorkers = 16
+
+// metaHeaders - the metadata of objects other than user metadata which
+// can be edited.
+var metaHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+	"X-Amz-Website-Redirect-Location",
+}
+
+var metaFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "edit the metadata of all the objects of a prefix",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the objects which would be updated without updating them",
+	},
+	cli.IntFlag{
+		Name:  "max-workers",
+		Usage: "maximum number of objects updated concurrently",
+		Value: metaWorkers,
+	},
+	cli.StringFlag{
+		Name:  "name",
+		Usage: "only edit object names matching wildcard pattern",
+	},
+	cli.StringFlag{
+		Name:  "path",
+		Usage: "only edit objects of directory names matching wildcard pattern",
+	},
+	cli.StringFlag{
+		Name:  "regex",
+		Usage: "only edit objects with directory and object name matching RE2 regex pattern",
+	},
+	cli.StringFlag{
+		Name:  "ignore",
+		Usage: "exclude objects matching the wildcard pattern",
+	},
+	cli.StringFlag{
+		Name:  "newer-than",
+		Usage: "only edit objects newer than value in duration string (e.g. 7d10h31s)",
+	},
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "only edit objects older than value in duration string (e.g. 7d10h31s)",
+	},
+	cli.StringFlag{
+		Name:  "larger",
+		Usage: "only edit objects larger than specified size in units (see UNITS)",
+	},
+	cli.StringFlag{
+		Name:  "smaller",
+		Usage: "only edit objects smaller than specified size in units (see UNITS)",
+	},
+	cli.StringSliceFlag{
+		Name:  "metadata",
+		Usage: "only edit objects with metadata matching RE2 regex pattern. Specify each with key=regex. MinIO server only.",
+	},
+	cli.StringSliceFlag{
+		Name:  "tags",
+		Usage: "only edit objects with tags matching RE2 regex pattern. Specify each with key=regex. MinIO server only.",
+	},
+}
+
+// metaMessage - the metadata of an object edited.
+type metaMessage struct {
+	Status    string            `json:"status"`
+	Target    string            `json:"target"`
+	VersionID string            `json:"versionId,omitempty"`
+	Updated   bool              `json:"updated"`
+	DryRun    bool              `json:"dryRun,omitempty"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// String colorized meta message
+func (m metaMessage) String() string {
+	switch {
+	case !m.Updated:
+		return console.Colorize("MetaUnchanged", fmt.Sprintf("Metadata of `%s` unchanged.", m.Target))
+	case m.DryRun:
+		return console.Colorize("Meta", fmt.Sprintf("Metadata of `%s` would be updated.", m.Target))
+	}
+	return console.Colorize("Meta", fmt.Sprintf("Metadata of `%s` updated.", m.Target))
+}
+
+// JSON jsonified meta message
+func (m metaMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// metaSummaryMessage - the objects of a prefix edited.
+type metaSummaryMessage struct {
+	Status    string `json:"status"`
+	Target    string `json:"target"`
+	Updated   int64  `json:"updated"`
+	Unchanged int64  `json:"unchanged"`
+	Failed    int64  `json:"failed"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+// String colorized meta summary message
+func (m metaSummaryMessage) String() string {
+	verb := "Updated"
+	if m.DryRun {
+		verb = "Would update"
+	}
+	return console.Colorize("MetaSummary", fmt.Sprintf("%s %d objects of `%s`, %d unchanged, %d failed.", verb, m.Updated, m.Target, m.Unchanged, m.Failed))
+}
+
+// JSON jsonified meta summary message
+func (m metaSummaryMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// metaKey - the header of a metadata key, user metadata unless it is one
+// of metaHeaders.
+func metaKey(key string) string {
+	key = http.CanonicalHeaderKey(strings.TrimSpace(key))
+	if slices.Contains(metaHeaders, key) || strings.HasPrefix(key, "X-Amz-Meta-") {
+		return key
+	}
+	return "X-Amz-Meta-" + key
+}
+
+// editMetadata - the editable metadata of an object with keys set and
+// removed, false when it does not change.
+func editMetadata(current, set map[string]string, remove []string) (map[string]string, bool) {
+	metadata := make(map[string]string)
+	for k, v := range current {
+		k = http.CanonicalHeaderKey(k)
+		if slices.Contains(metaHeaders, k) || strings.HasPrefix(k, "X-Amz-Meta-") {
+			metadata[k] = v
+		}
+	}
+	changed := false
+	for k, v := range set {
+		if old, ok := metadata[k]; !ok || old != v {
+			metadata[k] = v
+			changed = true
+		}
+	}
+	for _, k := range remove {
+		if _, ok := metadata[k]; ok {
+			delete(metadata, k)
+			changed = true
+		}
+	}
+	return metadata, changed
+}
+
+// metaJob - the edition of the metadata of objects.
+type metaJob struct {
+	targetURL string
+	alias     string
+	set       map[string]string
+	remove    []string
+	recursive bool
+	dryRun    bool
+	workers   int
+	encKeyDB  map[string][]prefixSSEPair
+	filter    *findContext
+
+	updated, unchanged, failed atomic.Int64
+}
+
+// newMetaJob - the edition of the metadata of TARGET, the last argument.
+func newMetaJob(cliCtx *cli.Context, set map[string]string, remove []string) *metaJob {
+	targetURL := cliCtx.Args()[len(cliCtx.Args())-1]
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	alias, _, hostCfg, err := expandAlias(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to expand alias.")
+	var targetFullURL string
+	if hostCfg != nil {
+		targetFullURL = hostCfg.URL
+	}
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize `%s`.", targetURL)
+	if clnt.GetURL().Type != objectStorage {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Only the metadata of objects can be edited.")
+	}
+
+	// The filters of find select the objects of a prefix.
+	filter := &findContext{
+		Context:       cliCtx,
+		namePattern:   cliCtx.String("name"),
+		pathPattern:   cliCtx.String("path"),
+		ignorePattern: cliCtx.String("ignore"),
+		olderThan:     cliCtx.String("older-than"),
+		newerThan:     cliCtx.String("newer-than"),
+		matchMeta:     getRegexMap(cliCtx, "metadata"),
+		matchTags:     getRegexMap(cliCtx, "tags"),
+		targetAlias:   alias,
+		targetURL:     targetURL,
+		targetFullURL: targetFullURL,
+		clnt:          clnt,
+	}
+	if cliCtx.String("regex") != "" {
+		regex, e := regexp.Compile(cliCtx.String("regex"))
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("regex")), "Unable to parse --regex.")
+		filter.regexPattern = regex
+	}
+	for flag, size := range map[string]*uint64{"larger": &filter.largerSize, "smaller": &filter.smallerSize} {
+		if cliCtx.String(flag) != "" {
+			var e error
+			*size, e = humanize.ParseBytes(cliCtx.String(flag))
+			fatalIf(probe.NewError(e).Trace(cliCtx.String(flag)), "Unable to parse input bytes.")
+		}
+	}
+
+	workers := cliCtx.Int("max-workers")
+	if workers <= 0 {
+		workers = metaWorkers
+	}
+	return &metaJob{
+		targetURL: targetURL,
+		alias:     alias,
+		set:       set,
+		remove:    remove,
+		recursive: cliCtx.Bool("recursive"),
+		dryRun:    cliCtx.Bool("dry-run"),
+		workers:   workers,
+		encKeyDB:  encKeyDB,
+		filter:    filter,
+	}
+}
+
+// update - edit the metadata of an object with a server side copy to
+// itself, which keeps its tags, its retention and its storage class.
+func (m *metaJob) update(ctx context.Context, objectURL string) (metaMessage, *probe.Error) {
+	clnt, err := newClientFromAlias(m.alias, objectURL)
+	if err != nil {
+		return metaMessage{}, err.Trace(objectURL)
+	}
+	objectPath := filepath.ToSlash(filepath.Join(m.alias, clnt.GetURL().Path))
+	sse := getSSE(objectPath, m.encKeyDB[m.alias])
+	content, err := clnt.Stat(ctx, StatOptions{sse: sse, headOnly: true})
+	if err != nil {
+		return metaMessage{}, err.Trace(objectPath)
+	}
+	if content.Type.IsDir() {
+		return metaMessage{}, probe.NewError(PathIsNotRegular{Path: objectPath}).Trace(objectPath)
+	}
+
+	metadata, changed := editMetadata(content.Metadata, m.set, m.remove)
+	msg := metaMessage{
+		Target:    objectPath,
+		VersionID: content.VersionID,
+		Updated:   changed,
+		DryRun:    m.dryRun,
+		Metadata:  metadata,
+	}
+	if !changed || m.dryRun {
+		return msg, nil
+	}
+
+	// Objects encrypted on the client are copied as they are stored,
+	// others keep their server side encryption.
+	if _, ok := sse.(*clientKey); ok {
+		sse = nil
+	} else if sse == nil {
+		if sse, err = serverSideEncryption(content.Metadata); err != nil {
+			return metaMessage{}, err.Trace(objectPath)
+		}
+	}
+
+	copyMetadata := make(map[string]string, len(metadata)+3)
+	for k, v := range metadata {
+		copyMetadata[k] = v
+	}
+	for _, k := range []string{AmzObjectLockMode, AmzObjectLockRetainUntilDate, AmzObjectLockLegalHold} {
+		if v := content.Metadata[k]; v != "" {
+			copyMetadata[k] = v
+		}
+	}
+	// The storage class of a HEAD is only in its headers.
+	storageClass := content.StorageClass
+	if storageClass == "" {
+		storageClass = content.Metadata["X-Amz-Storage-Class"]
+	}
+	// A write after the HEAD fails the copy instead of being overwritten
+	// by the former content.
+	opts := CopyOptions{
+		versionID:        content.VersionID,
+		matchETag:        trimETag(content.ETag),
+		size:             content.Size,
+		srcSSE:           sse,
+		tgtSSE:           sse,
+		metadata:         copyMetadata,
+		disableMultipart: content.Size <= 5*humanize.GiByte,
+		storageClass:     storageClass,
+		replaceMetadata:  true,
+	}
+	// Objects over 5GiB are copied in parts, which only keep the tags
+	// they are given.
+	if !opts.disableMultipart {
+		if opts.tags, err = clnt.GetTags(ctx, content.VersionID); err != nil {
+			return metaMessage{}, err.Trace(objectPath)
+		}
+	}
+	err = aliasRetryPolicy(m.alias).do(ctx, nil, nil, func() *probe.Error {
+		return clnt.Copy(ctx, clnt.GetURL().Path, opts, nil)
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "PreconditionFailed" {
+			return metaMessage{}, probe.NewError(errors.New("the object changed while its metadata was edited, its new content is kept")).Trace(objectPath)
+		}
+		return metaMessage{}, err.Trace(objectPath)
+	}
+	return msg, nil
+}
+
+// serverSideEncryption - the server side encryption of an object from
+// the metadata of its HEAD, with the encryption context of SSE-KMS.
+func serverSideEncryption(metadata map[string]string) (encrypt.ServerSide, *probe.Error) {
+	switch metadata["X-Amz-Server-Side-Encryption"] {
+	case "aws:kms":
+		var kmsContext interface{}
+		if value := metadata["X-Amz-Server-Side-Encryption-Context"]; value != "" {
+			data, e := base64.StdEncoding.DecodeString(value)
+			if e != nil {
+				return nil, probe.NewError(e)
+			}
+			var values map[string]interface{}
+			if e = gojson.Unmarshal(data, &values); e != nil {
+				return nil, probe.NewError(e)
+			}
+			kmsContext = values
+		}
+		sse, e := encrypt.NewSSEKMS(metadata["X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"], kmsContext)
+		return sse, probe.NewError(e)
+	case "AES256":
+		return encrypt.NewSSE(), nil
+	}
+	return nil, nil
+}
+
+// run - edit the metadata of the target object, or of the objects of the
+// target prefix matching the filters. False when any of them failed.
+func (m *metaJob) run(ctx context.Context) bool {
+	if !m.recursive {
+		msg, err := m.update(ctx, m.filter.clnt.GetURL().String())
+		fatalIf(err, "Unable to edit the metadata of `%s`.", m.targetURL)
+		printMsg(msg)
+		return true
+	}
+
+	objectCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < m.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for objectURL := range objectCh {
+				msg, err := m.update(ctx, objectURL)
+				switch {
+				case err != nil:
+					m.failed.Add(1)
+					errorIf(err, "Unable to edit the metadata of `%s`.", objectURL)
+					continue
+				case msg.Updated:
+					m.updated.Add(1)
+				default:
+					m.unchanged.Add(1)
+				}
+				printMsg(msg)
+			}
+		}()
+	}
+
+	withMetadata := len(m.filter.matchMeta) > 0 || len(m.filter.matchTags) > 0
+	for content := range m.filter.clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone, WithMetadata: withMetadata}) {
+		if content.Err != nil {
+			m.failed.Add(1)
+			errorIf(content.Err.Trace(m.targetURL), "Unable to list `%s`.", m.targetURL)
+			continue
+		}
+		if content.Type.IsDir() || content.IsDeleteMarker {
+			continue
+		}
+		fileContent := contentMessage{
+			Key:      getAliasedPath(m.filter, content.URL.String()),
+			Time:     content.Time.Local(),
+			Size:     content.Size,
+			Metadata: content.UserMetadata,
+			Tags:     content.Tags,
+		}
+		if !matchFind(m.filter, fileContent) {
+			continue
+		}
+		objectCh <- content.URL.String()
+	}
+	close(objectCh)
+	wg.Wait()
+
+	printMsg(metaSummaryMessage{
+		Target:    m.targetURL,
+		Updated:   m.updated.Load(),
+		Unchanged: m.unchanged.Load(),
+		Failed:    m.failed.Load(),
+		DryRun:    m.dryRun,
+	})
+	return m.failed.Load() == 0
+}
+
+// checkMetaSyntax - validate the metadata keys, with their values when
+// they are set.
+func checkMetaSyntax(cliCtx *cli.Context, keys []string, withValues bool) {
+	if len(cliCtx.Args()) < 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	for _, key := range keys {
+		k, v, ok := strings.Cut(key, "=")
+		if ok != withValues || k == "" || !httpguts.ValidHeaderFieldName(metaKey(k)) || !httpguts.ValidHeaderFieldValue(v) {
+			fatalIf(errInvalidArgument().Trace(key), "Invalid metadata `%s`.", key)
+		}
+	}
+}
+
+// runMeta - run the edition of the metadata of objects.
+func runMeta(cliCtx *cli.Context, set map[string]string, remove []string) error {
+	console.SetColor("Meta", color.New(color.FgGreen))
+	console.SetColor("MetaUnchanged", color.New(color.FgWhite))
+	console.SetColor("MetaSummary", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelMeta := context.WithCancel(globalContext)
+	defer cancelMeta()
+
+	if !newMetaJob(cliCtx, set, remove).run(ctx) {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
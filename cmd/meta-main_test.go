@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMetaKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"content-type", "Content-Type"},
+		{"Cache-Control", "Cache-Control"},
+		{"x-amz-website-redirect-location", "X-Amz-Website-Redirect-Location"},
+		{"owner", "X-Amz-Meta-Owner"},
+		{" project-id ", "X-Amz-Meta-Project-Id"},
+		{"x-amz-meta-owner", "X-Amz-Meta-Owner"},
+	}
+	for i, testCase := range testCases {
+		if key := metaKey(testCase.key); key != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, key)
+		}
+	}
+}
+
+func TestEditMetadata(t *testing.T) {
+	current := map[string]string{
+		"Content-Type":      "application/octet-stream",
+		"X-Amz-Meta-Owner":  "web",
+		"Etag":              "0ebcd2e1d497aaf869184d42e8dd1707",
+		"Last-Modified":     "Thu, 16 Oct 2026 14:46:02 GMT",
+		"x-amz-server-side": "AES256",
+	}
+	testCases := []struct {
+		set      map[string]string
+		remove   []string
+		expected map[string]string
+		changed  bool
+	}{
+		// Only the editable metadata is kept.
+		{nil, nil, map[string]string{"Content-Type": "application/octet-stream", "X-Amz-Meta-Owner": "web"}, false},
+		{
+			map[string]string{"Content-Type": "text/css", "Cache-Control": "max-age=86400"}, nil,
+			map[string]string{"Content-Type": "text/css", "Cache-Control": "max-age=86400", "X-Amz-Meta-Owner": "web"}, true,
+		},
+		// Already set.
+		{map[string]string{"X-Amz-Meta-Owner": "web"}, nil, map[string]string{"Content-Type": "application/octet-stream", "X-Amz-Meta-Owner": "web"}, false},
+		{nil, []string{"X-Amz-Meta-Owner"}, map[string]string{"Content-Type": "application/octet-stream"}, true},
+		// Already removed.
+		{nil, []string{"X-Amz-Meta-Project"}, map[string]string{"Content-Type": "application/octet-stream", "X-Amz-Meta-Owner": "web"}, false},
+	}
+	for i, testCase := range testCases {
+		metadata, changed := editMetadata(current, testCase.set, testCase.remove)
+		if changed != testCase.changed {
+			t.Fatalf("Test %d: expected changed %t, got %t", i+1, testCase.changed, changed)
+		}
+		if !reflect.DeepEqual(metadata, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, metadata)
+		}
+	}
+}
+
+func TestServerSideEncryption(t *testing.T) {
+	// {"project":"web"}
+	kmsContext := "eyJwcm9qZWN0Ijoid2ViIn0="
+	testCases := []struct {
+		metadata map[string]string
+		expected http.Header
+		invalid  bool
+	}{
+		{map[string]string{}, nil, false},
+		{map[string]string{"X-Amz-Server-Side-Encryption": "AES256"}, http.Header{"X-Amz-Server-Side-Encryption": {"AES256"}}, false},
+		{
+			map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "key"},
+			http.Header{"X-Amz-Server-Side-Encryption": {"aws:kms"}, "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": {"key"}}, false,
+		},
+		{
+			map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "key", "X-Amz-Server-Side-Encryption-Context": kmsContext},
+			http.Header{"X-Amz-Server-Side-Encryption": {"aws:kms"}, "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": {"key"}, "X-Amz-Server-Side-Encryption-Context": {kmsContext}}, false,
+		},
+		{map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Context": "not base64"}, nil, true},
+	}
+	for i, testCase := range testCases {
+		sse, err := serverSideEncryption(testCase.metadata)
+		if testCase.invalid {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		var header http.Header
+		if sse != nil {
+			header = http.Header{}
+			sse.Marshal(header)
+		}
+		if !reflect.DeepEqual(header, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, header)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var metaRemoveCmd = cli.Command{
+	Name:         "rm",
+	Usage:        "remove metadata of objects",
+	Action:       mainMetaRemove,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(metaFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] KEY [KEY...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+UNITS
+  --smaller, --larger flags accept human-readable case-insensitive number
+  suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
+  MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
+  units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
+  also accepted. Without suffixes the unit is bytes.
+
+DESCRIPTION:
+  KEY is one of Cache-Control, Content-Disposition, Content-Encoding, Content-Language,
+  Content-Type, Expires or X-Amz-Website-Redirect-Location, any other KEY is user metadata.
+
+  Objects are copied to themselves on the server without the metadata, they
+  keep their other metadata, tags, retention, legal hold and storage class. On
+  versioned buckets, the copy is a new version. Objects which do not have the
+  metadata are not copied.
+
+EXAMPLES:
+  01. Remove the cache control of all the objects of a website.
+      {{.Prompt}} {{.HelpName}} Cache-Control --recursive play/website/
+
+  02. Remove user metadata from the objects of a dataset older than a year, print the objects to update without updating them.
+      {{.Prompt}} {{.HelpName}} owner reviewed --recursive --older-than 365d --dry-run play/datasets/
+`,
+}
+
+// mainMetaRemove is the handle for "mc meta rm" command.
+func mainMetaRemove(cliCtx *cli.Context) error {
+	args := cliCtx.Args()
+	keys := args
+	if len(args) > 0 {
+		keys = args[:len(args)-1]
+	}
+	checkMetaSyntax(cliCtx, keys, false)
+
+	remove := make([]string, 0, len(keys))
+	for _, key := range keys {
+		remove = append(remove, metaKey(key))
+	}
+	return runMeta(cliCtx, nil, remove)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/cli"
+)
+
+var metaSetCmd = cli.Command{
+	Name:         "set",
+	Usage:        "set metadata of objects",
+	Action:       mainMetaSet,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(metaFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] KEY=VALUE [KEY=VALUE...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+UNITS
+  --smaller, --larger flags accept human-readable case-insensitive number
+  suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
+  MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
+  units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
+  also accepted. Without suffixes the unit is bytes.
+
+DESCRIPTION:
+  KEY is one of Cache-Control, Content-Disposition, Content-Encoding, Content-Language,
+  Content-Type, Expires or X-Amz-Website-Redirect-Location, any other KEY is user metadata.
+
+  Objects are copied to themselves on the server with their new metadata, they
+  keep their other metadata, tags, retention, legal hold and storage class. On
+  versioned buckets, the copy is a new version. Objects which already have the
+  metadata are not copied.
+
+EXAMPLES:
+  01. Fix the content type of the stylesheets of a website.
+      {{.Prompt}} {{.HelpName}} Content-Type=text/css --recursive --name '*.css' play/website/
+
+  02. Cache the images of a website for a day, print the objects to update without updating them.
+      {{.Prompt}} {{.HelpName}} Cache-Control=max-age=86400 --recursive --path 'images/*' --dry-run play/website/
+
+  03. Set user metadata on an object.
+      {{.Prompt}} {{.HelpName}} owner=analytics reviewed=2025-01-01 play/datasets/census-2020.csv
+`,
+}
+
+// mainMetaSet is the handle for "mc meta set" command.
+func mainMetaSet(cliCtx *cli.Context) error {
+	args := cliCtx.Args()
+	keys := args
+	if len(args) > 0 {
+		keys = args[:len(args)-1]
+	}
+	checkMetaSyntax(cliCtx, keys, true)
+
+	set := make(map[string]string, len(keys))
+	for _, key := range keys {
+		k, v, _ := strings.Cut(key, "=")
+		set[metaKey(k)] = v
+	}
+	return runMeta(cliCtx, set, nil)
+}
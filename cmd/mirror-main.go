@@ -133,8 +133,9 @@ var (
 			Usage: "if specified, a new prometheus endpoint will be created to report mirroring activity. (eg: localhost:8081)",
 		},
 		cli.BoolFlag{
-			Name:  "retry",
-			Usage: "if specified, will enable retrying on a per object basis if errors occur",
+			Name:   "retry",
+			Usage:  "if specified, will enable retrying on a per object basis if errors occur",
+			Hidden: true, // Objects failing with a transient error are always retried.
 		},
 		cli.BoolFlag{
 			Name:  "summary",
@@ -148,7 +149,24 @@ var (
 			Name:  "max-workers",
 			Usage: "maximum number of concurrent copies (default: autodetect)",
 		},
+		cli.BoolFlag{
+			Name:  "continue, c",
+			Usage: "create or resume mirror session",
+		},
+		cli.BoolFlag{
+			Name:  "compare-checksum",
+			Usage: "compare objects of the same size by checksum, local files are hashed",
+		},
+		cli.BoolFlag{
+			Name:  "detect-renames",
+			Usage: "copy objects renamed on the source from their former location on the target instead of uploading them",
+		},
 		checksumFlag,
+		filterFromFlag,
+		renameFlag,
+		fanOutFlag,
+		verifyFlag,
+		compressFlag,
 	}
 )
 
@@ -159,7 +177,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, encFlags...), globalFlags...),
+	Flags:        append(append(append(mirrorFlags, batchSmallFlags...), encFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -227,6 +245,37 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Mirror a local folder in a resumable session, run the same command again to continue after an interruption.
+      {{.Prompt}} {{.HelpName}} --continue backup/ s3/archive
+
+  18. Mirror a local folder to MinIO, overwriting objects whose content differs even when the size is the same.
+      {{.Prompt}} {{.HelpName}} --overwrite --compare-checksum backup/ myminio/backup
+
+  19. Mirror a local folder to MinIO after reorganizing it, renamed files are copied on the server side and removed from their former location.
+      {{.Prompt}} {{.HelpName}} --remove --detect-renames backup/ myminio/backup
+
+  20. Mirror a local folder to MinIO with the ordered include/exclude rules of 'rules.txt', excluded objects are not removed on the target.
+      {{.Prompt}} {{.HelpName}} --remove --filter-from rules.txt backup/ myminio/backup
+
+  21. Show how a bucket would be mirrored to a new layout, 'logs/2024/01/x.gz' is mirrored to 'year=2024/month=01/x.gz'.
+      {{.Prompt}} {{.HelpName}} --dry-run --rename 's#^logs/([0-9]+)/([0-9]+)/#year=$1/month=$2/#' play/mybucket s3/mybucket
+
+  22. Mirror a local folder to three sites, files needed by more than one site are read once.
+      {{.Prompt}} {{.HelpName}} --fan-out site2/backup --fan-out site3/backup backup/ site1/backup
+
+  23. Mirror a bucket to a remote site overnight, limited to 20MiB/s during business hours.
+      {{.Prompt}} {{.HelpName}} --limit-upload "08:00-18:00 20MiB/s, otherwise unlimited" myminio/backup remote/backup
+
+  24. Mirror an archive to a remote site, verifying the checksum of every copied object against its source.
+      {{.Prompt}} {{.HelpName}} --verify myminio/archive remote/archive
+
+  25. Mirror a local folder of many small files to MinIO, uploading the files under 1MiB in batches.
+      {{.Prompt}} {{.HelpName}} --batch-small ~/maildir myminio/mail
+
+  26. Mirror a folder of logs compressed with zstd, and mirror them back decompressed.
+      {{.Prompt}} {{.HelpName}} --compress zstd /var/log/app myminio/logs/app
+      {{.Prompt}} {{.HelpName}} myminio/logs/app /restore/app
 `,
 }
 
@@ -281,6 +330,21 @@ type mirrorJob struct {
 	targetURL string
 
 	opts mirrorOptions
+
+	// session journals the progress of a resumable mirror, nil otherwise.
+	session *sessionV8
+
+	// fanOut accounts for every target with --fan-out, nil otherwise.
+	fanOut fanOutStats
+
+	// verify accounts for the verified copies with --verify, nil otherwise.
+	verify *verifyStats
+
+	// planned are the URLs of a plan applied by sync apply, nil otherwise.
+	planned <-chan URLs
+
+	// batcher groups small objects with --batch-small, nil otherwise.
+	batcher *smallBatcher
 }
 
 // mirrorMessage container for file mirror messages
@@ -288,6 +352,7 @@ type mirrorMessage struct {
 	Status     string                 `json:"status"`
 	Source     string                 `json:"source"`
 	Target     string                 `json:"target"`
+	MovedFrom  string                 `json:"movedFrom,omitempty"`
 	Size       int64                  `json:"size"`
 	TotalCount int64                  `json:"totalCount"`
 	TotalSize  int64                  `json:"totalSize"`
@@ -309,6 +374,9 @@ func (m mirrorMessage) String() string {
 	case notification.ILMDelMarkerExpirationDelete:
 		return msg + "Removed (ILM)" + console.Colorize("Removed", fmt.Sprintf("`%s`", m.Target))
 	}
+	if m.MovedFrom != "" {
+		return msg + console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s` (moved from `%s`)", m.Source, m.Target, m.MovedFrom))
+	}
 	if m.EventTime == "" {
 		return console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
 	}
@@ -371,6 +439,21 @@ func (mj *mirrorJob) doDeleteBucket(ctx context.Context, sURLs URLs) URLs {
 	return sURLs.WithError(nil)
 }
 
+// checkRemoveETag - fails when the object to remove does not have the
+// expected ETag, or version, anymore. S3 cannot remove an object on the
+// condition of its ETag, it is checked just before the removal.
+func checkRemoveETag(ctx context.Context, clnt Client, sURLs URLs, sse encrypt.ServerSide) *probe.Error {
+	content, err := clnt.Stat(ctx, StatOptions{sse: sse})
+	if err != nil {
+		return err.Trace(clnt.GetURL().String())
+	}
+	versionID := sURLs.TargetContent.VersionID
+	if trimETag(content.ETag) != sURLs.matchETag || (versionID != "" && content.VersionID != versionID) {
+		return probe.NewError(fmt.Errorf("`%s` changed since it was compared, not removing it", clnt.GetURL().String()))
+	}
+	return nil
+}
+
 // doRemove - removes files on target.
 func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs, event EventInfo) URLs {
 	if mj.opts.isFake {
@@ -388,11 +471,17 @@ func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs, event EventInfo)
 	} else {
 		clnt.AddUserAgent(uaMirrorAppName, ReleaseTag)
 	}
+	if sURLs.matchETag != "" {
+		sse := getSSE(filepath.ToSlash(targetWithAlias), mj.opts.encKeyDB[sURLs.TargetAlias])
+		if err := checkRemoveETag(ctx, clnt, sURLs, sse); err != nil {
+			return sURLs.WithError(err)
+		}
+	}
 	contentCh := make(chan *ClientContent, 1)
 	contentCh <- &ClientContent{URL: *newClientURL(sURLs.TargetContent.URL.Path)}
 	close(contentCh)
 	isRemoveBucket := false
-	resultCh := clnt.Remove(ctx, false, isRemoveBucket, false, false, contentCh)
+	resultCh := removeWithRetry(ctx, sURLs.TargetAlias, clnt, false, isRemoveBucket, false, false, contentCh)
 	for result := range resultCh {
 		if result.Err != nil {
 			switch result.Err.ToGoError().(type) {
@@ -471,11 +560,27 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 	// For a fake mirror make sure we update respective progress bars
 	// and accounting readers under relevant conditions.
 	if mj.opts.isFake {
-		if sURLs.SourceContent != nil {
-			mj.status.Add(sURLs.SourceContent.Size)
+		targets := append([]URLs{sURLs}, sURLs.fanOut...)
+		for i, urls := range targets {
+			if urls.SourceContent == nil {
+				continue
+			}
+			mj.status.Add(urls.SourceContent.Size)
+			// Show the planned copy with its renamed target, or the
+			// copies of an applied plan.
+			if (mj.opts.rename != nil || mj.planned != nil) && !mj.opts.isSummary {
+				mj.status.PrintMsg(mirrorMessage{
+					Source:     filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+					Target:     filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+					Size:       urls.SourceContent.Size,
+					TotalCount: urls.TotalCount,
+					TotalSize:  urls.TotalSize,
+				})
+			}
+			targets[i] = urls.WithError(nil)
 		}
 		mj.status.Update()
-		return sURLs.WithError(nil)
+		return mj.fanOutResults(targets)
 	}
 
 	sourceAlias := sURLs.SourceAlias
@@ -486,6 +591,38 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 
 	mj.status.SetCaption(sourceURL.String() + ":")
 
+	sURLs = mj.prepareTarget(sURLs)
+
+	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
+	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
+	var movedFrom string
+	if sURLs.MoveFrom != nil {
+		movedFrom = filepath.ToSlash(filepath.Join(targetAlias, sURLs.MoveFrom.URL.Path))
+	}
+	if !mj.opts.isSummary {
+		mj.status.PrintMsg(mirrorMessage{
+			Source:     sourcePath,
+			Target:     targetPath,
+			MovedFrom:  movedFrom,
+			Size:       length,
+			TotalCount: sURLs.TotalCount,
+			TotalSize:  sURLs.TotalSize,
+			EventTime:  event.Time,
+			EventType:  event.Type,
+		})
+	}
+	if sURLs.MoveFrom != nil {
+		return mj.doMove(ctx, sURLs, event)
+	}
+	if len(sURLs.fanOut) > 0 {
+		return mj.doMirrorFanOut(ctx, sURLs, event)
+	}
+	return mj.upload(ctx, sURLs)
+}
+
+// prepareTarget - set the metadata and upload options of the target of
+// an object.
+func (mj *mirrorJob) prepareTarget(sURLs URLs) URLs {
 	// Initialize target metadata.
 	sURLs.TargetContent.Metadata = make(map[string]string)
 
@@ -507,47 +644,81 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 	// Initialize additional target user metadata.
 	sURLs.TargetContent.UserMetadata = mj.opts.userMetadata
 
-	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
-	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
-	if !mj.opts.isSummary {
-		mj.status.PrintMsg(mirrorMessage{
-			Source:     sourcePath,
-			Target:     targetPath,
-			Size:       length,
-			TotalCount: sURLs.TotalCount,
-			TotalSize:  sURLs.TotalSize,
-			EventTime:  event.Time,
-			EventType:  event.Type,
-		})
-	}
 	sURLs.MD5 = mj.opts.md5
 	sURLs.checksum = mj.opts.checksum
+	sURLs.compress = mj.opts.compress
 	sURLs.DisableMultipart = mj.opts.disableMultipart
+	return sURLs
+}
 
-	var ret URLs
-
-	if !mj.opts.isRetriable {
-		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
-		if ret.Error == nil {
-			durationMs := time.Since(now).Milliseconds()
-			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
+// queueBatch - mirror a batch of small objects in a single upload, the
+// objects of a failed batch are mirrored one by one.
+func (mj *mirrorJob) queueBatch(ctx context.Context, batch *smallBatch) {
+	mj.parallel.queueTask(func() URLs {
+		if !mj.opts.isSummary {
+			for _, sURLs := range batch.urls {
+				mj.status.PrintMsg(mirrorMessage{
+					Source:     filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path)),
+					Target:     filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)),
+					Size:       sURLs.SourceContent.Size,
+					TotalCount: sURLs.TotalCount,
+					TotalSize:  sURLs.TotalSize,
+				})
+			}
 		}
+		results := batch.upload(ctx, uploadSourceToTargetURLOpts{progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, verify: mj.opts.verify}, func(sURLs URLs) URLs {
+			return mj.upload(ctx, sURLs)
+		})
+		return mj.fanOutResults(results)
+	}, batch.size)
+}
 
-		return ret
-	}
-
-	newRetryManager(ctx, time.Second, 3).retry(func(rm *retryManager) *probe.Error {
-		if rm.retries > 0 {
-			printMsg(retryMessage{
-				SourceURL: sURLs.SourceContent.URL.String(),
-				TargetURL: sURLs.TargetContent.URL.String(),
-				Retries:   rm.retries,
+// doMirrorFanOut - mirror an object to all targets needing it, reading
+// the source once.
+func (mj *mirrorJob) doMirrorFanOut(ctx context.Context, sURLs URLs, event EventInfo) URLs {
+	targets := append([]URLs{sURLs}, sURLs.fanOut...)
+	targets[0].fanOut = nil
+	for i := range targets[1:] {
+		urls := &targets[i+1]
+		urls.TargetContent.Metadata = sURLs.TargetContent.Metadata
+		urls.TargetContent.UserMetadata = sURLs.TargetContent.UserMetadata
+		urls.TargetContent.StorageClass = sURLs.TargetContent.StorageClass
+		urls.MD5 = sURLs.MD5
+		urls.checksum = sURLs.checksum
+		urls.compress = sURLs.compress
+		urls.DisableMultipart = sURLs.DisableMultipart
+		if !mj.opts.isSummary {
+			mj.status.PrintMsg(mirrorMessage{
+				Source:     filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+				Target:     filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+				Size:       urls.SourceContent.Size,
+				TotalCount: urls.TotalCount,
+				TotalSize:  urls.TotalSize,
+				EventTime:  event.Time,
+				EventType:  event.Type,
 			})
 		}
+	}
 
+	results := uploadSourceToTargetURLs(ctx, uploadSourceToTargetURLOpts{progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, verify: mj.opts.verify}, targets)
+	return mj.fanOutResults(results)
+}
+
+// fanOutResults - report the results of all targets but the first,
+// which is returned to the caller.
+func (mj *mirrorJob) fanOutResults(results []URLs) URLs {
+	for _, ret := range results[1:] {
+		mj.statusCh <- ret
+	}
+	return results[0]
+}
+
+// upload - copy an object to its target, with the retry policy of the target.
+func (mj *mirrorJob) upload(ctx context.Context, sURLs URLs) URLs {
+	var ret URLs
+	urlsRetryPolicy(sURLs).do(ctx, nil, printRetry(sURLs), func() *probe.Error {
 		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
+		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false, verify: mj.opts.verify})
 		if ret.Error == nil {
 			durationMs := time.Since(now).Milliseconds()
 			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
@@ -559,6 +730,37 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 	return ret
 }
 
+// doMove - copy an object renamed on the source from its former location
+// on the target with a server side copy, the former location is removed
+// afterwards when --remove is set.
+func (mj *mirrorJob) doMove(ctx context.Context, sURLs URLs, event EventInfo) URLs {
+	copyURLs := sURLs
+	copyURLs.SourceAlias = sURLs.TargetAlias
+	copyURLs.SourceContent = sURLs.MoveFrom
+	// The content is already verified, do not stream it to compute a checksum.
+	copyURLs.checksum = minio.ChecksumNone
+	// Neither copied nor removed if it changed since it was compared.
+	copyURLs.matchETag = trimETag(sURLs.MoveFrom.ETag)
+
+	ret := uploadWithRetry(ctx, uploadSourceToTargetURLOpts{urls: copyURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, verify: mj.opts.verify})
+	sURLs.verify = ret.verify
+	if ret.Error != nil || !mj.opts.isRemove {
+		return sURLs.WithError(ret.Error)
+	}
+
+	removeURLs := URLs{
+		TargetAlias:   sURLs.TargetAlias,
+		TargetContent: sURLs.MoveFrom,
+		TotalCount:    sURLs.TotalCount,
+		TotalSize:     sURLs.TotalSize,
+		matchETag:     copyURLs.matchETag,
+	}
+	if ret = mj.doRemove(ctx, removeURLs, event); ret.Error != nil {
+		return removeURLs.WithError(ret.Error)
+	}
+	return sURLs.WithError(nil)
+}
+
 // Update progress status
 func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMirror bool) {
 	// now we want to start the progress bar
@@ -585,6 +787,13 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 		// Update prometheus fields
 		mirrorTotalOps.Inc()
 
+		if mj.fanOut != nil {
+			mj.fanOut.add(sURLs)
+		}
+		if mj.verify != nil {
+			mj.verify.add(sURLs)
+		}
+
 		if sURLs.Error != nil {
 			var ignoreErr bool
 
@@ -635,6 +844,10 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 		if sURLs.SourceContent != nil {
 			mirrorTotalUploadedBytes.Add(float64(sURLs.SourceContent.Size))
 		}
+
+		if mj.session != nil {
+			errorIf(mj.session.SetCompleted(sURLs).Trace(mj.session.SessionID), "Unable to save session.")
+		}
 	}
 
 	return
@@ -716,6 +929,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 				TargetContent:    &ClientContent{URL: *targetURL},
 				MD5:              mj.opts.md5,
 				checksum:         mj.opts.checksum,
+				compress:         mj.opts.compress,
 				DisableMultipart: mj.opts.disableMultipart,
 				encKeyDB:         mj.opts.encKeyDB,
 			}
@@ -746,6 +960,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 				TargetContent:    &ClientContent{URL: *targetURL},
 				MD5:              mj.opts.md5,
 				checksum:         mj.opts.checksum,
+				compress:         mj.opts.compress,
 				DisableMultipart: mj.opts.disableMultipart,
 				encKeyDB:         mj.opts.encKeyDB,
 			}
@@ -817,19 +1032,52 @@ func (mj *mirrorJob) watchURL(ctx context.Context, sourceClient Client) *probe.E
 
 // Fetch urls that need to be mirrored
 func (mj *mirrorJob) startMirror(ctx context.Context) {
-	URLsCh := prepareMirrorURLs(ctx, mj.sourceURL, mj.targetURL, mj.opts)
+	var URLsCh <-chan URLs
+	if mj.session != nil && mj.session.Header.Planned {
+		// The differences were fully computed before,
+		// replay them from the session journal.
+		URLsCh = mj.session.PlannedURLs(ctx)
+	} else if mj.planned != nil {
+		URLsCh = mj.planned
+	} else {
+		URLsCh = prepareMirrorURLs(ctx, mj.sourceURL, mj.targetURL, mj.opts)
+	}
+
+	var totalObjects, totalBytes int64
+	listingErr := false
 
 	for {
 		select {
 		case sURLs, ok := <-URLsCh:
 			if !ok {
+				if mj.session != nil && !listingErr && ctx.Err() == nil {
+					errorIf(mj.session.SetPlanned(totalObjects, totalBytes).Trace(mj.session.SessionID), "Unable to save session.")
+				}
+				if mj.batcher != nil {
+					for _, batch := range mj.batcher.flush() {
+						mj.queueBatch(ctx, batch)
+					}
+				}
 				return
 			}
 			if sURLs.Error != nil {
+				listingErr = true
 				mj.statusCh <- sURLs
 				continue
 			}
 
+			if mj.session != nil {
+				if err := mj.session.AddPlanned(sURLs); err != nil {
+					listingErr = true
+					mj.statusCh <- sURLs.WithError(err)
+					continue
+				}
+				totalObjects++
+				if sURLs.SourceContent != nil {
+					totalBytes += sURLs.SourceContent.Size
+				}
+			}
+
 			if sURLs.SourceContent != nil {
 				if isOlder(sURLs.SourceContent.Time, mj.opts.olderThan) {
 					continue
@@ -840,16 +1088,35 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 			}
 
 			if sURLs.SourceContent != nil {
-				mj.status.Add(sURLs.SourceContent.Size)
+				mj.status.Add(sURLs.SourceContent.Size * int64(1+len(sURLs.fanOut)))
 			}
 
 			mj.status.SetTotal(mj.status.Get()).Update()
-			mj.status.AddCounts(1)
+			mj.status.AddCounts(int64(1 + len(sURLs.fanOut)))
 
 			// Save total count.
 			sURLs.TotalCount = mj.status.GetCounts()
 			// Save totalSize.
 			sURLs.TotalSize = mj.status.Get()
+			for i := range sURLs.fanOut {
+				sURLs.fanOut[i].TotalCount = sURLs.TotalCount
+				sURLs.fanOut[i].TotalSize = sURLs.TotalSize
+			}
+
+			if mj.session != nil && mj.session.IsCompleted(sURLs) {
+				// Already done in a previous run, only account for it.
+				continue
+			}
+
+			if sURLs.SourceContent != nil && mj.batcher != nil {
+				if batch, ok := mj.batcher.add(ctx, mj.prepareTarget(sURLs)); ok {
+					// Mirrored with the other small objects of its bucket.
+					if batch != nil {
+						mj.queueBatch(ctx, batch)
+					}
+					continue
+				}
+			}
 
 			if sURLs.SourceContent != nil {
 				mj.parallel.queueTask(func() URLs {
@@ -902,6 +1169,13 @@ func (mj *mirrorJob) mirror(ctx context.Context) bool {
 
 	ret := mj.monitorMirrorStatus(cancel)
 	<-doneCh
+	if mj.fanOut != nil {
+		mj.fanOut.print()
+	}
+	if mj.verify != nil {
+		mj.verify.print()
+	}
+	mj.opts.uncompared.print()
 	return ret
 }
 
@@ -917,6 +1191,15 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 	}
 
 	mj.parallel = newParallelManager(mj.statusCh, opts.maxWorkers)
+	if len(opts.fanOut) > 0 {
+		mj.fanOut = newFanOutStats(append([]string{dstURL}, opts.fanOut...))
+	}
+	if opts.verify {
+		mj.verify = &verifyStats{}
+	}
+	if opts.compareChecksum {
+		mj.opts.uncompared = &compareStats{}
+	}
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
@@ -983,7 +1266,7 @@ func getEventPathURLWin(srcURL, eventPath string) string {
 }
 
 // runMirror - mirrors all buckets to another S3 server
-func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
+func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair, session *sessionV8) bool {
 	// Parse metadata.
 	userMetadata := make(map[string]string)
 	if cli.String("attr") != "" {
@@ -1020,9 +1303,9 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		isWatch:               isWatch,
 		isMetadata:            isMetadata,
 		isSummary:             cli.Bool("summary"),
-		isRetriable:           cli.Bool("retry"),
 		md5:                   md5,
 		checksum:              checksum,
+		compress:              parseCompress(cli),
 		disableMultipart:      cli.Bool("disable-multipart"),
 		skipErrors:            cli.Bool("skip-errors"),
 		excludeOptions:        cli.StringSlice("exclude"),
@@ -1035,17 +1318,29 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		encKeyDB:              encKeyDB,
 		activeActive:          isActiveActive,
 		maxWorkers:            cli.Int("max-workers"),
+		compareChecksum:       cli.Bool("compare-checksum"),
+		detectRenames:         cli.Bool("detect-renames"),
+		filter:                parseFilterFromFlag(cli),
+		rename:                parseRenameFlag(cli),
+		fanOut:                cli.StringSlice("fan-out"),
+		verify:                cli.Bool("verify"),
 	}
 
 	// If we are not using active/active and we are not removing
 	// files from the remote, then we can exit the listing once
 	// local files have been checked for diff.
-	if !mopts.activeActive && !mopts.isRemove {
+	if !mopts.activeActive && !mopts.isRemove && !mopts.detectRenames {
 		mopts.sourceListingOnly = true
 	}
 
 	// Create a new mirror job and execute it
 	mj := newMirrorJob(srcURL, dstURL, mopts)
+	mj.session = session
+	if !isFake {
+		var err *probe.Error
+		mj.batcher, err = newSmallBatcher(cli, encKeyDB)
+		fatalIf(err, "Unable to parse --batch-small-size.")
+	}
 
 	preserve := cli.Bool("preserve")
 
@@ -1164,6 +1459,7 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 func mainMirror(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SessionSaved", color.New(color.FgYellow, color.Bold))
 
 	ctx, cancelMirror := context.WithCancel(globalContext)
 	defer cancelMirror()
@@ -1183,18 +1479,35 @@ func mainMirror(cliCtx *cli.Context) error {
 		}()
 	}
 
+	var session *sessionV8
+	if cliCtx.Bool("continue") {
+		session, err = newSessionV8("mirror", cliCtx)
+		fatalIf(err.Trace(cliCtx.Args()...), "Unable to create session.")
+	}
+
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for {
 		select {
 		case <-ctx.Done():
+			if session != nil {
+				session.CloseAndPrint()
+			}
 			return exitStatus(globalErrorExitStatus)
 		default:
-			errorDetected := runMirror(ctx, srcURL, tgtURL, cliCtx, encKeyDB)
+			errorDetected := runMirror(ctx, srcURL, tgtURL, cliCtx, encKeyDB, session)
 			if cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active") {
 				mirrorRestarts.Inc()
 				time.Sleep(time.Duration(r.Float64() * float64(2*time.Second)))
 				continue
 			}
+			if session != nil {
+				// Keep the session on disk if anything is left to mirror.
+				if errorDetected || ctx.Err() != nil {
+					session.CloseAndPrint()
+				} else {
+					errorIf(session.Delete().Trace(session.SessionID), "Unable to clear session.")
+				}
+			}
 			if errorDetected {
 				return exitStatus(globalErrorExitStatus)
 			}
@@ -0,0 +1,242 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// renameMaxObjects - the number of objects only on the source or only on
+// the target held back by mirror to detect renames, renames are not
+// detected beyond it.
+const renameMaxObjects = 100000
+
+// localSums - the sums of the local files compared to detect renames, by
+// path and type of sum. Each file is hashed at most once for a type.
+type localSums map[string]localSum
+
+type localSum struct {
+	sum string
+	err error
+}
+
+// get - the sum of a local file, computed by hash when it is not known.
+func (s localSums) get(path, name string, hash func() (string, error)) (string, error) {
+	key := path + "\x00" + name
+	if v, ok := s[key]; ok {
+		return v.sum, v.err
+	}
+	sum, e := hash()
+	s[key] = localSum{sum, e}
+	return sum, e
+}
+
+// sameContent - verify positively that a source object and a target
+// object have the same content. Unlike checksumDiffer, contents which
+// cannot be compared are considered different. Local sources are hashed
+// once, their sums are kept in sums.
+func sameContent(src, tgt *ClientContent, sums localSums) bool {
+	if src.Size != tgt.Size || !src.Type.IsRegular() || !tgt.Type.IsRegular() {
+		return false
+	}
+
+	if src.URL.Type == fileSystem {
+		path := src.URL.Path
+		for _, key := range verifyChecksumOrder {
+			if value, ok := fullObjectChecksum(tgt, key); ok {
+				sum, e := sums.get(path, key, func() (string, error) {
+					sum, e := hashFile(path, checksumTypes[key].Hasher())
+					return base64.StdEncoding.EncodeToString(sum), e
+				})
+				return e == nil && sum == value
+			}
+		}
+		equal, known := localETagMatch(src.Size, tgt.ETag, func(partSize int64) (string, error) {
+			return sums.get(path, strconv.FormatInt(partSize, 10), func() (string, error) {
+				return localETag(path, partSize)
+			})
+		})
+		return known && equal
+	}
+
+	for _, key := range verifyChecksumOrder {
+		srcValue, ok := fullObjectChecksum(src, key)
+		if !ok {
+			continue
+		}
+		if tgtValue, ok := fullObjectChecksum(tgt, key); ok {
+			return srcValue == tgtValue
+		}
+	}
+	srcETag, tgtETag := trimETag(src.ETag), trimETag(tgt.ETag)
+	return srcETag != "" && srcETag == tgtETag
+}
+
+// renameKey - a sum of the content of objects of a size, a checksum by
+// its type or an ETag.
+type renameKey struct {
+	size      int64
+	sum, kind string
+}
+
+// renameIndex - the objects only present on the target by the sums of
+// their content, with the kinds of sums known for each size.
+type renameIndex struct {
+	objects map[renameKey][]*ClientContent
+	// checksums are the types of checksums and parts the numbers of
+	// parts of the ETags of the objects of a size.
+	checksums map[int64]map[string]bool
+	parts     map[int64]map[int]bool
+}
+
+func newRenameIndex(vanished []*ClientContent) *renameIndex {
+	index := &renameIndex{
+		objects:   make(map[renameKey][]*ClientContent),
+		checksums: make(map[int64]map[string]bool),
+		parts:     make(map[int64]map[int]bool),
+	}
+	for _, content := range vanished {
+		// Empty objects are cheaper to upload than to copy.
+		if content.Size <= 0 || !content.Type.IsRegular() {
+			continue
+		}
+		for _, key := range verifyChecksumOrder {
+			if value, ok := fullObjectChecksum(content, key); ok {
+				index.add(renameKey{content.Size, value, key}, content)
+				if index.checksums[content.Size] == nil {
+					index.checksums[content.Size] = make(map[string]bool)
+				}
+				index.checksums[content.Size][key] = true
+			}
+		}
+		if etag := trimETag(content.ETag); etag != "" {
+			index.add(renameKey{content.Size, etag, "ETag"}, content)
+			if index.parts[content.Size] == nil {
+				index.parts[content.Size] = make(map[int]bool)
+			}
+			index.parts[content.Size][etagPartsCount(etag)] = true
+		}
+	}
+	return index
+}
+
+func (index *renameIndex) add(key renameKey, content *ClientContent) {
+	index.objects[key] = append(index.objects[key], content)
+}
+
+// keys - the sums of a created object to look up, in the order sameContent
+// compares them. Local files are only hashed for the sums known for their
+// size, once for every type of sum.
+func (index *renameIndex) keys(src *ClientContent, sums localSums) []renameKey {
+	var keys []renameKey
+	if src.URL.Type != fileSystem {
+		for _, key := range verifyChecksumOrder {
+			if value, ok := fullObjectChecksum(src, key); ok {
+				keys = append(keys, renameKey{src.Size, value, key})
+			}
+		}
+		if etag := trimETag(src.ETag); etag != "" {
+			keys = append(keys, renameKey{src.Size, etag, "ETag"})
+		}
+		return keys
+	}
+
+	path := src.URL.Path
+	for _, key := range verifyChecksumOrder {
+		if !index.checksums[src.Size][key] {
+			continue
+		}
+		sum, e := sums.get(path, key, func() (string, error) {
+			sum, e := hashFile(path, checksumTypes[key].Hasher())
+			return base64.StdEncoding.EncodeToString(sum), e
+		})
+		if e == nil {
+			keys = append(keys, renameKey{src.Size, sum, key})
+		}
+	}
+	for _, parts := range slices.Sorted(maps.Keys(index.parts[src.Size])) {
+		partSizes := []int64{0}
+		if parts != 0 {
+			partSizes = etagPartSizes(src.Size, parts)
+		}
+		for _, partSize := range partSizes {
+			etag, e := sums.get(path, strconv.FormatInt(partSize, 10), func() (string, error) {
+				return localETag(path, partSize)
+			})
+			if e == nil {
+				keys = append(keys, renameKey{src.Size, etag, "ETag"})
+			}
+		}
+	}
+	return keys
+}
+
+// take - the first object with the content of src which is not matched
+// yet, nil when there is none.
+func (index *renameIndex) take(src *ClientContent, sums localSums, matched map[*ClientContent]bool) *ClientContent {
+	for _, key := range index.keys(src, sums) {
+		// Objects matched by previous lookups are dropped.
+		candidates := index.objects[key]
+		for len(candidates) > 0 && matched[candidates[0]] {
+			candidates = candidates[1:]
+		}
+		index.objects[key] = candidates
+		for _, candidate := range candidates {
+			// Sums of another kind may tell the contents apart.
+			if !matched[candidate] && sameContent(src, candidate, sums) {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// matchRenames - pair objects only present on the source with objects only
+// present on the target that have the same content. Every vanished object
+// is used at most once, the matched pairs are returned as moves while the
+// unmatched objects are returned for a regular copy or removal. Vanished
+// objects are indexed by the sums of their content, each created object
+// is looked up by its own sums.
+func matchRenames(created []URLs, vanished []*ClientContent) (moves, copies []URLs, removals []*ClientContent) {
+	index := newRenameIndex(vanished)
+	matched := make(map[*ClientContent]bool)
+	sums := make(localSums)
+	for _, urls := range created {
+		var found *ClientContent
+		if urls.SourceContent.Size > 0 {
+			found = index.take(urls.SourceContent, sums, matched)
+		}
+		if found == nil {
+			copies = append(copies, urls)
+			continue
+		}
+		matched[found] = true
+		urls.MoveFrom = found
+		moves = append(moves, urls)
+	}
+
+	for _, content := range vanished {
+		if !matched[content] {
+			removals = append(removals, content)
+		}
+	}
+	return moves, copies, removals
+}
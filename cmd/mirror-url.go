@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/wildcard"
 )
@@ -66,6 +67,40 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		}
 	}
 
+	if cliCtx.Bool("detect-renames") {
+		if destClient.Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--detect-renames` requires an object storage target.")
+		}
+		if cliCtx.Bool("active-active") || cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--detect-renames` cannot be used with `--active-active`.")
+		}
+	}
+
+	if cliCtx.String("rename") != "" && (cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--rename` cannot be used with `--watch` or `--active-active`.")
+	}
+
+	if fanOut := cliCtx.StringSlice("fan-out"); len(fanOut) > 0 {
+		if cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master") || cliCtx.Bool("continue") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--fan-out` cannot be used with `--watch`, `--active-active` or `--continue`.")
+		}
+		// Renamed objects are sent after the listing, out of the order
+		// the differences of the targets are merged in.
+		if cliCtx.Bool("detect-renames") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--fan-out` cannot be used with `--detect-renames`.")
+		}
+		for _, url := range append([]string{srcURL, tgtURL}, fanOut...) {
+			_, expanded, _ := mustExpandAlias(url)
+			if clientURL := newClientURL(expanded); clientURL.Type == objectStorage && strings.Trim(clientURL.Path, "/") == "" {
+				fatalIf(errInvalidArgument().Trace(url), "`--fan-out` cannot mirror all buckets of `%s`, please add a bucket name.", url)
+			}
+		}
+	}
+
+	if cliCtx.Bool("continue") && (cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--continue` cannot be used with `--watch` or `--active-active`.")
+	}
+
 	/****** Generic rules *******/
 	if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
 		_, srcContent, err := url2Stat(ctx, url2StatOptions{urlStr: srcURL, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false})
@@ -157,6 +192,10 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 		return
 	}
 
+	// Objects encrypted on the client are compared by the size of their content.
+	sourceClnt = withClientEncryption(sourceClnt, sourceAlias, opts.encKeyDB[sourceAlias])
+	targetClnt = withClientEncryption(targetClnt, targetAlias, opts.encKeyDB[targetAlias])
+
 	// If the passed source URL points to fs, fetch the absolute src path
 	// to correctly calculate targetPath
 	if sourceAlias == "" {
@@ -166,6 +205,36 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 		}
 	}
 
+	// Objects only in source and only in target, kept to detect renames.
+	// Up to renameMaxObjects are kept in memory, renames are not detected
+	// beyond it.
+	detectRenames := opts.detectRenames
+	var created []URLs
+	var vanished []*ClientContent
+	sendRemovals := func(removals []*ClientContent) {
+		if !opts.isRemove && !opts.isFake {
+			return
+		}
+		for _, content := range removals {
+			URLsCh <- URLs{
+				TargetAlias:   targetAlias,
+				TargetContent: content,
+				diff:          differInSecond,
+			}
+		}
+	}
+	stopDetectRenames := func() {
+		if !detectRenames || len(created)+len(vanished) < renameMaxObjects {
+			return
+		}
+		detectRenames = false
+		for _, urls := range created {
+			URLsCh <- urls
+		}
+		sendRemovals(vanished)
+		created, vanished = nil, nil
+	}
+
 	// List both source and target, compare and return values through channel.
 	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts) {
 		if diffMsg.Error != nil {
@@ -196,6 +265,15 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			continue
 		}
 
+		// Objects excluded by the rules are neither copied nor removed.
+		if diffMsg.firstContent != nil {
+			if !opts.filter.Match(srcSuffix, diffMsg.firstContent) {
+				continue
+			}
+		} else if !opts.filter.Match(tgtSuffix, diffMsg.secondContent) {
+			continue
+		}
+
 		if diffMsg.firstContent != nil {
 			var found bool
 			for _, esc := range opts.excludeStorageClasses {
@@ -214,7 +292,11 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			// No difference, continue.
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInMetadata, differInAASourceMTime:
+		case differInSize, differInMetadata, differInAASourceMTime, differInChecksum:
+			if diffMsg.Diff == differInSize && sameContentSize(ctx, sourceAlias, diffMsg.firstContent, targetAlias, diffMsg.secondContent, opts.encKeyDB) {
+				// Compressed objects listed without their metadata.
+				continue
+			}
 			if !opts.isOverwrite && !opts.isFake && !opts.activeActive {
 				// Size or time or etag differs but --overwrite not set.
 				URLsCh <- URLs{
@@ -225,8 +307,13 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			}
 
 			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
+			targetSuffix, e := opts.rename.Apply(sourceSuffix)
+			if e != nil {
+				URLsCh <- URLs{Error: probe.NewError(e).Trace(diffMsg.FirstURL)}
+				continue
+			}
 			// Either available only in source or size differs and force is set
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetPath := urlJoinPath(targetURL, targetSuffix)
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -234,26 +321,46 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 				SourceContent: sourceContent,
 				TargetAlias:   targetAlias,
 				TargetContent: targetContent,
+				diff:          diffMsg.Diff,
 			}
 		case differInFirst:
 			// Only in first, always copy.
 			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetSuffix, e := opts.rename.Apply(sourceSuffix)
+			if e != nil {
+				URLsCh <- URLs{Error: probe.NewError(e).Trace(diffMsg.FirstURL)}
+				continue
+			}
+			targetPath := urlJoinPath(targetURL, targetSuffix)
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
-			URLsCh <- URLs{
+			urls := URLs{
 				SourceAlias:   sourceAlias,
 				SourceContent: sourceContent,
 				TargetAlias:   targetAlias,
 				TargetContent: targetContent,
+				diff:          diffMsg.Diff,
 			}
+			if detectRenames {
+				// Held back until all vanished objects are known.
+				created = append(created, urls)
+				stopDetectRenames()
+				continue
+			}
+			URLsCh <- urls
 		case differInSecond:
+			if detectRenames {
+				vanished = append(vanished, diffMsg.secondContent)
+				stopDetectRenames()
+				continue
+			}
 			if !opts.isRemove && !opts.isFake {
 				continue
 			}
 			URLsCh <- URLs{
 				TargetAlias:   targetAlias,
 				TargetContent: diffMsg.secondContent,
+				diff:          diffMsg.Diff,
 			}
 		default:
 			URLsCh <- URLs{
@@ -262,12 +369,24 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			}
 		}
 	}
+
+	if !detectRenames {
+		return
+	}
+
+	moves, copies, removals := matchRenames(created, vanished)
+	for _, urls := range moves {
+		URLsCh <- urls
+	}
+	for _, urls := range copies {
+		URLsCh <- urls
+	}
+	sendRemovals(removals)
 }
 
 type mirrorOptions struct {
 	isFake, isOverwrite, activeActive                     bool
 	isWatch, isRemove, isMetadata                         bool
-	isRetriable                                           bool
 	isSummary                                             bool
 	skipErrors                                            bool
 	excludeOptions, excludeStorageClasses, excludeBuckets []string
@@ -277,13 +396,103 @@ type mirrorOptions struct {
 	storageClass                                          string
 	userMetadata                                          map[string]string
 	checksum                                              minio.ChecksumType
+	compress                                              string
 	sourceListingOnly                                     bool
+	compareChecksum                                       bool
+	uncompared                                            *compareStats
+	detectRenames                                         bool
+	filter                                                filterRules
+	rename                                                *keyRename
+	fanOut                                                []string
 	maxWorkers                                            int
+	verify                                                bool
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
 func prepareMirrorURLs(ctx context.Context, sourceURL, targetURL string, opts mirrorOptions) <-chan URLs {
 	URLsCh := make(chan URLs)
+	if len(opts.fanOut) > 0 {
+		go deltaSourceTargets(ctx, sourceURL, append([]string{targetURL}, opts.fanOut...), opts, URLsCh)
+		return URLsCh
+	}
 	go deltaSourceTarget(ctx, sourceURL, targetURL, opts, URLsCh)
 	return URLsCh
 }
+
+// deltaSourceTargets - the differences of a source with several targets,
+// computed concurrently and merged by object name. An object to copy to
+// more than one target is sent once, its copies to the other targets are
+// in the fanOut of the URLs.
+func deltaSourceTargets(ctx context.Context, sourceURL string, targetURLs []string, opts mirrorOptions, URLsCh chan<- URLs) {
+	defer close(URLsCh)
+
+	roots := make([]ClientURL, len(targetURLs))
+	deltaChs := make([]chan URLs, len(targetURLs))
+	for i, targetURL := range targetURLs {
+		_, expanded, _ := mustExpandAlias(targetURL)
+		roots[i] = *newClientURL(expanded)
+		deltaChs[i] = make(chan URLs)
+		go deltaSourceTarget(ctx, sourceURL, targetURL, opts, deltaChs[i])
+	}
+
+	// The next pending difference of every target, nil once done.
+	heads := make([]*URLs, len(targetURLs))
+	keys := make([]string, len(targetURLs))
+	next := func(i int) {
+		urls, ok := <-deltaChs[i]
+		if !ok {
+			heads[i] = nil
+			return
+		}
+		urls.targetIndex = i
+		heads[i] = &urls
+		if urls.TargetContent != nil {
+			keys[i], _ = fanOutSuffix(roots[i], urls.TargetContent.URL)
+		}
+	}
+	for i := range deltaChs {
+		next(i)
+	}
+
+	// Plain copies can share the stream of their source.
+	isCopy := func(urls *URLs) bool {
+		return urls.Error == nil && urls.SourceContent != nil && urls.MoveFrom == nil
+	}
+
+	for {
+		first := -1
+		for i, head := range heads {
+			if head == nil {
+				continue
+			}
+			if head.Error != nil {
+				first = i
+				break
+			}
+			if first < 0 || keys[i] < keys[first] {
+				first = i
+			}
+		}
+		if first < 0 {
+			return
+		}
+
+		urls := *heads[first]
+		if isCopy(&urls) {
+			for i := first + 1; i < len(heads); i++ {
+				if heads[i] != nil && isCopy(heads[i]) && keys[i] == keys[first] &&
+					heads[i].SourceContent.URL.String() == urls.SourceContent.URL.String() {
+					urls.fanOut = append(urls.fanOut, *heads[i])
+					next(i)
+				}
+			}
+		}
+		next(first)
+
+		select {
+		case URLsCh <- urls:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -180,7 +180,7 @@ func (rm *removeManager) add(ctx context.Context, targetAlias, targetURL string)
 		}
 
 		contentCh := make(chan *ClientContent, 10000)
-		resultCh := client.Remove(ctx, false, false, false, false, contentCh)
+		resultCh := removeWithRetry(ctx, targetAlias, client, false, false, false, false, contentCh)
 		rm.readErrors(resultCh, targetURL)
 
 		clientInfo = &removeClientInfo{
@@ -232,7 +232,7 @@ func mainMove(cliCtx *cli.Context) error {
 	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
-	e := doCopySession(ctx, cancelMove, cliCtx, encKeyDB, true)
+	e := doCopySession(ctx, cancelMove, cliCtx, encKeyDB, true, nil)
 
 	console.Colorize("Copy", "Waiting for move operations to complete")
 	rmManager.close()
@@ -0,0 +1,224 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Error rate of the requests above which workers are removed.
+	maxRequestErrorRate = 0.1
+
+	// Latency of the requests, relative to the lowest observed, above
+	// which workers are removed when the throughput does not improve.
+	maxLatencyFactor = 2
+
+	// Latency increase ignored whatever the factor, it avoids reacting
+	// to the noise of the very fast requests of a local server.
+	minLatencyIncrease = 50 * time.Millisecond
+
+	// Throughput increase over the best observed needed to keep adding
+	// workers.
+	minThroughputGain = 1.05
+
+	// Number of periods without throughput increase before the workers
+	// stop growing.
+	maxSteadyPeriods = 3
+)
+
+// requestStats - counters of the HTTP requests sent to the servers.
+type requestStats struct {
+	requests  atomic.Int64
+	throttled atomic.Int64
+	errors    atomic.Int64
+	latency   atomic.Int64
+}
+
+// globalRequestStats - requests of all aliases, sampled by the
+// concurrency controller of the parallel manager.
+var globalRequestStats requestStats
+
+// requestSample - requests sent during a period.
+type requestSample struct {
+	requests  int64
+	throttled int64
+	errors    int64
+	latency   time.Duration
+}
+
+// sample - the requests sent since the previous sample.
+func (s *requestStats) sample(prev *requestSample) requestSample {
+	total := requestSample{
+		requests:  s.requests.Load(),
+		throttled: s.throttled.Load(),
+		errors:    s.errors.Load(),
+		latency:   time.Duration(s.latency.Load()),
+	}
+	delta := requestSample{
+		requests:  total.requests - prev.requests,
+		throttled: total.throttled - prev.throttled,
+		errors:    total.errors - prev.errors,
+	}
+	if delta.requests > 0 {
+		delta.latency = (total.latency - prev.latency) / time.Duration(delta.requests)
+	}
+	*prev = total
+	return delta
+}
+
+// statsTransport - accounts every request in globalRequestStats, the
+// latency of a request is the time from the end of the request, body
+// included, to the first byte of its response. The upload of the body,
+// slowed down by --limit-upload, is not part of it.
+type statsTransport struct {
+	http.RoundTripper
+}
+
+func (t statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var wrote, firstByte atomic.Int64
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			wrote.Store(time.Now().UnixNano())
+		},
+		GotFirstResponseByte: func() {
+			firstByte.Store(time.Now().UnixNano())
+		},
+	}))
+	start := time.Now().UnixNano()
+	res, err := t.RoundTripper.RoundTrip(req)
+	if errors.Is(err, context.Canceled) {
+		return res, err
+	}
+
+	end := firstByte.Load()
+	if end == 0 {
+		end = time.Now().UnixNano()
+	}
+	if w := wrote.Load(); w != 0 && w <= end {
+		start = w
+	}
+	globalRequestStats.requests.Add(1)
+	globalRequestStats.latency.Add(end - start)
+	switch {
+	case err != nil:
+		globalRequestStats.errors.Add(1)
+	case res.StatusCode == http.StatusServiceUnavailable || res.StatusCode == http.StatusTooManyRequests:
+		globalRequestStats.throttled.Add(1)
+	case res.StatusCode >= http.StatusInternalServerError:
+		globalRequestStats.errors.Add(1)
+	}
+	return res, err
+}
+
+// concurrencyController - decides the number of workers of a parallel
+// manager, additive increase while the throughput improves and
+// multiplicative decrease when the servers throttle the requests,
+// fail them or slow down.
+type concurrencyController struct {
+	minWorkers int
+	maxWorkers int
+	step       int
+
+	bestThroughput int64
+	baseLatency    time.Duration
+	steady         int
+}
+
+// concurrencyMessage - a change of the number of workers, told apart from
+// the messages of the transfers by its type.
+type concurrencyMessage struct {
+	Status     string        `json:"status"`
+	Type       string        `json:"type"`
+	Workers    int           `json:"workers"`
+	Previous   int           `json:"previous"`
+	Reason     string        `json:"reason"`
+	Throughput int64         `json:"throughput"`
+	Requests   int64         `json:"requests"`
+	Throttled  int64         `json:"throttled"`
+	Errors     int64         `json:"errors"`
+	Latency    time.Duration `json:"latency"`
+}
+
+func (m concurrencyMessage) String() string {
+	return fmt.Sprintf("workers %d -> %d (%s, %s/s, latency %s)", m.Previous, m.Workers, m.Reason,
+		humanize.IBytes(uint64(m.Throughput)), m.Latency.Round(time.Millisecond))
+}
+
+func (m concurrencyMessage) JSON() string {
+	m.Status = "success"
+	m.Type = "concurrency"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// decide - the number of workers for the next period, given the current
+// one, the bytes per second sent and the requests of the last period.
+func (c *concurrencyController) decide(workers int, throughput int64, s requestSample) (int, string) {
+	if s.requests == 0 && throughput == 0 {
+		return workers, "idle"
+	}
+
+	decrease := func(factor float64, reason string) (int, string) {
+		// The throughput measured with more workers cannot be
+		// reached anymore, probe upward again from here.
+		c.bestThroughput = 0
+		c.steady = 0
+		return max(c.minWorkers, int(float64(workers)*factor)), reason
+	}
+
+	switch {
+	case s.throttled > 0:
+		return decrease(0.5, "throttled")
+	case s.requests > 0 && float64(s.errors)/float64(s.requests) > maxRequestErrorRate:
+		return decrease(0.5, "errors")
+	}
+
+	if s.requests > 0 && (c.baseLatency == 0 || s.latency < c.baseLatency) {
+		c.baseLatency = s.latency
+	}
+	improved := throughput > int64(float64(c.bestThroughput)*minThroughputGain)
+	if improved {
+		c.bestThroughput = throughput
+	}
+	if !improved && s.latency > maxLatencyFactor*c.baseLatency && s.latency-c.baseLatency > minLatencyIncrease {
+		return decrease(0.75, "latency")
+	}
+
+	if improved {
+		c.steady = 0
+	} else {
+		c.steady++
+	}
+	if c.steady >= maxSteadyPeriods {
+		return workers, "steady"
+	}
+	return min(c.maxWorkers, workers+c.step), "throughput"
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// slowBody - a request body uploaded slowly.
+type slowBody struct{ sent bool }
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	if b.sent {
+		return 0, io.EOF
+	}
+	time.Sleep(200 * time.Millisecond)
+	b.sent = true
+	return copy(p, "body"), nil
+}
+
+func TestStatsTransportLatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	var prev requestSample
+	globalRequestStats.sample(&prev)
+
+	req, e := http.NewRequest(http.MethodPut, server.URL, &slowBody{})
+	if e != nil {
+		t.Fatal(e)
+	}
+	res, e := (statsTransport{RoundTripper: http.DefaultTransport}).RoundTrip(req)
+	if e != nil {
+		t.Fatal(e)
+	}
+	res.Body.Close()
+
+	// The upload of the body is not part of the latency.
+	s := globalRequestStats.sample(&prev)
+	if s.requests != 1 || s.latency < 20*time.Millisecond || s.latency >= 200*time.Millisecond {
+		t.Fatalf("expected 1 request of about 20ms, got %d of %s", s.requests, s.latency)
+	}
+}
+
+func TestConcurrencyController(t *testing.T) {
+	fast := requestSample{requests: 100, latency: 10 * time.Millisecond}
+	slow := requestSample{requests: 100, latency: 200 * time.Millisecond}
+
+	testCases := []struct {
+		workers    int
+		throughput int64
+		sample     requestSample
+		expected   int
+		reason     string
+	}{
+		{8, 100, fast, 12, "throughput"},
+		{12, 200, fast, 16, "throughput"},
+		// No improvement, keep probing for a few periods.
+		{16, 200, fast, 20, "throughput"},
+		{20, 200, fast, 24, "throughput"},
+		{24, 200, fast, 24, "steady"},
+		// Slower requests without throughput gain.
+		{24, 200, slow, 18, "latency"},
+		{18, 0, requestSample{}, 18, "idle"},
+		{18, 150, requestSample{requests: 100, throttled: 1, latency: 10 * time.Millisecond}, 9, "throttled"},
+		// The workers grow again after a decrease.
+		{9, 100, fast, 13, "throughput"},
+		{13, 100, requestSample{requests: 10, errors: 2}, 6, "errors"},
+		{1, 100, requestSample{requests: 10, errors: 2}, 1, "errors"},
+		{30, 1000, fast, 32, "throughput"},
+	}
+
+	c := concurrencyController{minWorkers: 1, maxWorkers: 32, step: 4}
+	for i, testCase := range testCases {
+		n, reason := c.decide(testCase.workers, testCase.throughput, testCase.sample)
+		if n != testCase.expected || reason != testCase.reason {
+			t.Fatalf("Test %d: expected %d (%s), got %d (%s)", i+1, testCase.expected, testCase.reason, n, reason)
+		}
+	}
+}
+
+func TestParallelManagerSetWorkers(t *testing.T) {
+	resultCh := make(chan URLs)
+	p := newParallelManager(resultCh, 8)
+	defer p.stopAndWait()
+
+	for _, n := range []int{8, 2, 5, 1, 8} {
+		p.setWorkers(n)
+		if p.workers() != n {
+			t.Fatalf("expected %d workers, got %d", n, p.workers())
+		}
+	}
+
+	// Workers quit only when asked to.
+	p.setWorkers(3)
+	deadline := time.Now().Add(10 * time.Second)
+	for len(p.retireCh) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if p.workers() != 3 || len(p.retireCh) != 0 {
+		t.Fatalf("expected 3 workers, got %d (%d quitting)", p.workers(), len(p.retireCh))
+	}
+}
+
+func TestConcurrencyMessageJSON(t *testing.T) {
+	var msg struct {
+		Status  string `json:"status"`
+		Type    string `json:"type"`
+		Workers int    `json:"workers"`
+	}
+	if e := json.Unmarshal([]byte(concurrencyMessage{Workers: 4, Previous: 2}.JSON()), &msg); e != nil {
+		t.Fatal(e)
+	}
+	if msg.Status != "success" || msg.Type != "concurrency" || msg.Workers != 4 {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/v3/env"
+)
+
+// defaultDownloadPartSize - size of the ranges of a parallel download.
+const defaultDownloadPartSize = "64MiB"
+
+// rangeDownload - an object downloaded with concurrent range requests,
+// each range is written in place at its offset.
+type rangeDownload struct {
+	size     int64
+	partSize int64
+	threads  int
+
+	// first streams the object from its start, it serves the first range.
+	first io.Reader
+	// fetch streams a range of the object.
+	fetch func(ctx context.Context, offset, length int64) (io.ReadCloser, *probe.Error)
+	// verify checks the file downloaded to path before it is
+	// committed to target, if set.
+	verify func(path, target string) *probe.Error
+}
+
+// writeAt - download all ranges to w, the first error cancels the others.
+func (d rangeDownload) writeAt(ctx context.Context, w io.WriterAt, progress io.Reader) *probe.Error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parts := (d.size + d.partSize - 1) / d.partSize
+	partCh := make(chan int64)
+	go func() {
+		defer close(partCh)
+		for part := int64(0); part < parts; part++ {
+			select {
+			case partCh <- part:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr *probe.Error
+	)
+	for i := int64(0); i < int64(d.threads) && i < parts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range partCh {
+				if err := d.writePart(ctx, w, part, progress); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// writePart - download one range to w.
+func (d rangeDownload) writePart(ctx context.Context, w io.WriterAt, part int64, progress io.Reader) *probe.Error {
+	offset := part * d.partSize
+	length := min(d.partSize, d.size-offset)
+
+	reader := d.first
+	if part > 0 || reader == nil {
+		rc, err := d.fetch(ctx, offset, length)
+		if err != nil {
+			return err.Trace()
+		}
+		defer rc.Close()
+		reader = rc
+	}
+
+	n, e := io.Copy(io.NewOffsetWriter(w, offset), hookreader.NewHook(io.LimitReader(reader, length), progress))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if n < length {
+		return probe.NewError(UnexpectedEOF{
+			TotalSize:    length,
+			TotalWritten: n,
+		})
+	}
+	return nil
+}
+
+// newRangeDownload - prepare the download of an object to a local file with
+// concurrent range requests, nil when a single stream is used instead. The
+// stream already opened on the source serves the first range.
+func newRangeDownload(uploadOpts uploadSourceToTargetURLOpts, content *ClientContent, first io.Reader, srcSSE encrypt.ServerSide) (*rangeDownload, *probe.Error) {
+	urls := uploadOpts.urls
+	if uploadOpts.isZip || uploadOpts.openSource != nil ||
+		urls.SourceContent.URL.Type != objectStorage || urls.TargetContent.URL.Type != fileSystem {
+		return nil, nil
+	}
+	// The checksums of objects encrypted on the client do not verify the
+	// content, compressed objects are decompressed from their start.
+	if _, ok := srcSSE.(*clientKey); ok || isDecompressed(first) {
+		return nil, nil
+	}
+
+	v := uploadOpts.downloadThreads
+	if v == "" {
+		v = env.Get("MC_DOWNLOAD_PARALLEL", "1")
+	}
+	threads, e := strconv.Atoi(v)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	v = uploadOpts.downloadPartSize
+	if v == "" {
+		v = env.Get("MC_DOWNLOAD_PART_SIZE", defaultDownloadPartSize)
+	}
+	partSize, e := humanize.ParseBytes(v)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	if threads <= 1 || partSize == 0 || content.Size <= int64(partSize) {
+		return nil, nil
+	}
+
+	sourceClnt, err := newClientFromAlias(urls.SourceAlias, urls.SourceContent.URL.String())
+	if err != nil {
+		return nil, err.Trace(urls.SourceAlias, urls.SourceContent.URL.String())
+	}
+
+	// The ranges are read from the version of the first stream,
+	// a concurrent overwrite of the object fails the download.
+	versionID := content.VersionID
+	if versionID == "" {
+		versionID = urls.SourceContent.VersionID
+	}
+	fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, *probe.Error) {
+		reader, _, err := sourceClnt.Get(ctx, GetOptions{
+			SSE:         srcSSE,
+			VersionID:   versionID,
+			RangeStart:  offset,
+			RangeLength: length,
+			MatchETag:   content.ETag,
+		})
+		return reader, err
+	}
+
+	return &rangeDownload{
+		size:     content.Size,
+		partSize: int64(partSize),
+		threads:  threads,
+		first:    first,
+		fetch:    fetch,
+		verify:   verifyDownload(urls.SourceContent, content, srcSSE != nil),
+	}, nil
+}
+
+// verifyDownload - verify a downloaded file with the checksum of its
+// source, or with its ETag when that is the MD5 sum of the content.
+// Sources with neither are not verified.
+func verifyDownload(listed, content *ClientContent, encrypted bool) func(path, target string) *probe.Error {
+	return func(path, target string) *probe.Error {
+		mismatch := probe.NewError(ContentMismatch{Source: content.URL.String(), Target: target})
+		for _, checksums := range []map[string]string{content.Checksum, listed.Checksum} {
+			for _, key := range verifyChecksumOrder {
+				value, ok := checksums[key]
+				if !ok || strings.Contains(value, "-") {
+					continue
+				}
+				sum, e := hashFile(path, checksumTypes[key].Hasher())
+				if e != nil {
+					return probe.NewError(e)
+				}
+				if base64.StdEncoding.EncodeToString(sum) != value {
+					return mismatch
+				}
+				return nil
+			}
+		}
+
+		// The ETag of an encrypted object is not the MD5 sum of its content.
+		if encrypted || content.Metadata["X-Amz-Server-Side-Encryption"] != "" ||
+			content.Metadata["X-Amz-Server-Side-Encryption-Customer-Algorithm"] != "" {
+			return nil
+		}
+		if equal, known := localETagEqual(path, content.Size, content.ETag); known && !equal {
+			return mismatch
+		}
+		return nil
+	}
+}
+
+// putTargetRanges writes a local file from concurrent range requests.
+func putTargetRanges(ctx context.Context, alias, urlStr string, d rangeDownload, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
+	targetClnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return 0, err.Trace(alias, urlStr)
+	}
+	fsClnt, ok := targetClnt.(*fsClient)
+	if !ok {
+		return 0, errInvalidArgument().Trace(alias, urlStr)
+	}
+	n, err := fsClnt.putRanges(ctx, d, progress, opts)
+	if err != nil {
+		return n, err.Trace(alias, urlStr)
+	}
+	return n, nil
+}
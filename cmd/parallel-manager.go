@@ -33,7 +33,7 @@ const (
 	// Maximum number of parallel workers
 	maxParallelWorkers = 128
 
-	// Monitor tick to decide to add new workers
+	// Monitor tick to decide to add or remove workers
 	monitorPeriod = 4 * time.Second
 )
 
@@ -72,6 +72,9 @@ type ParallelManager struct {
 	// Channel to send back results
 	resultCh chan URLs
 
+	// Channel to ask idle workers to quit
+	retireCh chan struct{}
+
 	stopMonitorCh chan struct{}
 
 	// The maximum memory to use
@@ -81,13 +84,17 @@ type ParallelManager struct {
 	maxWorkers int
 }
 
-// addWorker creates a new worker to process tasks
-func (p *ParallelManager) addWorker() {
-	maxWorkers := maxParallelWorkers
+// workersLimit returns the maximum number of workers
+func (p *ParallelManager) workersLimit() int {
 	if p.maxWorkers > 0 {
-		maxWorkers = p.maxWorkers
+		return p.maxWorkers
 	}
-	if int(atomic.LoadUint32(&p.workersNum)) >= maxWorkers {
+	return maxParallelWorkers
+}
+
+// addWorker creates a new worker to process tasks
+func (p *ParallelManager) addWorker() {
+	if int(atomic.LoadUint32(&p.workersNum)) >= p.workersLimit() {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -101,7 +108,16 @@ func (p *ParallelManager) addWorker() {
 	go func() {
 		for {
 			// Wait for jobs
-			t, ok := <-p.queueCh
+			var t task
+			var ok bool
+			select {
+			case t, ok = <-p.queueCh:
+			case <-p.retireCh:
+				// Too many workers, quit
+				atomic.AddUint32(&p.workersNum, ^uint32(0))
+				p.wg.Done()
+				return
+			}
 			if !ok {
 				// No more tasks, quit
 				p.wg.Done()
@@ -125,17 +141,48 @@ func (p *ParallelManager) Read(b []byte) (n int, err error) {
 	return len(b), nil
 }
 
-// monitorProgress monitors realtime transfer speed of data
-// and increases threads until it reaches a maximum number of
-// threads or notice there is no apparent enhancement of
-// transfer speed.
+// workers returns the number of workers, not counting
+// the ones asked to quit.
+func (p *ParallelManager) workers() int {
+	return int(atomic.LoadUint32(&p.workersNum)) - len(p.retireCh)
+}
+
+// setWorkers adds or removes workers to reach n workers,
+// busy workers quit once their current task is done.
+func (p *ParallelManager) setWorkers(n int) {
+	// Cancel pending requests to quit first.
+	for p.workers() < n && len(p.retireCh) > 0 {
+		select {
+		case <-p.retireCh:
+		default:
+		}
+	}
+	for p.workers() < n {
+		p.addWorker()
+	}
+	for p.workers() > n {
+		p.retireCh <- struct{}{}
+	}
+}
+
+// monitorProgress monitors realtime transfer speed of data,
+// the rate of errors and the latency of the requests, and
+// adapts the number of workers to them: workers are added
+// while the transfer speed increases and removed when the
+// server throttles the requests, fails them or slows down.
 func (p *ParallelManager) monitorProgress() {
 	go func() {
 		ticker := time.NewTicker(monitorPeriod)
 		defer ticker.Stop()
 
-		var prevSentBytes, maxBandwidth int64
-		var retry int
+		controller := concurrencyController{
+			minWorkers: 1,
+			maxWorkers: p.workersLimit(),
+			step:       defaultWorkerFactor,
+		}
+		var prevSentBytes int64
+		var prevRequests requestSample
+		globalRequestStats.sample(&prevRequests)
 
 		for {
 			select {
@@ -145,24 +192,27 @@ func (p *ParallelManager) monitorProgress() {
 			case <-ticker.C:
 				// Compute new bandwidth from counted sent bytes
 				sentBytes := atomic.LoadInt64(&p.sentBytes)
-				bandwidth := sentBytes - prevSentBytes
+				throughput := (sentBytes - prevSentBytes) / int64(monitorPeriod/time.Second)
 				prevSentBytes = sentBytes
+				requests := globalRequestStats.sample(&prevRequests)
 
-				if bandwidth <= maxBandwidth {
-					retry++
-					// We still want to add more workers
-					// until we are sure that it is not
-					// useful to add more of them.
-					if retry > 2 {
-						return
-					}
-				} else {
-					retry = 0
-					maxBandwidth = bandwidth
+				workers := p.workers()
+				n, reason := controller.decide(workers, throughput, requests)
+				if n == workers {
+					continue
 				}
-
-				for i := 0; i < defaultWorkerFactor; i++ {
-					p.addWorker()
+				p.setWorkers(n)
+				if globalJSON {
+					printMsg(concurrencyMessage{
+						Workers:    n,
+						Previous:   workers,
+						Reason:     reason,
+						Throughput: throughput,
+						Requests:   requests.requests,
+						Throttled:  requests.throttled,
+						Errors:     requests.errors,
+						Latency:    requests.latency,
+					})
 				}
 			}
 		}
@@ -280,6 +330,7 @@ func newParallelManager(resultCh chan URLs, maxWorkers int) *ParallelManager {
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan task),
 		resultCh:      resultCh,
+		retireCh:      make(chan struct{}, max(maxWorkers, maxParallelWorkers)),
 		maxMem:        availableMemory(),
 		maxWorkers:    maxWorkers,
 	}
@@ -22,9 +22,11 @@ import (
 	"io"
 	"os"
 	"runtime/debug"
+	"sync"
 	"syscall"
 
 	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
@@ -80,7 +82,7 @@ var pipeCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] [TARGET]
+  {{.HelpName}} [FLAGS] [TARGET...]
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -114,6 +116,9 @@ EXAMPLES:
 
   8. Set tags to the uploaded objects
       {{.Prompt}} tar cvf - . | {{.HelpName}} --tags "category=prod&type=backup" play/mybucket/backup.tar
+
+  9. Stream a database dump to two sites at once, the dump is read once.
+      {{.Prompt}} mysqldump -u root -p ******* accountsdb | {{.HelpName}} site1/sql-backups/accountsdb.sql site2/sql-backups/accountsdb.sql
 `,
 }
 
@@ -122,36 +127,44 @@ type pipeMessage struct {
 	Status string `json:"status"`
 	Target string `json:"target"`
 	Size   int64  `json:"size"`
+	Error  string `json:"error,omitempty"`
+
+	err *probe.Error
 }
 
 // String colorized pipe message
 func (p pipeMessage) String() string {
+	if p.err != nil {
+		return console.Colorize("PipeError", fmt.Sprintf("%d bytes -> `%s` failed: %s", p.Size, p.Target, p.err.ToGoError()))
+	}
 	return console.Colorize("Pipe", fmt.Sprintf("%d bytes -> `%s`", p.Size, p.Target))
 }
 
 // JSON jsonified pipe message
 func (p pipeMessage) JSON() string {
 	p.Status = "success"
+	if p.err != nil {
+		p.Status = "error"
+		p.Error = p.err.ToGoError().Error()
+	}
 	pipeMessageBytes, e := json.MarshalIndent(p, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(pipeMessageBytes)
 }
 
-func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPair, meta map[string]string, quiet bool, json bool) *probe.Error {
+func pipe(ctx *cli.Context, targetURLs []string, encKeyDB map[string][]prefixSSEPair, meta map[string]string, quiet bool, json bool) *probe.Error {
 	// If possible increase the pipe buffer size
 	if e := increasePipeBufferSize(os.Stdin, ctx.Int("pipe-max-size")); e != nil {
 		fatalIf(probe.NewError(e), "Unable to increase custom pipe-max-size")
 	}
 
-	if targetURL == "" {
+	if len(targetURLs) == 0 {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
 	}
 	md5, checksum := parseChecksum(ctx)
 	storageClass := ctx.String("storage-class")
-	alias, _ := url2Alias(targetURL)
-	sseKey := getSSE(targetURL, encKeyDB[alias])
 
 	multipartThreads := ctx.Int("concurrent")
 	if multipartThreads > 1 {
@@ -168,20 +181,6 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 		}
 	}
 
-	// Stream from stdin to multiple objects until EOF.
-	// Ignore size, since os.Stat() would not return proper size all the time
-	// for local filesystem for example /proc files.
-	opts := PutOptions{
-		sse:              sseKey,
-		storageClass:     storageClass,
-		metadata:         meta,
-		multipartSize:    multipartSize,
-		multipartThreads: uint(multipartThreads),
-		concurrentStream: ctx.IsSet("concurrent"),
-		md5:              md5,
-		checksum:         checksum,
-	}
-
 	var reader io.Reader
 	if !quiet && !json {
 		pg := newProgressBar(0)
@@ -190,26 +189,74 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 		reader = os.Stdin
 	}
 
-	n, err := putTargetStreamWithURL(targetURL, reader, -1, opts)
-	// TODO: See if this check is necessary.
-	switch e := err.ToGoError().(type) {
-	case *os.PathError:
-		if e.Err == syscall.EPIPE {
-			// stdin closed by the user. Gracefully exit.
-			return nil
+	// Stream from stdin to multiple objects until EOF.
+	// Ignore size, since os.Stat() would not return proper size all the time
+	// for local filesystem for example /proc files.
+	putOpts := func(targetURL string) PutOptions {
+		alias, _ := url2Alias(targetURL)
+		return PutOptions{
+			sse:              getSSE(targetURL, encKeyDB[alias]),
+			storageClass:     storageClass,
+			metadata:         meta,
+			multipartSize:    multipartSize,
+			multipartThreads: uint(multipartThreads),
+			concurrentStream: ctx.IsSet("concurrent"),
+			md5:              md5,
+			checksum:         checksum,
+		}
+	}
+
+	if len(targetURLs) == 1 {
+		targetURL := targetURLs[0]
+		n, err := putTargetStreamWithURL(targetURL, reader, -1, putOpts(targetURL))
+		// TODO: See if this check is necessary.
+		switch e := err.ToGoError().(type) {
+		case *os.PathError:
+			if e.Err == syscall.EPIPE {
+				// stdin closed by the user. Gracefully exit.
+				return nil
+			}
+		case nil:
+			printMsg(pipeMessage{
+				Target: targetURL,
+				Size:   n,
+			})
+		}
+		return err.Trace(targetURL)
+	}
+
+	// Stream stdin to all targets at once, a failing target
+	// does not stop the others.
+	readers := teeReaders(io.NopCloser(reader), len(targetURLs))
+	msgs := make([]pipeMessage, len(targetURLs))
+	var wg sync.WaitGroup
+	for i, targetURL := range targetURLs {
+		wg.Add(1)
+		go func(i int, targetURL string) {
+			defer wg.Done()
+			n, err := putTargetStreamWithURL(targetURL, readers[i], -1, putOpts(targetURL))
+			readers[i].Close()
+			msgs[i] = pipeMessage{Target: targetURL, Size: n}
+			if err != nil {
+				msgs[i].err = err.Trace(targetURL)
+			}
+		}(i, targetURL)
+	}
+	wg.Wait()
+
+	var failed *probe.Error
+	for _, msg := range msgs {
+		if msg.err != nil {
+			failed = msg.err
 		}
-	case nil:
-		printMsg(pipeMessage{
-			Target: targetURL,
-			Size:   n,
-		})
+		printMsg(msg)
 	}
-	return err.Trace(targetURL)
+	return failed
 }
 
 // checkPipeSyntax - validate arguments passed by user
 func checkPipeSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) < 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code.
 	}
 }
@@ -219,6 +266,10 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	// Additional command specific theme customization.
+	console.SetColor("Pipe", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PipeError", color.New(color.FgRed, color.Bold))
+
 	encKeyDB, err := validateAndCreateEncryptionKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
@@ -236,13 +287,13 @@ func mainPipe(ctx *cli.Context) error {
 		meta["X-Amz-Tagging"] = tags
 	}
 	if len(ctx.Args()) == 0 {
-		err = pipe(ctx, "", nil, meta, quiet, json)
+		err = pipe(ctx, nil, nil, meta, quiet, json)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(ctx, URLs[0], encKeyDB, meta, quiet, json)
-		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
+		err = pipe(ctx, URLs, encKeyDB, meta, quiet, json)
+		fatalIf(err.Trace(URLs...), "Unable to write to one or more targets.")
 	}
 
 	// Done.
@@ -55,6 +55,16 @@ var (
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object on target",
 		},
+		cli.BoolFlag{
+			Name:  "continue, c",
+			Usage: "resume an interrupted multipart upload of the same object",
+		},
+		cli.BoolFlag{
+			Name:  "preserve, a",
+			Usage: "preserve filesystem attributes (mode, ownership, timestamps)",
+		},
+		extractFlag,
+		compressFlag,
 	}
 )
 
@@ -98,6 +108,18 @@ EXAMPLES:
 
   6. Put an object to MinIO storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
       {{.Prompt}} {{.HelpName}} --storage-class REDUCED_REDUNDANCY myobject.txt play/mybucket
+
+  7. Put a large file, re-running the same command after an interruption uploads only the missing parts.
+      {{.Prompt}} {{.HelpName}} --continue backup.tar play/mybucket
+
+  8. Put the files of a tarball under a prefix, keeping their modes and modification times as metadata.
+      {{.Prompt}} {{.HelpName}} --extract --preserve site.tar.zst play/mybucket/site/
+
+  9. Put an object encrypted on the client with a key file, the server never sees its content.
+      {{.Prompt}} {{.HelpName}} --enc-client "play/mybucket/=file:~/.mc/keys/customer.key" path-to/object play/mybucket/object
+
+  10. Put a log file compressed with gzip, 'mc cat' shows its content.
+      {{.Prompt}} {{.HelpName}} --compress gzip app.log play/mybucket/logs/app.log
 `,
 }
 
@@ -136,6 +158,24 @@ func mainPut(cliCtx *cli.Context) (e error) {
 	}
 	fatalIf(err, "SSE Error")
 	md5, checksum := parseChecksum(cliCtx)
+	compress := parseCompress(cliCtx)
+
+	if cliCtx.Bool("extract") {
+		checkExtractSyntax(cliCtx, []string{"continue", "if-not-exists"})
+		return extractArchive(ctx, extractOpts{
+			sourceURL:        args.Get(0),
+			targetURL:        args.Get(1),
+			encKeyDB:         encryptionKeys,
+			preserve:         cliCtx.Bool("preserve"),
+			storageClass:     cliCtx.String("sc"),
+			md5:              md5,
+			checksum:         checksum,
+			compress:         compress,
+			disableMultipart: disableMultipart,
+			multipartSize:    size,
+			multipartThreads: strconv.Itoa(threads),
+		})
+	}
 
 	if len(args) < 2 {
 		fatalIf(errInvalidArgument().Trace(args...), "Invalid number of arguments.")
@@ -177,6 +217,7 @@ func mainPut(cliCtx *cli.Context) (e error) {
 				putURLs.TargetContent.StorageClass = storageClass
 			}
 			putURLs.checksum = checksum
+			putURLs.compress = compress
 			putURLs.MD5 = md5
 			totalBytes += putURLs.SourceContent.Size
 			pg.SetTotal(totalBytes)
@@ -208,6 +249,8 @@ func mainPut(cliCtx *cli.Context) (e error) {
 				multipartSize:    size,
 				multipartThreads: strconv.Itoa(threads),
 				ifNotExists:      cliCtx.Bool("if-not-exists"),
+				resumeMultipart:  cliCtx.Bool("continue"),
+				preserve:         cliCtx.Bool("preserve"),
 			})
 			if urls.Error != nil {
 				showLastProgressBar(pg, urls.Error.ToGoError())
@@ -137,7 +137,9 @@ func setRetentionSingle(ctx context.Context, op lockOpType, alias, url, versionI
 		VersionID: versionID,
 	}
 
-	err = newClnt.PutObjectRetention(ctx, versionID, mode, retainUntil, bypassGovernance)
+	err = aliasRetryPolicy(alias).do(ctx, nil, nil, func() *probe.Error {
+		return newClnt.PutObjectRetention(ctx, versionID, mode, retainUntil, bypassGovernance)
+	})
 	if err != nil {
 		msg.Err = err.ToGoError()
 		msg.Status = "failure"
@@ -2,32 +2,239 @@ package cmd
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 )
 
-type retryManager struct {
-	retries       int
-	maxRetries    int
-	retryInterval time.Duration
-	commandCtx    context.Context
-	retryCtx      context.Context
-	cancelRetry   context.CancelFunc
+// retryPolicy - how an operation failing with a transient error is
+// retried: up to maxAttempts attempts, waiting between them for an
+// exponential backoff with full jitter, as long as maxElapsed is not
+// exceeded since the first attempt.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxElapsed  time.Duration
 }
 
-func newRetryManager(ctx context.Context, retryInterval time.Duration, maxRetries int) *retryManager {
-	retryCtx, cancelFunc := context.WithCancel(context.Background())
-	return &retryManager{
-		retryInterval: retryInterval,
-		maxRetries:    maxRetries,
-		commandCtx:    ctx,
-		retryCtx:      retryCtx,
-		cancelRetry:   cancelFunc,
+// defaultRetryPolicy - the policy of the aliases without one.
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 3,
+	backoff:     time.Second,
+	maxBackoff:  30 * time.Second,
+	maxElapsed:  5 * time.Minute,
+}
+
+func (p retryPolicy) String() string {
+	if p.maxAttempts <= 1 {
+		return "off"
+	}
+	return fmt.Sprintf("attempts=%d,backoff=%s,max-backoff=%s,max-elapsed=%s",
+		p.maxAttempts, p.backoff, p.maxBackoff, p.maxElapsed)
+}
+
+// parseRetryPolicy - parse a comma separated list of 'attempts=N',
+// 'backoff=DURATION', 'max-backoff=DURATION' and 'max-elapsed=DURATION',
+// unset values are the defaults. 'off' disables the retries.
+func parseRetryPolicy(s string) (retryPolicy, error) {
+	p := defaultRetryPolicy
+	if strings.EqualFold(strings.TrimSpace(s), "off") {
+		p.maxAttempts = 1
+		return p, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return retryPolicy{}, fmt.Errorf("invalid retry policy entry '%s', expected KEY=VALUE", entry)
+		}
+		var e error
+		switch strings.ToLower(key) {
+		case "attempts":
+			p.maxAttempts, e = strconv.Atoi(value)
+			if e == nil && p.maxAttempts < 1 {
+				e = errors.New("at least one attempt is required")
+			}
+		case "backoff":
+			p.backoff, e = time.ParseDuration(value)
+		case "max-backoff":
+			p.maxBackoff, e = time.ParseDuration(value)
+		case "max-elapsed":
+			p.maxElapsed, e = time.ParseDuration(value)
+		default:
+			return retryPolicy{}, fmt.Errorf("unknown retry policy key '%s', valid keys are 'attempts, backoff, max-backoff, max-elapsed'", key)
+		}
+		if e != nil {
+			return retryPolicy{}, fmt.Errorf("invalid retry policy entry '%s': %w", entry, e)
+		}
+	}
+	if p.backoff < 0 || p.maxBackoff < 0 || p.maxElapsed < 0 {
+		return retryPolicy{}, fmt.Errorf("invalid retry policy '%s': negative duration", s)
+	}
+	return p, nil
+}
+
+// aliasRetryPolicy - the retry policy of an alias, set with --retry-policy
+// or else in the config of the alias.
+func aliasRetryPolicy(alias string) retryPolicy {
+	if globalRetryPolicy != nil {
+		return *globalRetryPolicy
+	}
+	if alias == "" {
+		return defaultRetryPolicy
+	}
+	aliasCfg := mustGetHostConfig(alias)
+	if aliasCfg == nil || aliasCfg.RetryPolicy == "" {
+		return defaultRetryPolicy
+	}
+	p, e := parseRetryPolicy(aliasCfg.RetryPolicy)
+	fatalIf(probe.NewError(e).Trace(alias), "Invalid retry policy of alias `"+alias+"`.")
+	return p
+}
+
+// urlsRetryPolicy - the retry policy of a copy, the one of the target
+// unless it is a local path.
+func urlsRetryPolicy(urls URLs) retryPolicy {
+	if urls.TargetContent != nil && urls.TargetContent.URL.Type == objectStorage {
+		return aliasRetryPolicy(urls.TargetAlias)
+	}
+	if urls.SourceContent != nil && urls.SourceContent.URL.Type == objectStorage {
+		return aliasRetryPolicy(urls.SourceAlias)
+	}
+	return aliasRetryPolicy("")
+}
+
+// setAdminRetryPolicy - apply the attempts of the retry policy of alias
+// to admin requests. madmin retries transient errors itself with its own
+// backoff, and its number of attempts is shared by all its clients.
+func setAdminRetryPolicy(alias string) {
+	madmin.MaxRetry = aliasRetryPolicy(alias).maxAttempts
+}
+
+// wait - the time to wait before the attempt following the given one,
+// a random duration up to the exponential backoff.
+func (p retryPolicy) wait(attempt int) time.Duration {
+	backoff := p.backoff
+	for i := 1; i < attempt && backoff < p.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.maxBackoff > 0 && backoff > p.maxBackoff {
+		backoff = p.maxBackoff
+	}
+	if backoff <= 0 {
+		return 0
 	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// retryable - the errors to retry, isRetryableError when nil.
+type retryable func(err *probe.Error) bool
+
+// do - run action until it succeeds, fails with an error which is not
+// retryable or the attempts are exhausted, the error of the last attempt
+// is returned. onRetry, if set, is called before every new attempt.
+func (p retryPolicy) do(ctx context.Context, retry retryable, onRetry func(attempt int, err *probe.Error), action func() *probe.Error) *probe.Error {
+	if retry == nil {
+		retry = isRetryableError
+	}
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := action()
+		if err == nil || attempt >= p.maxAttempts || !retry(err) {
+			return err
+		}
+
+		wait := p.wait(attempt)
+		if p.maxElapsed > 0 && time.Since(start)+wait > p.maxElapsed {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+	}
+}
+
+// retryableS3Codes - S3 error codes of transient failures.
+var retryableS3Codes = map[string]bool{
+	"RequestError":               true,
+	"RequestTimeout":             true,
+	"Throttling":                 true,
+	"ThrottlingException":        true,
+	"RequestLimitExceeded":       true,
+	"RequestThrottled":           true,
+	"InternalError":              true,
+	"SlowDown":                   true,
+	"SlowDownRead":               true,
+	"SlowDownWrite":              true,
+	"ServiceUnavailable":         true,
+	"OperationAborted":           true,
+	"XMinioServerNotInitialized": true,
+}
+
+// retryableHTTPStatus - HTTP status codes of transient failures.
+var retryableHTTPStatus = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// isRetryableError - true when err is a transient failure, a throttled
+// or failed request, a network error or an interrupted transfer, which
+// may not happen again.
+func isRetryableError(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	e := err.ToGoError()
+	if errors.Is(e, context.Canceled) {
+		return false
+	}
+
+	switch e.(type) {
+	case UnexpectedEOF, UnexpectedShortWrite, ContentMismatch:
+		return true
+	}
+
+	var errResp minio.ErrorResponse
+	if errors.As(e, &errResp) {
+		return retryableS3Codes[errResp.Code] || retryableHTTPStatus[errResp.StatusCode]
+	}
+
+	var certErr x509.UnknownAuthorityError
+	if errors.As(e, &certErr) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(e, &netErr) {
+		return true
+	}
+	return errors.Is(e, context.DeadlineExceeded) ||
+		errors.Is(e, io.ErrUnexpectedEOF) ||
+		errors.Is(e, syscall.ECONNRESET) ||
+		errors.Is(e, syscall.ECONNREFUSED) ||
+		errors.Is(e, syscall.EPIPE)
 }
 
 type retryMessage struct {
@@ -46,23 +253,115 @@ func (r retryMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
-func (r *retryManager) retry(action func(rm *retryManager) *probe.Error) {
-	defer r.cancelRetry()
-	for r.retries <= r.maxRetries {
-
-		err := action(r)
-		if err == nil {
-			return
+// printRetry - report a new attempt of a copy.
+func printRetry(urls URLs) func(attempt int, err *probe.Error) {
+	return func(attempt int, _ *probe.Error) {
+		msg := retryMessage{Retries: attempt}
+		if urls.SourceContent != nil {
+			msg.SourceURL = urls.SourceContent.URL.String()
 		}
-
-		select {
-		case <-r.retryCtx.Done():
-			return
-		case <-r.commandCtx.Done():
-			return
-		case <-time.After(r.retryInterval/2 + time.Duration(rand.Int63n(int64(r.retryInterval)))):
-			r.retries++
+		if urls.TargetContent != nil {
+			msg.TargetURL = urls.TargetContent.URL.String()
 		}
+		printMsg(msg)
+	}
+}
+
+// uploadWithRetry - copy an object with the retry policy of its target.
+func uploadWithRetry(ctx context.Context, uploadOpts uploadSourceToTargetURLOpts) URLs {
+	var ret URLs
+	urlsRetryPolicy(uploadOpts.urls).do(ctx, nil, printRetry(uploadOpts.urls), func() *probe.Error {
+		ret = uploadSourceToTargetURL(ctx, uploadOpts)
+		return ret.Error
+	})
+	return ret
+}
 
+// removeKey - identifies an object removed by an S3 client.
+func removeKey(bucket, object, versionID string) string {
+	return bucket + "/" + object + "\x00" + versionID
+}
+
+// removeWithRetry - Client.Remove retrying the objects which failed to
+// be removed with a transient error, with the retry policy of alias.
+func removeWithRetry(ctx context.Context, alias string, clnt Client, isIncomplete, isRemoveBucket, isBypass, isForceDel bool, contentCh <-chan *ClientContent) <-chan RemoveResult {
+	s3Clnt, ok := clnt.(*S3Client)
+	policy := aliasRetryPolicy(alias)
+	// Buckets are removed once their objects are, and forced removals
+	// are single requests retried by the client itself.
+	if !ok || isRemoveBucket || isForceDel || policy.maxAttempts <= 1 {
+		return clnt.Remove(ctx, isIncomplete, isRemoveBucket, isBypass, isForceDel, contentCh)
 	}
+
+	resultCh := make(chan RemoveResult)
+	go func() {
+		defer close(resultCh)
+
+		var (
+			mu      sync.Mutex
+			pending = map[string]*ClientContent{}
+		)
+		start := time.Now()
+		for attempt := 1; ; attempt++ {
+			inCh := make(chan *ClientContent)
+			go func(contentCh <-chan *ClientContent) {
+				defer close(inCh)
+				for content := range contentCh {
+					bucket, object := s3Clnt.splitPath(content.URL.Path)
+					mu.Lock()
+					pending[removeKey(bucket, object, content.VersionID)] = content
+					mu.Unlock()
+					select {
+					case inCh <- content:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(contentCh)
+
+			var retries []*ClientContent
+			var lastErrs []RemoveResult
+			for result := range clnt.Remove(ctx, isIncomplete, false, isBypass, false, inCh) {
+				key := removeKey(result.BucketName, result.ObjectName, result.ObjectVersionID)
+				mu.Lock()
+				content, found := pending[key]
+				delete(pending, key)
+				mu.Unlock()
+				if result.Err != nil && found && isRetryableError(result.Err) {
+					retries = append(retries, content)
+					lastErrs = append(lastErrs, result)
+					continue
+				}
+				resultCh <- result
+			}
+			if len(retries) == 0 {
+				return
+			}
+
+			wait := policy.wait(attempt)
+			giveUp := attempt >= policy.maxAttempts ||
+				(policy.maxElapsed > 0 && time.Since(start)+wait > policy.maxElapsed)
+			if !giveUp {
+				select {
+				case <-ctx.Done():
+					giveUp = true
+				case <-time.After(wait):
+				}
+			}
+			if giveUp {
+				for _, result := range lastErrs {
+					resultCh <- result
+				}
+				return
+			}
+
+			retryCh := make(chan *ClientContent, len(retries))
+			for _, content := range retries {
+				retryCh <- content
+			}
+			close(retryCh)
+			contentCh = retryCh
+		}
+	}()
+	return resultCh
 }
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected string
+		err      bool
+	}{
+		{policy: "off", expected: "off"},
+		{policy: "attempts=1", expected: "off"},
+		{policy: "attempts=5", expected: "attempts=5,backoff=1s,max-backoff=30s,max-elapsed=5m0s"},
+		{policy: "attempts=10, backoff=100ms, max-backoff=2s, max-elapsed=1m", expected: "attempts=10,backoff=100ms,max-backoff=2s,max-elapsed=1m0s"},
+		{policy: "", err: true},
+		{policy: "attempts=0", err: true},
+		{policy: "attempts=three", err: true},
+		{policy: "backoff=-1s", err: true},
+		{policy: "jitter=1s", err: true},
+	}
+
+	for i, testCase := range testCases {
+		policy, e := parseRetryPolicy(testCase.policy)
+		if testCase.err {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error for '%s'", i+1, testCase.policy)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if policy.String() != testCase.expected {
+			t.Fatalf("Test %d: expected '%s', got '%s'", i+1, testCase.expected, policy.String())
+		}
+	}
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := retryPolicy{backoff: 10 * time.Millisecond, maxBackoff: 50 * time.Millisecond}
+	for attempt, maxWait := range []time.Duration{10, 20, 40, 50, 50} {
+		for i := 0; i < 100; i++ {
+			if wait := p.wait(attempt + 1); wait < 0 || wait > maxWait*time.Millisecond {
+				t.Fatalf("attempt %d: unexpected wait %s", attempt+1, wait)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := probe.NewError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable})
+	permanent := probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden})
+	p := retryPolicy{maxAttempts: 3, backoff: time.Millisecond, maxBackoff: time.Millisecond}
+
+	testCases := []struct {
+		errs     []*probe.Error
+		attempts int
+		success  bool
+	}{
+		{errs: []*probe.Error{nil}, attempts: 1, success: true},
+		{errs: []*probe.Error{transient, nil}, attempts: 2, success: true},
+		{errs: []*probe.Error{transient, transient, transient}, attempts: 3},
+		{errs: []*probe.Error{permanent}, attempts: 1},
+		{errs: []*probe.Error{transient, permanent}, attempts: 2},
+	}
+
+	for i, testCase := range testCases {
+		var attempts, retries int
+		err := p.do(context.Background(), nil, func(int, *probe.Error) { retries++ }, func() *probe.Error {
+			err := testCase.errs[attempts]
+			attempts++
+			return err
+		})
+		if attempts != testCase.attempts || retries != attempts-1 {
+			t.Fatalf("Test %d: expected %d attempts, got %d (%d retries)", i+1, testCase.attempts, attempts, retries)
+		}
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: unexpected result %v", i+1, err)
+		}
+	}
+
+	// The maximum elapsed time stops the retries.
+	p = retryPolicy{maxAttempts: 100, backoff: 20 * time.Millisecond, maxBackoff: 20 * time.Millisecond, maxElapsed: 50 * time.Millisecond}
+	var attempts int
+	retryAll := func(*probe.Error) bool { return true }
+	p.do(context.Background(), retryAll, nil, func() *probe.Error {
+		attempts++
+		return permanent
+	})
+	if attempts < 2 || attempts > 50 {
+		t.Fatalf("unexpected number of attempts %d", attempts)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		{minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}, true},
+		{minio.ErrorResponse{Code: "InternalError", StatusCode: http.StatusInternalServerError}, true},
+		{minio.ErrorResponse{StatusCode: http.StatusBadGateway}, true},
+		{minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound}, false},
+		{minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}, false},
+		// Expired credentials fail again until they are refreshed.
+		{minio.ErrorResponse{Code: "ExpiredToken", StatusCode: http.StatusBadRequest}, false},
+		{fmt.Errorf("upload: %w", minio.ErrorResponse{Code: "RequestTimeout", StatusCode: http.StatusBadRequest}), true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{syscall.ECONNRESET, true},
+		{io.ErrUnexpectedEOF, true},
+		{UnexpectedEOF{TotalSize: 10, TotalWritten: 5}, true},
+		{ContentMismatch{Source: "a", Target: "b"}, true},
+		{context.Canceled, false},
+		{ObjectMissing{}, false},
+		{errors.New("invalid argument"), false},
+	}
+
+	for i, testCase := range testCases {
+		if retryable := isRetryableError(probe.NewError(testCase.err)); retryable != testCase.retryable {
+			t.Fatalf("Test %d: expected %v for %v", i+1, testCase.retryable, testCase.err)
+		}
+	}
+	if isRetryableError(nil) {
+		t.Fatal("nil is not retryable")
+	}
+}
@@ -101,6 +101,9 @@ var (
 			Usage:  "attempt a prefix purge, requires confirmation please use with caution - only works with '--force'",
 			Hidden: true,
 		},
+		filterFromFlag,
+		manifestFlag,
+		inventoryFlag,
 	}
 )
 
@@ -117,6 +120,7 @@ var rmCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+  {{.HelpName}} [FLAGS] --manifest FILE [TARGET]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -162,6 +166,15 @@ EXAMPLES:
   14. Perform a fake removal of object(s) versions that are non-current and older than 10 days. If top-level version is a delete 
   marker, this will also be deleted when --non-current flag is specified.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --older-than 10d --dry-run
+
+  15. Remove objects recursively, only those included by the ordered rules of 'rules.txt' such as '+ *.log age>30d' and '- *'.
+      {{.Prompt}} {{.HelpName}} --recursive --force --filter-from rules.txt s3/logs/
+
+  16. Remove the object versions listed in 'versions.csv', rows of 'key,versionId', from a bucket without listing it.
+      {{.Prompt}} {{.HelpName}} --force --manifest versions.csv s3/docs
+
+  17. Remove the objects under "s3/docs/tmp/" found in the last S3 Inventory report of the bucket, without listing it.
+      {{.Prompt}} {{.HelpName}} --recursive --force --inventory s3/reports/docs/daily/2025-01-01T01-00Z/manifest.json s3/docs/tmp/
 `,
 }
 
@@ -219,11 +232,27 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 	rewind := cliCtx.String("rewind")
 	isNamespaceRemoval := false
 
+	if cliCtx.IsSet("manifest") {
+		checkRmManifestSyntax(cliCtx)
+		return
+	}
+
 	if versionID != "" && (isRecursive || isVersions || rewind != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --version-id with any of --versions, --rewind and --recursive flags.")
 	}
 
+	if cliCtx.IsSet("inventory") {
+		if !isRecursive {
+			fatalIf(errDummy().Trace(), "You cannot specify --inventory without --recursive.")
+		}
+		for _, flag := range []string{"rewind", "stdin", "incomplete", "purge"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errDummy().Trace(), fmt.Sprintf("You cannot specify --inventory with --%s.", flag))
+			}
+		}
+	}
+
 	if isNoncurrentVersion && (!isVersions || !isRecursive) {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --non-current without --versions --recursive, please use --non-current --versions --recursive.")
@@ -239,7 +268,12 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 			"You cannot specify --purge with --recursive.")
 	}
 
-	if isForceDel && (isNoncurrentVersion || isVersions || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") || versionID != "") {
+	if cliCtx.IsSet("filter-from") && !isRecursive {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --filter-from without --recursive.")
+	}
+
+	if isForceDel && (isNoncurrentVersion || isVersions || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") || cliCtx.IsSet("filter-from") || versionID != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --purge flag with any flag(s) other than --force.")
 	}
@@ -284,6 +318,23 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 	}
 }
 
+// checkRmManifestSyntax - validate a removal of the objects of a
+// manifest, relative to TARGET when passed.
+func checkRmManifestSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) > 1 {
+		showCommandHelpAndExit(cliCtx, 1)
+	}
+	for _, flag := range []string{"recursive", "versions", "non-current", "version-id", "rewind", "stdin", "incomplete", "purge", "older-than", "newer-than", "filter-from"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errDummy().Trace(), fmt.Sprintf("You cannot specify --manifest with --%s.", flag))
+		}
+	}
+	if !cliCtx.Bool("force") {
+		fatalIf(errDummy().Trace(),
+			"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
+	}
+}
+
 // Remove a single object or a single version in a versioned bucket
 func removeSingle(url, versionID string, opts removeOpts) error {
 	ctx, cancel := context.WithCancel(globalContext)
@@ -365,7 +416,7 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 	contentCh <- &ClientContent{URL: contentURL, VersionID: versionID}
 	close(contentCh)
 	isRemoveBucket := false
-	resultCh := clnt.Remove(ctx, opts.isIncomplete, isRemoveBucket, opts.isBypass, opts.isForce && opts.isForceDel, contentCh)
+	resultCh := removeWithRetry(ctx, targetAlias, clnt, opts.isIncomplete, isRemoveBucket, opts.isBypass, opts.isForce && opts.isForceDel, contentCh)
 	for result := range resultCh {
 		if result.Err != nil {
 			errorIf(result.Err.Trace(url), "Failed to remove `%s`.", url)
@@ -401,6 +452,8 @@ type removeOpts struct {
 	isForceDel        bool
 	olderThan         string
 	newerThan         string
+	filter            filterRules
+	inventory         string
 }
 
 func printDryRunMsg(targetAlias string, content *ClientContent, printModTime bool) {
@@ -445,11 +498,16 @@ func listAndRemove(url string, opts removeOpts) error {
 	}
 	atLeastOneObjectFound := false
 
-	resultCh := clnt.Remove(ctx, opts.isIncomplete, isRemoveBucket, opts.isBypass, false, contentCh)
+	resultCh := removeWithRetry(ctx, targetAlias, clnt, opts.isIncomplete, isRemoveBucket, opts.isBypass, false, contentCh)
+
+	listCh := clnt.List(ctx, listOpts)
+	if opts.inventory != "" {
+		listCh = listInventory(ctx, opts.inventory, clnt, listOpts)
+	}
 
 	var lastPath string
 	var perObjectVersions []*ClientContent
-	for content := range clnt.List(ctx, listOpts) {
+	for content := range listCh {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(url), "Failed to remove `%s` recursively.", url)
 			switch content.Err.ToGoError().(type) {
@@ -468,6 +526,11 @@ func listAndRemove(url string, opts removeOpts) error {
 			continue
 		}
 
+		// Skip objects excluded by --filter-from rules, if specified
+		if !opts.filter.Match(strings.TrimPrefix(urlString, clnt.GetURL().Path), content) {
+			continue
+		}
+
 		if !opts.isRecursive {
 			currentObjectURL := getStandardizedURL(targetAlias + getKey(content))
 			standardizedURL := getStandardizedURL(currentObjectURL)
@@ -560,6 +623,16 @@ func listAndRemove(url string, opts removeOpts) error {
 			continue
 		}
 
+		// Objects of a report are removed by their key, unless they
+		// changed since the report.
+		if opts.inventory != "" && content.VersionID == "" {
+			if err := checkInventoryObject(ctx, targetAlias, content); err != nil {
+				objectPath := targetAlias + getKey(content)
+				errorIf(err.Trace(objectPath), "Not removing `%s`.", objectPath)
+				continue
+			}
+		}
+
 		if !opts.isFake {
 			sent := false
 			for !sent {
@@ -695,6 +768,101 @@ func listAndRemove(url string, opts removeOpts) error {
 	return nil
 }
 
+// manifestRemoveBatch - the maximum number of objects of a manifest
+// removed by a task, the limit of a multi-object delete request.
+const manifestRemoveBatch = 1000
+
+// removeManifest removes the objects of a manifest without listing, the
+// objects of each alias are removed in batches running concurrently.
+func removeManifest(ctx context.Context, m manifest, opts removeOpts) error {
+	statusCh := make(chan URLs)
+	parallel := newParallelManager(statusCh, 0)
+
+	var errSeen bool
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for urls := range statusCh {
+			if urls.Error != nil {
+				errSeen = true
+			}
+		}
+	}()
+
+	batches := map[string][]*ClientContent{}
+	flush := func(alias string) {
+		batch := batches[alias]
+		delete(batches, alias)
+		parallel.queueTask(func() URLs {
+			return removeBatch(ctx, alias, batch, opts)
+		}, 0)
+	}
+	err := m.read(func(entry manifestEntry) bool {
+		alias, urlStr, _ := mustExpandAlias(m.source(entry))
+		content := &ClientContent{URL: *newClientURL(urlStr), VersionID: entry.VersionID}
+		if opts.isFake {
+			printDryRunMsg(alias, content, false)
+			return true
+		}
+		batches[alias] = append(batches[alias], content)
+		if len(batches[alias]) >= manifestRemoveBatch {
+			flush(alias)
+		}
+		return ctx.Err() == nil
+	})
+	for alias := range batches {
+		flush(alias)
+	}
+	parallel.stopAndWait()
+	close(statusCh)
+	<-doneCh
+
+	if err != nil {
+		errorIf(err.Trace(m.filename), "Unable to read the manifest `%s`.", m.filename)
+		return exitStatus(globalErrorExitStatus)
+	}
+	if errSeen {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+// removeBatch removes objects of the same alias, the error of the last
+// object which failed to be removed is returned.
+func removeBatch(ctx context.Context, alias string, batch []*ClientContent, opts removeOpts) URLs {
+	clnt, err := newClientFromAlias(alias, batch[0].URL.String())
+	if err != nil {
+		errorIf(err.Trace(alias), "Invalid argument `%s`.", batch[0].URL)
+		return URLs{Error: err}
+	}
+
+	contentCh := make(chan *ClientContent, len(batch))
+	for _, content := range batch {
+		contentCh <- content
+	}
+	close(contentCh)
+
+	var ret URLs
+	for result := range removeWithRetry(ctx, alias, clnt, false, false, opts.isBypass, false, contentCh) {
+		path := path.Join(alias, result.BucketName, result.ObjectName)
+		if result.Err != nil {
+			errorIf(result.Err.Trace(path), "Failed to remove `%s`.", path)
+			ret.Error = result.Err
+			continue
+		}
+		msg := rmMessage{
+			Key:       path,
+			VersionID: result.ObjectVersionID,
+		}
+		if result.DeleteMarker {
+			msg.DeleteMarker = true
+			msg.VersionID = result.DeleteMarkerVersionID
+		}
+		printMsg(msg)
+	}
+	return ret
+}
+
 // main for rm command.
 func mainRm(cliCtx *cli.Context) error {
 	ctx, cancelRm := context.WithCancel(globalContext)
@@ -715,6 +883,8 @@ func mainRm(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
+	filter := parseFilterFromFlag(cliCtx)
+	inventory := cliCtx.String("inventory")
 
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
@@ -723,6 +893,13 @@ func mainRm(cliCtx *cli.Context) error {
 	// Set color.
 	console.SetColor("Removed", color.New(color.FgGreen, color.Bold))
 
+	if m := parseManifestFlag(cliCtx, cliCtx.Args().First()); m != nil {
+		return removeManifest(ctx, *m, removeOpts{
+			isFake:   isFake,
+			isBypass: isBypass,
+		})
+	}
+
 	var rerr error
 	var e error
 	// Support multiple targets.
@@ -739,6 +916,8 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
+				filter:            filter,
+				inventory:         inventory,
 			})
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
@@ -775,6 +954,8 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
+				filter:            filter,
+				inventory:         inventory,
 			})
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
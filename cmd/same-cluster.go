@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// deploymentIDs - the deployment ID of the server of an alias, by alias.
+var deploymentIDs sync.Map
+
+type aliasDeploymentID struct {
+	once sync.Once
+	id   string
+}
+
+// sameEndpoint - whether two endpoint URLs address the same server.
+func sameEndpoint(a, b string) bool {
+	u1, e1 := url.Parse(a)
+	u2, e2 := url.Parse(b)
+	if e1 != nil || e2 != nil || u1.Host == "" || u2.Host == "" {
+		return false
+	}
+	port := func(u *url.URL) string {
+		if p := u.Port(); p != "" {
+			return p
+		}
+		if strings.EqualFold(u.Scheme, "https") {
+			return "443"
+		}
+		return "80"
+	}
+	return strings.EqualFold(u1.Scheme, u2.Scheme) &&
+		strings.EqualFold(u1.Hostname(), u2.Hostname()) &&
+		port(u1) == port(u2) &&
+		strings.Trim(u1.Path, "/") == strings.Trim(u2.Path, "/")
+}
+
+// getDeploymentID - the deployment ID of the server of an alias from the
+// admin info API, empty when it is not MinIO or the credentials of the
+// alias are not allowed to read it. The ID is read once per alias.
+func getDeploymentID(ctx context.Context, alias string) string {
+	v, _ := deploymentIDs.LoadOrStore(alias, &aliasDeploymentID{})
+	d := v.(*aliasDeploymentID)
+	d.once.Do(func() {
+		client, err := newAdminClient(alias)
+		if err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if info, e := client.ServerInfo(ctx); e == nil {
+			d.id = info.DeploymentID
+		}
+	})
+	return d.id
+}
+
+// sameCluster - whether the source and the target of a copy are on the
+// same server under different aliases, by their endpoints or by the
+// deployment ID of the server.
+func sameCluster(ctx context.Context, urls URLs) bool {
+	sourceAlias, targetAlias := urls.SourceAlias, urls.TargetAlias
+	if sourceAlias == "" || targetAlias == "" || sourceAlias == targetAlias {
+		return false
+	}
+	if urls.SourceContent.URL.Type != objectStorage || urls.TargetContent.URL.Type != objectStorage {
+		return false
+	}
+	sourceCfg, targetCfg := mustGetHostConfig(sourceAlias), mustGetHostConfig(targetAlias)
+	if sourceCfg == nil || targetCfg == nil {
+		return false
+	}
+	if sameEndpoint(sourceCfg.URL, targetCfg.URL) {
+		return true
+	}
+	id := getDeploymentID(ctx, sourceAlias)
+	return id != "" && id == getDeploymentID(ctx, targetAlias)
+}
+
+// isAccessDenied - err is the refusal of the server, the credentials of
+// the target of a copy may not be allowed to read its source.
+func isAccessDenied(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.ToGoError().(PathInsufficientPermission)
+	return ok
+}
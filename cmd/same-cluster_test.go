@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestSameEndpoint(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		same bool
+	}{
+		{"http://localhost:9000", "http://localhost:9000", true},
+		{"http://localhost:9000", "http://LOCALHOST:9000/", true},
+		{"https://play.min.io", "https://play.min.io:443", true},
+		{"http://play.min.io", "http://play.min.io:80", true},
+		{"http://play.min.io", "https://play.min.io", false},
+		{"http://localhost:9000", "http://localhost:9001", false},
+		{"http://localhost:9000", "http://127.0.0.1:9000", false},
+		{"http://localhost:9000/s3", "http://localhost:9000", false},
+		{"", "", false},
+	}
+	for i, testCase := range testCases {
+		if same := sameEndpoint(testCase.a, testCase.b); same != testCase.same {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.same, same)
+		}
+	}
+}
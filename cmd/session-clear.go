@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var sessionClearFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all, a",
+		Usage: "clear all sessions",
+	},
+}
+
+var sessionClearCmd = cli.Command{
+	Name:         "clear",
+	Usage:        "clear interrupted sessions",
+	Action:       mainSessionClear,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(sessionClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SESSION-ID [SESSION-ID...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Clear a session.
+     {{.Prompt}} {{.HelpName}} 3f8a71c2
+
+  2. Clear all sessions.
+     {{.Prompt}} {{.HelpName}} --all
+`,
+}
+
+// sessionClearMessage container for a cleared session.
+type sessionClearMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+}
+
+// String colorized clear session message.
+func (s sessionClearMessage) String() string {
+	return console.Colorize("ClearSession", "Session `"+s.SessionID+"` cleared successfully.")
+}
+
+// JSON jsonified clear session message.
+func (s sessionClearMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// mainSessionClear - remove one or all sessions.
+func mainSessionClear(cliCtx *cli.Context) error {
+	isAll := cliCtx.Bool("all")
+	if isAll == cliCtx.Args().Present() {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+
+	console.SetColor("ClearSession", color.New(color.FgGreen, color.Bold))
+
+	sessionIDs := cliCtx.Args()
+	if isAll {
+		sessionIDs = getSessionIDs()
+	}
+	for _, sessionID := range sessionIDs {
+		if !isSessionExists(sessionID) {
+			fatalIf(errInvalidArgument().Trace(sessionID), "Session `%s` not found.", sessionID)
+		}
+		fatalIf(removeSessionFiles(sessionID), "Unable to clear session `%s`.", sessionID)
+		printMsg(sessionClearMessage{SessionID: sessionID})
+	}
+	return nil
+}
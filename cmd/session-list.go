@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var sessionListCmd = cli.Command{
+	Name:         "list",
+	ShortName:    "ls",
+	Usage:        "list all interrupted sessions",
+	Action:       mainSessionList,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all interrupted cp and mirror sessions.
+     {{.Prompt}} {{.HelpName}}
+`,
+}
+
+// sessionListMessage container for a session entry.
+type sessionListMessage struct {
+	Status       string    `json:"status"`
+	SessionID    string    `json:"sessionId"`
+	Time         time.Time `json:"time"`
+	CommandType  string    `json:"commandType"`
+	CommandArgs  []string  `json:"commandArgs"`
+	RootPath     string    `json:"workingFolder"`
+	Planned      bool      `json:"planned"`
+	TotalObjects int64     `json:"totalObjects"`
+	Completed    int       `json:"completed"`
+}
+
+// String colorized session entry.
+func (s sessionListMessage) String() string {
+	msg := console.Colorize("SessionID", s.SessionID) + " "
+	msg += console.Colorize("SessionTime", "["+s.Time.Local().Format(printDate)+"]") + " "
+	msg += console.Colorize("Command", s.CommandType+" "+strings.Join(s.CommandArgs, " ")) + " "
+	if s.Planned {
+		msg += fmt.Sprintf("(%d/%d)", s.Completed, s.TotalObjects)
+	} else {
+		msg += fmt.Sprintf("(%d, listing incomplete)", s.Completed)
+	}
+	return msg
+}
+
+// JSON jsonified session entry.
+func (s sessionListMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// mainSessionList - list all interrupted sessions.
+func mainSessionList(cliCtx *cli.Context) error {
+	if cliCtx.Args().Present() {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+
+	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SessionTime", color.New(color.FgGreen))
+	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
+
+	for _, sessionID := range getSessionIDs() {
+		s, err := loadSessionV8(sessionID)
+		if err != nil {
+			errorIf(err.Trace(sessionID), "Unable to load session `%s`.", sessionID)
+			continue
+		}
+		printMsg(sessionListMessage{
+			SessionID:    s.SessionID,
+			Time:         s.Header.When,
+			CommandType:  s.Header.CommandType,
+			CommandArgs:  s.Header.CommandArgs,
+			RootPath:     s.Header.RootPath,
+			Planned:      s.Header.Planned,
+			TotalObjects: s.Header.TotalObjects,
+			Completed:    s.CompletedCount(),
+		})
+		s.Close()
+	}
+	return nil
+}
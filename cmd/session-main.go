@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var sessionSubcommands = []cli.Command{
+	sessionListCmd,
+	sessionResumeCmd,
+	sessionClearCmd,
+}
+
+var sessionCmd = cli.Command{
+	Name:            "session",
+	Usage:           "resume interrupted cp and mirror sessions",
+	Action:          mainSession,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     sessionSubcommands,
+}
+
+// sessionSavedMessage is printed when an interrupted session is saved to disk.
+type sessionSavedMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	Completed int    `json:"completed"`
+}
+
+// String colorized session saved message.
+func (s sessionSavedMessage) String() string {
+	return console.Colorize("SessionSaved", fmt.Sprintf("Session safely terminated. To resume session, run `mc session resume %s`.", s.SessionID))
+}
+
+// JSON jsonified session saved message.
+func (s sessionSavedMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func mainSession(ctx *cli.Context) error {
+	commandNotFound(ctx, sessionSubcommands)
+	return nil
+}
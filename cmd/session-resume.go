@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"flag"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionResumeCmd = cli.Command{
+	Name:         "resume",
+	Usage:        "resume an interrupted session",
+	Action:       mainSessionResume,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+NOTE:
+  Encryption keys are never saved in a session, set them again
+  through the environment before resuming an encrypted session.
+
+EXAMPLES:
+  1. Resume an interrupted session.
+     {{.Prompt}} {{.HelpName}} 3f8a71c2
+`,
+}
+
+// mainSessionResume - re-run the command of an interrupted session, the
+// command finds its journal and skips the transfers already completed.
+func mainSessionResume(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+
+	sessionID := cliCtx.Args().First()
+	if !isSessionExists(sessionID) {
+		fatalIf(errInvalidArgument().Trace(sessionID), "Session `%s` not found.", sessionID)
+	}
+	s, err := loadSessionV8(sessionID)
+	fatalIf(err.Trace(sessionID), "Unable to load session `%s`.", sessionID)
+	fatalIf(s.Close().Trace(sessionID), "Unable to load session `%s`.", sessionID)
+
+	var cmd cli.Command
+	switch s.Header.CommandType {
+	case "cp":
+		cmd = cpCmd
+	case "mirror":
+		cmd = mirrorCmd
+	default:
+		fatalIf(errInvalidArgument().Trace(s.Header.CommandType), "Unsupported session command `%s`.", s.Header.CommandType)
+	}
+
+	// Relative paths in the arguments refer to the original working folder.
+	if e := os.Chdir(s.Header.RootPath); e != nil {
+		fatalIf(probe.NewError(e).Trace(s.Header.RootPath), "Unable to change to the session working folder.")
+	}
+
+	set := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	for _, f := range cmd.Flags {
+		f.Apply(set)
+	}
+	if e := set.Parse(append([]string{"--continue"}, s.Header.CommandArgs...)); e != nil {
+		fatalIf(probe.NewError(e).Trace(sessionID), "Unable to parse session `%s`.", sessionID)
+	}
+
+	cmdCtx := cli.NewContext(cliCtx.App, set, cliCtx)
+	cmdCtx.Command = cmd
+	if getSessionID(s.Header.CommandType, s.Header.RootPath, sessionArgsFromContext(cmdCtx)) != sessionID {
+		fatalIf(errInvalidArgument().Trace(sessionID), "Session `%s` does not match its command.", sessionID)
+	}
+	return cli.HandleAction(cmd.Action, cmdCtx)
+}
@@ -0,0 +1,458 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/quick"
+)
+
+// A session journals a long running cp or mirror on disk, so it can
+// be resumed after an interruption. Each session is made of three files
+// in the session folder:
+//
+//	<id>.json - header, the command line and the state of the listing.
+//	<id>.data - planned URLs, one JSON document per line.
+//	<id>.done - target of every completed transfer, one per line.
+const (
+	sessionHeaderExt = ".json"
+	sessionDataExt   = ".data"
+	sessionDoneExt   = ".done"
+)
+
+// sessionV8Header - persisted header of a resumable session.
+type sessionV8Header struct {
+	Version     string    `json:"version"`
+	When        time.Time `json:"time"`
+	RootPath    string    `json:"workingFolder"`
+	CommandType string    `json:"commandType"`
+	CommandArgs []string  `json:"cmdArgs"`
+	// Planned is set once the listing has completed, the
+	// data file then holds every URL of this session.
+	Planned      bool  `json:"planned"`
+	TotalBytes   int64 `json:"totalBytes"`
+	TotalObjects int64 `json:"totalObjects"`
+}
+
+// sessionV8 - resumable session container.
+type sessionV8 struct {
+	Header    *sessionV8Header
+	SessionID string
+
+	mutex     *sync.Mutex
+	dataFile  *os.File
+	dataBuf   *bufio.Writer
+	doneFile  *os.File
+	completed map[string]struct{}
+}
+
+// getSessionDir - return the full path of the session folder.
+func getSessionDir() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, globalSessionDir), nil
+}
+
+// mustGetSessionDir - return the full path of the session folder or die.
+func mustGetSessionDir() string {
+	sessionDir, err := getSessionDir()
+	fatalIf(err.Trace(), "Unable to determine session folder.")
+	return sessionDir
+}
+
+// createSessionDir - create the session folder if not present.
+func createSessionDir() *probe.Error {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return err.Trace()
+	}
+	if e := os.MkdirAll(sessionDir, 0o700); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// getSessionFile - return the path of a session file with the given extension.
+func getSessionFile(sessionID, ext string) string {
+	return filepath.Join(mustGetSessionDir(), sessionID+ext)
+}
+
+// isSessionExists - verify if a session exists on disk.
+func isSessionExists(sessionID string) bool {
+	_, e := os.Stat(getSessionFile(sessionID, sessionHeaderExt))
+	return e == nil
+}
+
+// getSessionIDs - list the IDs of all sessions on disk.
+func getSessionIDs() (sessionIDs []string) {
+	matches, e := filepath.Glob(filepath.Join(mustGetSessionDir(), "*"+sessionHeaderExt))
+	if e != nil {
+		return nil
+	}
+	for _, m := range matches {
+		sessionIDs = append(sessionIDs, strings.TrimSuffix(filepath.Base(m), sessionHeaderExt))
+	}
+	sort.Strings(sessionIDs)
+	return sessionIDs
+}
+
+// sessionArgsFromContext returns the command flags and arguments which
+// identify a session. Global flags only affect the presentation and are
+// ignored, encryption keys are never written to disk.
+func sessionArgsFromContext(cliCtx *cli.Context) (args []string) {
+	ignored := map[string]bool{"continue": true, "help": true}
+	for _, f := range append(globalFlags, encFlags...) {
+		ignored[strings.TrimSpace(strings.Split(f.GetName(), ",")[0])] = true
+	}
+
+	for _, f := range cliCtx.Command.Flags {
+		name := strings.TrimSpace(strings.Split(f.GetName(), ",")[0])
+		if ignored[name] || !cliCtx.IsSet(name) {
+			continue
+		}
+		switch f.(type) {
+		case cli.BoolFlag:
+			args = append(args, "--"+name)
+		case cli.StringFlag:
+			args = append(args, "--"+name+"="+cliCtx.String(name))
+		case cli.IntFlag:
+			args = append(args, fmt.Sprintf("--%s=%d", name, cliCtx.Int(name)))
+		case cli.DurationFlag:
+			args = append(args, "--"+name+"="+cliCtx.Duration(name).String())
+		case cli.StringSliceFlag:
+			for _, v := range cliCtx.StringSlice(name) {
+				args = append(args, "--"+name+"="+v)
+			}
+		}
+	}
+
+	// Terminate flag parsing, so arguments starting with '-' are kept as is.
+	args = append(args, "--")
+	return append(args, cliCtx.Args()...)
+}
+
+// getSessionID - derive a stable session ID from the command line and
+// the working folder, re-running the same command finds the same session.
+func getSessionID(cmdType, rootPath string, args []string) string {
+	h := sha256.New()
+	h.Write([]byte(cmdType + "\x00" + rootPath))
+	for _, arg := range args {
+		h.Write([]byte("\x00" + arg))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// newSessionV8 - create a new session, or open the previous one if the
+// same command was interrupted before in the current working folder.
+func newSessionV8(cmdType string, cliCtx *cli.Context) (*sessionV8, *probe.Error) {
+	if err := createSessionDir(); err != nil {
+		return nil, err.Trace()
+	}
+
+	rootPath, e := os.Getwd()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	args := sessionArgsFromContext(cliCtx)
+	sessionID := getSessionID(cmdType, rootPath, args)
+	if isSessionExists(sessionID) {
+		return loadSessionV8(sessionID)
+	}
+
+	s := &sessionV8{
+		Header: &sessionV8Header{
+			Version:     globalSessionConfigVersion,
+			When:        UTCNow(),
+			RootPath:    rootPath,
+			CommandType: cmdType,
+			CommandArgs: args,
+		},
+		SessionID: sessionID,
+		mutex:     &sync.Mutex{},
+		completed: make(map[string]struct{}),
+	}
+	if err := s.save(); err != nil {
+		return nil, err.Trace(sessionID)
+	}
+	if err := s.open(); err != nil {
+		return nil, err.Trace(sessionID)
+	}
+	return s, nil
+}
+
+// loadSessionV8 - load a session from disk.
+func loadSessionV8(sessionID string) (*sessionV8, *probe.Error) {
+	if !isSessionExists(sessionID) {
+		return nil, errInvalidArgument().Trace(sessionID)
+	}
+
+	qs, e := quick.NewConfig(&sessionV8Header{Version: globalSessionConfigVersion}, nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sessionID)
+	}
+	if e = qs.Load(getSessionFile(sessionID, sessionHeaderExt)); e != nil {
+		return nil, probe.NewError(e).Trace(sessionID)
+	}
+
+	s := &sessionV8{
+		Header:    qs.Data().(*sessionV8Header),
+		SessionID: sessionID,
+		mutex:     &sync.Mutex{},
+		completed: make(map[string]struct{}),
+	}
+	if s.Header.Version != globalSessionConfigVersion {
+		return nil, probe.NewError(fmt.Errorf("Unsupported session version `%s`", s.Header.Version)).Trace(sessionID)
+	}
+
+	// Load all transfers completed so far.
+	if f, e := os.Open(getSessionFile(sessionID, sessionDoneExt)); e == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64<<10), 1<<20)
+		for scanner.Scan() {
+			s.completed[scanner.Text()] = struct{}{}
+		}
+		f.Close()
+		if e = scanner.Err(); e != nil {
+			return nil, probe.NewError(e).Trace(sessionID)
+		}
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err.Trace(sessionID)
+	}
+	return s, nil
+}
+
+// open the data and done files of a session. When the previous listing
+// did not complete, the planned URLs are discarded and listed again.
+func (s *sessionV8) open() *probe.Error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if s.Header.Planned {
+		flags = os.O_CREATE | os.O_RDONLY
+	}
+	dataFile, e := os.OpenFile(getSessionFile(s.SessionID, sessionDataExt), flags, 0o600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	doneFile, e := os.OpenFile(getSessionFile(s.SessionID, sessionDoneExt), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if e != nil {
+		dataFile.Close()
+		return probe.NewError(e)
+	}
+	s.dataFile = dataFile
+	s.doneFile = doneFile
+	if !s.Header.Planned {
+		s.dataBuf = bufio.NewWriter(dataFile)
+	}
+	return nil
+}
+
+// save the session header.
+func (s *sessionV8) save() *probe.Error {
+	qs, e := quick.NewConfig(s.Header, nil)
+	if e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+	if e = qs.Save(getSessionFile(s.SessionID, sessionHeaderExt)); e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+	return nil
+}
+
+// sessionKey returns the key under which a transfer is journaled, each
+// target is only ever written or removed once in a session.
+func sessionKey(urls URLs) string {
+	if urls.TargetContent == nil {
+		return ""
+	}
+	return urls.TargetAlias + "\x00" + urls.TargetContent.URL.String()
+}
+
+// AddPlanned - journal a URL that is going to be processed.
+func (s *sessionV8) AddPlanned(urls URLs) *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.dataBuf == nil {
+		return nil
+	}
+	data, e := json.Marshal(urls)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = s.dataBuf.Write(append(data, '\n')); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// SetPlanned - mark the listing as complete, a resumed session
+// processes the journaled URLs instead of listing again.
+func (s *sessionV8) SetPlanned(totalObjects, totalBytes int64) *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.dataBuf != nil {
+		if e := s.dataBuf.Flush(); e != nil {
+			return probe.NewError(e)
+		}
+		if e := s.dataFile.Sync(); e != nil {
+			return probe.NewError(e)
+		}
+		s.dataBuf = nil
+	}
+	s.Header.Planned = true
+	s.Header.TotalObjects = totalObjects
+	s.Header.TotalBytes = totalBytes
+	return s.save()
+}
+
+// PlannedURLs - stream all the URLs journaled by a completed listing.
+func (s *sessionV8) PlannedURLs(ctx context.Context) <-chan URLs {
+	urlsCh := make(chan URLs)
+	go func() {
+		defer close(urlsCh)
+
+		f, e := os.Open(getSessionFile(s.SessionID, sessionDataExt))
+		if e != nil {
+			urlsCh <- URLs{Error: probe.NewError(e).Trace(s.SessionID)}
+			return
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64<<10), 16<<20)
+		for scanner.Scan() {
+			var urls URLs
+			if e = json.Unmarshal(scanner.Bytes(), &urls); e != nil {
+				urls = URLs{Error: probe.NewError(e).Trace(s.SessionID)}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case urlsCh <- urls:
+			}
+		}
+		if e = scanner.Err(); e != nil {
+			urlsCh <- URLs{Error: probe.NewError(e).Trace(s.SessionID)}
+		}
+	}()
+	return urlsCh
+}
+
+// SetCompleted - journal a successfully processed URL.
+func (s *sessionV8) SetCompleted(urls URLs) *probe.Error {
+	key := sessionKey(urls)
+	if key == "" {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.completed[key]; ok {
+		return nil
+	}
+	s.completed[key] = struct{}{}
+	if _, e := s.doneFile.WriteString(key + "\n"); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// IsCompleted - verify if a URL was processed in a previous run.
+func (s *sessionV8) IsCompleted(urls URLs) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.completed[sessionKey(urls)]
+	return ok
+}
+
+// CompletedCount - number of URLs processed so far.
+func (s *sessionV8) CompletedCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.completed)
+}
+
+// Close - flush and close the session files, the session stays
+// on disk to be resumed later.
+func (s *sessionV8) Close() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.dataBuf != nil {
+		if e := s.dataBuf.Flush(); e != nil {
+			return probe.NewError(e)
+		}
+	}
+	if e := s.dataFile.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	if e := s.doneFile.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// Delete - close and remove all the files of a session.
+func (s *sessionV8) Delete() *probe.Error {
+	if err := s.Close(); err != nil {
+		return err.Trace(s.SessionID)
+	}
+	return removeSessionFiles(s.SessionID)
+}
+
+// CloseAndPrint - close the session and tell how to resume it.
+func (s *sessionV8) CloseAndPrint() {
+	fatalIf(s.Close().Trace(s.SessionID), "Unable to save session.")
+	printMsg(sessionSavedMessage{
+		SessionID: s.SessionID,
+		Completed: s.CompletedCount(),
+	})
+}
+
+// removeSessionFiles - remove the files of a session from disk.
+func removeSessionFiles(sessionID string) *probe.Error {
+	// quick.Save keeps a backup of the previous header with an ".old" suffix.
+	for _, ext := range []string{sessionDataExt, sessionDoneExt, sessionHeaderExt, sessionHeaderExt + ".old"} {
+		if e := os.Remove(getSessionFile(sessionID, ext)); e != nil && !os.IsNotExist(e) {
+			return probe.NewError(e).Trace(sessionID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestGetSessionID(t *testing.T) {
+	args := []string{"--recursive", "--", "src/", "play/bucket/"}
+
+	id := getSessionID("cp", "/home/user", args)
+	if len(id) != 8 {
+		t.Fatalf("expected an 8 character session ID, got %q", id)
+	}
+	if id != getSessionID("cp", "/home/user", args) {
+		t.Fatal("expected the same command line to map to the same session")
+	}
+
+	testCases := []struct {
+		cmdType  string
+		rootPath string
+		args     []string
+	}{
+		{"mirror", "/home/user", args},
+		{"cp", "/home/other", args},
+		{"cp", "/home/user", []string{"--", "src/", "play/bucket/"}},
+		{"cp", "/home/user", []string{"--recursive", "--", "src/play/", "bucket/"}},
+	}
+	for i, testCase := range testCases {
+		if getSessionID(testCase.cmdType, testCase.rootPath, testCase.args) == id {
+			t.Errorf("Test %d: expected a different session ID", i+1)
+		}
+	}
+}
+
+func TestSessionKey(t *testing.T) {
+	if key := sessionKey(URLs{}); key != "" {
+		t.Fatalf("expected an empty key without a target, got %q", key)
+	}
+
+	urls := URLs{
+		TargetAlias:   "play",
+		TargetContent: &ClientContent{URL: *newClientURL("https://play.min.io/bucket/object")},
+	}
+	other := urls
+	other.TargetAlias = "s3"
+	if sessionKey(urls) == sessionKey(other) {
+		t.Fatal("expected different keys for different target aliases")
+	}
+}
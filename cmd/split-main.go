@@ -0,0 +1,284 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	gojson "encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+)
+
+var splitFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "size",
+		Value: "1GiB",
+		Usage: "size of the chunks, the last one may be smaller",
+	},
+}
+
+var splitCmd = cli.Command{
+	Name:         "split",
+	Usage:        "split a file or an object into numbered chunks",
+	Action:       mainSplit,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(splitFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET-PREFIX
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+DESCRIPTION:
+  The chunks are named TARGET-PREFIX followed by their number from '00001', and
+  TARGET-PREFIX followed by 'manifest.json' lists them with their size and
+  SHA-256 checksum. 'mc join' reassembles them.
+
+EXAMPLES:
+  01. Split a disk image into chunks of 1GiB.
+      {{.Prompt}} {{.HelpName}} ~/images/disk.img play/backups/disk.img.
+
+  02. Split an object into chunks of 100MiB in a folder of another site.
+      {{.Prompt}} {{.HelpName}} --size 100MiB play/datasets/census-2020.csv s3/partners/census-2020/
+`,
+}
+
+// splitManifestName - the name of the manifest of chunks after their prefix.
+const splitManifestName = "manifest.json"
+
+// splitManifest - the chunks of a file or an object.
+type splitManifest struct {
+	Version   int          `json:"version"`
+	Source    string       `json:"source"`
+	Size      int64        `json:"size"`
+	ChunkSize int64        `json:"chunkSize"`
+	Chunks    []splitChunk `json:"chunks"`
+}
+
+// splitChunk - a chunk, named after the prefix of the manifest. The ETag
+// of chunks uploaded to object storage allows a server side join.
+type splitChunk struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	ETag   string `json:"etag,omitempty"`
+}
+
+// splitChunkMessage - a chunk uploaded.
+type splitChunkMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// String colorized split chunk message
+func (m splitChunkMessage) String() string {
+	return console.Colorize("SplitChunk", fmt.Sprintf("`%s` (%s)", m.Target, humanize.IBytes(uint64(m.Size))))
+}
+
+// JSON jsonified split chunk message
+func (m splitChunkMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// splitMessage - a file or an object split.
+type splitMessage struct {
+	Status   string `json:"status"`
+	Source   string `json:"source"`
+	Manifest string `json:"manifest"`
+	Chunks   int    `json:"chunks"`
+	Size     int64  `json:"size"`
+}
+
+// String colorized split message
+func (m splitMessage) String() string {
+	return console.Colorize("Split", fmt.Sprintf("Split `%s` into %d chunks, %s, listed in `%s`.", m.Source, m.Chunks, humanize.IBytes(uint64(m.Size)), m.Manifest))
+}
+
+// JSON jsonified split message
+func (m splitMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// splitChunkName - the name of the chunk number n after its prefix.
+func splitChunkName(n int) string {
+	return fmt.Sprintf("%05d", n)
+}
+
+// splitStream - upload the content of r, of size bytes or -1 when it is
+// unknown, in chunks of chunkSize named after prefix.
+func splitStream(ctx context.Context, r io.Reader, size, chunkSize int64, prefix string, encKeyDB map[string][]prefixSSEPair) (splitManifest, *probe.Error) {
+	manifest := splitManifest{Version: 1, ChunkSize: chunkSize, Chunks: []splitChunk{}}
+	br := bufio.NewReader(r)
+	for {
+		if _, e := br.Peek(1); e == io.EOF {
+			break
+		} else if e != nil {
+			return manifest, probe.NewError(e)
+		}
+
+		length := int64(-1)
+		if size >= 0 {
+			length = min(chunkSize, size-manifest.Size)
+		}
+		name := splitChunkName(len(manifest.Chunks) + 1)
+		chunkURL := prefix + name
+		alias, urlStrFull, _, err := expandAlias(chunkURL)
+		if err != nil {
+			return manifest, err.Trace(chunkURL)
+		}
+		sse := getSSE(chunkURL, encKeyDB[alias])
+
+		sum := sha256.New()
+		lr := &io.LimitedReader{R: br, N: chunkSize}
+		_, err = putTargetStream(ctx, alias, urlStrFull, "", "", "", io.TeeReader(lr, sum), length, nil, PutOptions{
+			sse:      sse,
+			metadata: map[string]string{"Content-Type": "application/octet-stream"},
+			// Chunks of a known size are uploaded in a single part for
+			// the server to keep their full object SHA-256 checksum,
+			// which a join on the server compares with the manifest.
+			checksum:         minio.ChecksumSHA256,
+			disableMultipart: length >= 0 && length <= 5*humanize.GiByte,
+		})
+		if err != nil {
+			return manifest, err.Trace(chunkURL)
+		}
+		n := chunkSize - lr.N
+		if length >= 0 && n != length {
+			return manifest, probe.NewError(UnexpectedEOF{TotalSize: length, TotalWritten: n}).Trace(chunkURL)
+		}
+
+		chunk := splitChunk{Name: name, Size: n, SHA256: hex.EncodeToString(sum.Sum(nil))}
+		clnt, err := newClientFromAlias(alias, urlStrFull)
+		if err != nil {
+			return manifest, err.Trace(chunkURL)
+		}
+		if clnt.GetURL().Type == objectStorage {
+			content, err := clnt.Stat(ctx, StatOptions{sse: sse})
+			if err != nil {
+				return manifest, err.Trace(chunkURL)
+			}
+			chunk.ETag = content.ETag
+		}
+		printMsg(splitChunkMessage{Target: chunkURL, Size: chunk.Size, SHA256: chunk.SHA256})
+
+		manifest.Chunks = append(manifest.Chunks, chunk)
+		manifest.Size += n
+	}
+	if size >= 0 && manifest.Size != size {
+		return manifest, probe.NewError(UnexpectedEOF{TotalSize: size, TotalWritten: manifest.Size})
+	}
+	return manifest, nil
+}
+
+// putSplitManifest - upload the manifest of chunks named after prefix.
+func putSplitManifest(ctx context.Context, manifest splitManifest, prefix string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	data, e := gojson.MarshalIndent(manifest, "", "  ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	manifestURL := prefix + splitManifestName
+	alias, urlStrFull, _, err := expandAlias(manifestURL)
+	if err != nil {
+		return err.Trace(manifestURL)
+	}
+	_, err = putTargetStream(ctx, alias, urlStrFull, "", "", "", bytes.NewReader(data), int64(len(data)), nil, PutOptions{
+		sse:      getSSE(manifestURL, encKeyDB[alias]),
+		metadata: map[string]string{"Content-Type": "application/json"},
+	})
+	return err.Trace(manifestURL)
+}
+
+// checkSplitSyntax - validate all the passed arguments
+func checkSplitSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	size, e := humanize.ParseBytes(cliCtx.String("size"))
+	if e != nil || size == 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("size")), "Invalid chunk size `%s`.", cliCtx.String("size"))
+	}
+}
+
+// mainSplit is the handle for "mc split" command.
+func mainSplit(cliCtx *cli.Context) error {
+	checkSplitSyntax(cliCtx)
+	console.SetColor("SplitChunk", color.New(color.FgGreen))
+	console.SetColor("Split", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelSplit := context.WithCancel(globalContext)
+	defer cancelSplit()
+
+	// Chunks are uploaded with their SHA-256 checksum in a trailer.
+	useTrailingHeaders.Store(true)
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	sourceURL := cliCtx.Args().Get(0)
+	prefix := cliCtx.Args().Get(1)
+	chunkSize, _ := humanize.ParseBytes(cliCtx.String("size"))
+
+	alias, sourceURLFull, _, err := expandAlias(sourceURL)
+	fatalIf(err.Trace(sourceURL), "Unable to read `%s`.", sourceURL)
+	reader, content, err := getSourceStream(ctx, alias, sourceURLFull, getSourceOpts{
+		GetOptions: GetOptions{SSE: getSSE(sourceURL, encKeyDB[alias])},
+	})
+	fatalIf(err.Trace(sourceURL), "Unable to read `%s`.", sourceURL)
+	defer reader.Close()
+
+	manifest, err := splitStream(ctx, reader, content.Size, int64(chunkSize), prefix, encKeyDB)
+	fatalIf(err.Trace(sourceURL), "Unable to split `%s`.", sourceURL)
+	manifest.Source = sourceURL
+	fatalIf(putSplitManifest(ctx, manifest, prefix, encKeyDB), "Unable to upload the manifest of `%s`.", sourceURL)
+
+	printMsg(splitMessage{
+		Source:   sourceURL,
+		Manifest: prefix + splitManifestName,
+		Chunks:   len(manifest.Chunks),
+		Size:     manifest.Size,
+	})
+	return nil
+}
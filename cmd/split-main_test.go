@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestSplitJoin(t *testing.T) {
+	// Local paths without any alias.
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+
+	data := []byte(strings.Repeat("0123456789", 250))
+	for _, size := range []int64{int64(len(data)), -1} {
+		prefix := t.TempDir() + string(filepath.Separator) + "data."
+		manifest, err := splitStream(context.Background(), bytes.NewReader(data), size, 1000, prefix, nil)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if len(manifest.Chunks) != 3 || manifest.Size != int64(len(data)) {
+			t.Fatalf("size %d: expected 3 chunks of %d bytes, got %d of %d", size, len(data), len(manifest.Chunks), manifest.Size)
+		}
+		if chunk := manifest.Chunks[2]; chunk.Name != "00003" || chunk.Size != 500 {
+			t.Fatalf("size %d: unexpected last chunk %+v", size, chunk)
+		}
+		if err = putSplitManifest(context.Background(), manifest, prefix, nil); err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+
+		manifest, err = readSplitManifest(context.Background(), prefix, nil)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		var joined bytes.Buffer
+		if err = writeChunks(context.Background(), &joined, manifest, prefix, nil); err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !bytes.Equal(joined.Bytes(), data) {
+			t.Fatalf("size %d: joined content differs", size)
+		}
+
+		if e := os.WriteFile(prefix+"00002", bytes.Repeat([]byte("x"), 1000), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		if err = writeChunks(context.Background(), &bytes.Buffer{}, manifest, prefix, nil); err == nil {
+			t.Fatalf("size %d: expected a checksum mismatch", size)
+		}
+	}
+}
+
+func TestIsComposable(t *testing.T) {
+	chunk := splitChunk{Size: 5 * humanize.MiByte, ETag: "etag"}
+	last := splitChunk{Size: 1, ETag: "etag"}
+	testCases := []struct {
+		chunks     []splitChunk
+		composable bool
+	}{
+		{[]splitChunk{chunk, chunk, last}, true},
+		{[]splitChunk{last}, true},
+		{[]splitChunk{}, false},
+		// Chunks smaller than a part.
+		{[]splitChunk{last, chunk}, false},
+		// Chunks written to a file system.
+		{[]splitChunk{chunk, {Size: 1}}, false},
+	}
+	for i, testCase := range testCases {
+		manifest := splitManifest{Version: 1, Chunks: testCase.chunks}
+		for _, chunk := range testCase.chunks {
+			manifest.Size += chunk.Size
+		}
+		if composable := isComposable(manifest); composable != testCase.composable {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.composable, composable)
+		}
+	}
+}
+
+func TestChunkChecksumMatch(t *testing.T) {
+	// SHA-256 of "abc".
+	chunk := splitChunk{Size: 3, SHA256: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}
+	testCases := []struct {
+		size         int64
+		checksum     map[string]string
+		match, known bool
+	}{
+		{3, map[string]string{"SHA256": "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0="}, true, true},
+		{3, map[string]string{"SHA256": "LCa0a2j/xo/5m0U8HTBBNBNCLXBkg7+g+YpeiGJm564="}, false, true},
+		{4, map[string]string{"SHA256": "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0="}, false, true},
+		// Chunks uploaded in parts or without a SHA-256 checksum.
+		{3, map[string]string{"SHA256": "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=-2"}, false, false},
+		{3, map[string]string{"CRC32C": "NFKEEw=="}, false, false},
+		{3, nil, false, false},
+	}
+	for i, testCase := range testCases {
+		content := &ClientContent{Size: testCase.size, Checksum: testCase.checksum}
+		match, known := chunkChecksumMatch(content, chunk)
+		if match != testCase.match || known != testCase.known {
+			t.Errorf("Test %d: expected match %t and known %t, got %t and %t", i+1, testCase.match, testCase.known, match, known)
+		}
+	}
+}
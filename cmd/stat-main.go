@@ -65,7 +65,7 @@ var statCmd = cli.Command{
 	Action:       mainStat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(statFlags, encCFlag), globalFlags...),
+	Flags:        append(append(statFlags, encCFlag, encClientFlag, encClientAllowPlaintextFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -0,0 +1,351 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// syncCheckWorkers - the number of objects checked concurrently
+// before a plan is applied.
+const syncCheckWorkers = 16
+
+var syncApplyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "check the plan and print its actions without applying them",
+	},
+	cli.BoolFlag{
+		Name:  "disable-multipart",
+		Usage: "disable multipart upload feature",
+	},
+	cli.BoolFlag{
+		Name:  "summary",
+		Usage: "print a summary of the applied plan",
+	},
+	cli.BoolFlag{
+		Name:  "skip-errors",
+		Usage: "skip any errors when applying the plan",
+	},
+	cli.IntFlag{
+		Name:  "max-workers",
+		Usage: "maximum number of concurrent copies (default: autodetect)",
+	},
+	checksumFlag,
+	verifyFlag,
+}
+
+var syncApplyCmd = cli.Command{
+	Name:         "apply",
+	Usage:        "apply the actions of a plan",
+	Action:       mainSyncApply,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(syncApplyFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] PLAN
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+DESCRIPTION:
+  Before any action is applied, all the objects of the plan are compared with
+  their state when the plan was created. Nothing is applied when any of them
+  changed, the synchronization needs to be planned again.
+
+EXAMPLES:
+  01. Apply a plan written by 'mc sync plan'.
+      {{.Prompt}} {{.HelpName}} plan.json
+
+  02. Check that a plan can be applied, without applying it.
+      {{.Prompt}} {{.HelpName}} --dry-run plan.json
+
+  03. Apply a plan and verify every copy by reading it back.
+      {{.Prompt}} {{.HelpName}} --verify plan.json
+`,
+}
+
+// loadSyncPlan - read a plan written by sync plan.
+func loadSyncPlan(planFile string) (*syncPlan, *probe.Error) {
+	data, e := os.ReadFile(planFile)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(planFile)
+	}
+	var plan syncPlan
+	if e = json.Unmarshal(data, &plan); e != nil {
+		return nil, probe.NewError(e).Trace(planFile)
+	}
+	if plan.Version != syncPlanVersion {
+		return nil, probe.NewError(fmt.Errorf("unsupported plan version %d", plan.Version)).Trace(planFile)
+	}
+	for _, action := range plan.Actions {
+		switch action.Action {
+		case syncActionCopy, syncActionMetadata, syncActionMove, syncActionRemove:
+		default:
+			return nil, probe.NewError(fmt.Errorf("unknown action '%s' for `%s`", action.Action, action.Target)).Trace(planFile)
+		}
+	}
+	return &plan, nil
+}
+
+// changed - why the current state of the object of an action differs
+// from its planned state, empty if it is the same. ETags are compared
+// when both are known, the modification time otherwise.
+func (a syncAction) changed(content *ClientContent) string {
+	if content.Type.IsDir() {
+		return "it is a folder now"
+	}
+	if content.Size != a.Size {
+		return fmt.Sprintf("size is %d, planned %d", content.Size, a.Size)
+	}
+	if a.VersionID != "" && content.VersionID != "" && content.VersionID != a.VersionID {
+		return fmt.Sprintf("version is %s, planned %s", content.VersionID, a.VersionID)
+	}
+	if etag := strings.Trim(content.ETag, `"`); etag != "" && a.ETag != "" {
+		if etag != a.ETag {
+			return fmt.Sprintf("etag is %s, planned %s", etag, a.ETag)
+		}
+		return ""
+	}
+	if !content.Time.Equal(a.LastModified) {
+		return fmt.Sprintf("last modified %s, planned %s", content.Time, a.LastModified)
+	}
+	return ""
+}
+
+// moveFromChanged - why the target object a move copies from differs
+// from its planned state, empty if it is the same.
+func (a syncAction) moveFromChanged(content *ClientContent) string {
+	if content.Size != a.Size {
+		return fmt.Sprintf("size is %d, planned %d", content.Size, a.Size)
+	}
+	if a.MoveFromVersionID != "" && content.VersionID != "" && content.VersionID != a.MoveFromVersionID {
+		return fmt.Sprintf("version is %s, planned %s", content.VersionID, a.MoveFromVersionID)
+	}
+	if etag := strings.Trim(content.ETag, `"`); a.MoveFromETag != "" && etag != a.MoveFromETag {
+		return fmt.Sprintf("etag is %s, planned %s", etag, a.MoveFromETag)
+	}
+	return ""
+}
+
+// isSyncMissing - whether err is due to a missing object or file.
+func isSyncMissing(err *probe.Error) bool {
+	switch err.ToGoError().(type) {
+	case ObjectMissing, PathNotFound:
+		return true
+	}
+	return false
+}
+
+// checkSyncAction - compare the objects of an action with their planned
+// state, returns the URLs applying the action to the current objects.
+func checkSyncAction(ctx context.Context, action syncAction, encKeyDB map[string][]prefixSSEPair) (URLs, *probe.Error) {
+	stat := func(url string) (*ClientContent, *probe.Error) {
+		_, content, err := url2Stat(ctx, url2StatOptions{urlStr: url, encKeyDB: encKeyDB, headOnly: true})
+		return content, err
+	}
+	changedErr := func(url, why string) *probe.Error {
+		return probe.NewError(fmt.Errorf("`%s` changed since the plan was created, %s", url, why))
+	}
+
+	targetAlias, targetURL, _ := mustExpandAlias(action.Target)
+	if action.Action == syncActionRemove {
+		if action.Source != "" {
+			_, err := stat(action.Source)
+			if err == nil {
+				return URLs{}, changedErr(action.Source, "it exists now")
+			}
+			if !isSyncMissing(err) {
+				return URLs{}, err.Trace(action.Source)
+			}
+		}
+		content, err := stat(action.Target)
+		if err != nil {
+			if isSyncMissing(err) {
+				return URLs{}, changedErr(action.Target, "it does not exist anymore")
+			}
+			return URLs{}, err.Trace(action.Target)
+		}
+		if why := action.changed(content); why != "" {
+			return URLs{}, changedErr(action.Target, why)
+		}
+		// Not removed if it changes again before it is removed.
+		return URLs{TargetAlias: targetAlias, TargetContent: content, matchETag: strings.Trim(content.ETag, `"`)}, nil
+	}
+
+	content, err := stat(action.Source)
+	if err != nil {
+		if isSyncMissing(err) {
+			return URLs{}, changedErr(action.Source, "it does not exist anymore")
+		}
+		return URLs{}, err.Trace(action.Source)
+	}
+	if why := action.changed(content); why != "" {
+		return URLs{}, changedErr(action.Source, why)
+	}
+	sourceAlias, _ := url2Alias(action.Source)
+	// The copy reads the version checked, or fails if the source changes
+	// again before it is read.
+	urls := URLs{
+		SourceAlias:   sourceAlias,
+		SourceContent: content,
+		TargetAlias:   targetAlias,
+		TargetContent: &ClientContent{URL: *newClientURL(targetURL)},
+		matchETag:     strings.Trim(content.ETag, `"`),
+	}
+	if action.Action == syncActionMove {
+		moveFrom, err := stat(action.MoveFrom)
+		if err != nil {
+			if isSyncMissing(err) {
+				return URLs{}, changedErr(action.MoveFrom, "it does not exist anymore")
+			}
+			return URLs{}, err.Trace(action.MoveFrom)
+		}
+		if why := action.moveFromChanged(moveFrom); why != "" {
+			return URLs{}, changedErr(action.MoveFrom, why)
+		}
+		urls.MoveFrom = moveFrom
+	}
+	return urls, nil
+}
+
+// checkSyncPlan - check all the actions of a plan concurrently, fails on
+// the first object changed since the plan was created. Returns the URLs
+// of the actions in the order of the plan.
+func checkSyncPlan(ctx context.Context, plan *syncPlan, encKeyDB map[string][]prefixSSEPair) ([]URLs, *probe.Error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	planURLs := make([]URLs, len(plan.Actions))
+	indexCh := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr *probe.Error
+	)
+	for i := 0; i < syncCheckWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexCh {
+				urls, err := checkSyncAction(ctx, plan.Actions[index], encKeyDB)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				planURLs[index] = urls
+			}
+		}()
+	}
+	for index := range plan.Actions {
+		if ctx.Err() != nil {
+			break
+		}
+		indexCh <- index
+	}
+	close(indexCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if e := ctx.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return planURLs, nil
+}
+
+// mainSyncApply is the handle for "mc sync apply" command.
+func mainSyncApply(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+	}
+	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelApply := context.WithCancel(globalContext)
+	defer cancelApply()
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	planFile := cliCtx.Args().Get(0)
+	plan, err := loadSyncPlan(planFile)
+	fatalIf(err, "Unable to read the plan `%s`.", planFile)
+
+	// Nothing is applied unless all objects are as planned.
+	planURLs, err := checkSyncPlan(ctx, plan, encKeyDB)
+	fatalIf(err, "Unable to apply the plan `%s`.", planFile)
+
+	md5, checksum := parseChecksum(cliCtx)
+	mj := newMirrorJob(plan.Source, plan.Target, mirrorOptions{
+		isFake:           cliCtx.Bool("dry-run"),
+		isRemove:         plan.Options.Remove,
+		isOverwrite:      plan.Options.Overwrite,
+		isMetadata:       plan.Options.Preserve,
+		isSummary:        cliCtx.Bool("summary"),
+		md5:              md5,
+		checksum:         checksum,
+		disableMultipart: cliCtx.Bool("disable-multipart"),
+		skipErrors:       cliCtx.Bool("skip-errors"),
+		storageClass:     plan.Options.StorageClass,
+		userMetadata:     plan.Options.Attr,
+		encKeyDB:         encKeyDB,
+		maxWorkers:       cliCtx.Int("max-workers"),
+		verify:           cliCtx.Bool("verify"),
+	})
+
+	URLsCh := make(chan URLs)
+	go func() {
+		defer close(URLsCh)
+		for _, urls := range planURLs {
+			select {
+			case URLsCh <- urls:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	mj.planned = URLsCh
+
+	if errorDetected := mj.mirror(ctx); errorDetected {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
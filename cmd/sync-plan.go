@@ -0,0 +1,387 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var syncPlanFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "output, o",
+		Usage: "write the plan to a file instead of the standard output",
+	},
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "plan to overwrite object(s) on target if they differ from source",
+	},
+	cli.BoolFlag{
+		Name:  "remove",
+		Usage: "plan to remove extraneous object(s) on target",
+	},
+	cli.BoolFlag{
+		Name:  "preserve, a",
+		Usage: "preserve file(s)/object(s) attributes on target",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "exclude object(s) that match specified object name pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude-storageclass",
+		Usage: "exclude object(s) that match the specified storage class",
+	},
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "filter object(s) older than value in duration string (e.g. 7d10h31s)",
+	},
+	cli.StringFlag{
+		Name:  "newer-than",
+		Usage: "filter object(s) newer than value in duration string (e.g. 7d10h31s)",
+	},
+	cli.StringFlag{
+		Name:  "storage-class, sc",
+		Usage: "specify storage class for new object(s) on target",
+	},
+	cli.StringFlag{
+		Name:  "attr",
+		Usage: "add custom metadata for all objects",
+	},
+	cli.BoolFlag{
+		Name:  "compare-checksum",
+		Usage: "compare objects of the same size by checksum, local files are hashed",
+	},
+	cli.BoolFlag{
+		Name:  "detect-renames",
+		Usage: "plan to copy objects renamed on the source from their former location on the target",
+	},
+	filterFromFlag,
+	renameFlag,
+}
+
+var syncPlanCmd = cli.Command{
+	Name:         "plan",
+	Usage:        "write the actions synchronizing a target with a source to a plan",
+	Action:       mainSyncPlan,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(syncPlanFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
+  MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+
+EXAMPLES:
+  01. Plan the synchronization of a bucket with a bucket on another site, review and apply it later.
+      {{.Prompt}} {{.HelpName}} play/photos s3/backup-photos -o plan.json
+
+  02. Plan to overwrite changed objects and remove extraneous objects, print the plan.
+      {{.Prompt}} {{.HelpName}} --overwrite --remove backup/ s3/archive
+
+  03. Plan the synchronization of the objects modified in the last 7 days only.
+      {{.Prompt}} {{.HelpName}} --newer-than 7d play/photos s3/backup-photos -o plan.json
+`,
+}
+
+// syncPlanVersion - the version of the format of a plan.
+const syncPlanVersion = 1
+
+// Actions of a plan.
+const (
+	syncActionCopy     = "copy"
+	syncActionMetadata = "metadata"
+	syncActionMove     = "move"
+	syncActionRemove   = "remove"
+)
+
+// syncPlan - the actions synchronizing a target with a source, as the
+// differences of both when the plan was created.
+type syncPlan struct {
+	Version int             `json:"version"`
+	Created time.Time       `json:"created"`
+	Source  string          `json:"source"`
+	Target  string          `json:"target"`
+	Options syncPlanOptions `json:"options"`
+	Summary syncPlanSummary `json:"summary"`
+	Actions []syncAction    `json:"actions"`
+}
+
+// syncPlanOptions - the options of the plan applied to its copies and removals.
+type syncPlanOptions struct {
+	Overwrite    bool              `json:"overwrite,omitempty"`
+	Remove       bool              `json:"remove,omitempty"`
+	Preserve     bool              `json:"preserve,omitempty"`
+	StorageClass string            `json:"storageClass,omitempty"`
+	Attr         map[string]string `json:"attr,omitempty"`
+}
+
+// syncPlanSummary - the number of actions of a plan and the bytes to copy.
+type syncPlanSummary struct {
+	Copies   int64 `json:"copies"`
+	Moves    int64 `json:"moves"`
+	Removals int64 `json:"removals"`
+	Bytes    int64 `json:"bytes"`
+	// Skipped are the objects which differ but are not overwritten without --overwrite.
+	Skipped int64 `json:"skipped,omitempty"`
+	// Uncompared are the objects of the same size whose content could not
+	// be compared with --compare-checksum, they are considered equal.
+	Uncompared int64 `json:"uncompared,omitempty"`
+}
+
+// syncAction - an action of a plan with the state of the object it was
+// planned for, the source object for copies and moves and the target
+// object for removals. Moves also have the state of the target object
+// they copy from.
+type syncAction struct {
+	Action            string    `json:"action"`
+	Reason            string    `json:"reason,omitempty"`
+	Source            string    `json:"source,omitempty"`
+	Target            string    `json:"target"`
+	MoveFrom          string    `json:"moveFrom,omitempty"`
+	Size              int64     `json:"size"`
+	ETag              string    `json:"etag,omitempty"`
+	VersionID         string    `json:"versionId,omitempty"`
+	LastModified      time.Time `json:"lastModified"`
+	MoveFromETag      string    `json:"moveFromEtag,omitempty"`
+	MoveFromVersionID string    `json:"moveFromVersionId,omitempty"`
+}
+
+// syncPlanMessage container for a plan written to a file.
+type syncPlanMessage struct {
+	Status string `json:"status"`
+	Plan   string `json:"plan"`
+	syncPlanSummary
+}
+
+// String colorized plan message.
+func (m syncPlanMessage) String() string {
+	msg := fmt.Sprintf("Planned %d copies, %d moves and %d removals of %s in `%s`.",
+		m.Copies, m.Moves, m.Removals, humanize.IBytes(uint64(m.Bytes)), m.Plan)
+	if m.Skipped > 0 {
+		msg += fmt.Sprintf(" Skipped %d objects which differ, use '--overwrite' to overwrite them.", m.Skipped)
+	}
+	if m.Uncompared > 0 {
+		msg += fmt.Sprintf(" Unable to compare the content of %d objects without a comparable checksum, they are considered equal.", m.Uncompared)
+	}
+	return console.Colorize("SyncPlan", msg)
+}
+
+// JSON jsonified plan message.
+func (m syncPlanMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// syncPath - the path of an object as shown to the user, with its alias.
+func syncPath(alias string, u ClientURL) string {
+	return filepath.ToSlash(filepath.Join(alias, u.Path))
+}
+
+// add - add the action of the URLs of a difference to the plan.
+func (p *syncPlan) add(urls URLs, sourceRoot, targetRoot string, renamed bool) {
+	if urls.SourceContent == nil {
+		target := urls.TargetContent
+		action := syncAction{
+			Action:       syncActionRemove,
+			Reason:       urls.diff.String(),
+			Target:       syncPath(urls.TargetAlias, target.URL),
+			Size:         target.Size,
+			ETag:         strings.Trim(target.ETag, `"`),
+			VersionID:    target.VersionID,
+			LastModified: target.Time,
+		}
+		// Without renamed keys the removal is undone by a source object
+		// of the same name, which apply checks for.
+		if suffix, ok := strings.CutPrefix(action.Target, targetRoot+"/"); ok && !renamed {
+			action.Source = sourceRoot + "/" + suffix
+		}
+		p.Actions = append(p.Actions, action)
+		p.Summary.Removals++
+		return
+	}
+
+	source := urls.SourceContent
+	action := syncAction{
+		Action:       syncActionCopy,
+		Reason:       urls.diff.String(),
+		Source:       syncPath(urls.SourceAlias, source.URL),
+		Target:       syncPath(urls.TargetAlias, urls.TargetContent.URL),
+		Size:         source.Size,
+		ETag:         strings.Trim(source.ETag, `"`),
+		VersionID:    source.VersionID,
+		LastModified: source.Time,
+	}
+	switch {
+	case urls.MoveFrom != nil:
+		action.Action = syncActionMove
+		action.MoveFrom = syncPath(urls.TargetAlias, urls.MoveFrom.URL)
+		action.MoveFromETag = strings.Trim(urls.MoveFrom.ETag, `"`)
+		action.MoveFromVersionID = urls.MoveFrom.VersionID
+		p.Summary.Moves++
+	case urls.diff == differInMetadata:
+		action.Action = syncActionMetadata
+		p.Summary.Copies++
+		p.Summary.Bytes += source.Size
+	default:
+		p.Summary.Copies++
+		p.Summary.Bytes += source.Size
+	}
+	p.Actions = append(p.Actions, action)
+}
+
+// newSyncPlan - compute the differences of source and target as mirror
+// does and plan the actions to synchronize them. Fails on any error,
+// a partial plan would remove objects or leave differences behind.
+func newSyncPlan(ctx context.Context, srcURL, tgtURL string, opts mirrorOptions) (*syncPlan, *probe.Error) {
+	plan := &syncPlan{
+		Version: syncPlanVersion,
+		Created: UTCNow(),
+		Source:  srcURL,
+		Target:  tgtURL,
+		Options: syncPlanOptions{
+			Overwrite:    opts.isOverwrite,
+			Remove:       opts.isRemove,
+			Preserve:     opts.isMetadata,
+			StorageClass: opts.storageClass,
+			Attr:         opts.userMetadata,
+		},
+		Actions: []syncAction{},
+	}
+
+	srcAlias, srcExpanded, _ := mustExpandAlias(srcURL)
+	tgtAlias, tgtExpanded, _ := mustExpandAlias(tgtURL)
+	sourceRoot := syncPath(srcAlias, *newClientURL(srcExpanded))
+	targetRoot := syncPath(tgtAlias, *newClientURL(tgtExpanded))
+
+	if opts.compareChecksum {
+		opts.uncompared = &compareStats{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for urls := range prepareMirrorURLs(ctx, srcURL, tgtURL, opts) {
+		if urls.Error != nil {
+			if _, ok := urls.Error.ToGoError().(overwriteNotAllowedErr); ok {
+				plan.Summary.Skipped++
+				continue
+			}
+			return nil, urls.Error.Trace(srcURL, tgtURL)
+		}
+		if urls.SourceContent != nil {
+			if isOlder(urls.SourceContent.Time, opts.olderThan) || isNewer(urls.SourceContent.Time, opts.newerThan) {
+				continue
+			}
+		}
+		plan.add(urls, sourceRoot, targetRoot, opts.rename != nil)
+	}
+	plan.Summary.Uncompared = int64(opts.uncompared.count())
+	return plan, nil
+}
+
+// checkSyncPlanSyntax - validate all the passed arguments
+func checkSyncPlanSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) (srcURL, tgtURL string) {
+	srcURL, tgtURL = checkMirrorSyntax(ctx, cliCtx, encKeyDB)
+	for _, url := range []string{srcURL, tgtURL} {
+		_, expanded, _ := mustExpandAlias(url)
+		if clientURL := newClientURL(expanded); clientURL.Type == objectStorage && strings.Trim(clientURL.Path, "/") == "" {
+			fatalIf(errInvalidArgument().Trace(url), "Unable to plan the synchronization of all buckets of `%s`, please add a bucket name.", url)
+		}
+	}
+	return srcURL, tgtURL
+}
+
+// mainSyncPlan is the handle for "mc sync plan" command.
+func mainSyncPlan(cliCtx *cli.Context) error {
+	ctx, cancelPlan := context.WithCancel(globalContext)
+	defer cancelPlan()
+
+	console.SetColor("SyncPlan", color.New(color.FgGreen, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	srcURL, tgtURL := checkSyncPlanSyntax(ctx, cliCtx, encKeyDB)
+
+	userMetadata := make(map[string]string)
+	if cliCtx.String("attr") != "" {
+		userMetadata, err = getMetaDataEntry(cliCtx.String("attr"))
+		fatalIf(err, "Unable to parse attribute %v", cliCtx.String("attr"))
+	}
+
+	opts := mirrorOptions{
+		isOverwrite:           cliCtx.Bool("overwrite"),
+		isRemove:              cliCtx.Bool("remove"),
+		isMetadata:            cliCtx.Bool("a") || len(userMetadata) > 0,
+		excludeOptions:        cliCtx.StringSlice("exclude"),
+		excludeStorageClasses: cliCtx.StringSlice("exclude-storageclass"),
+		olderThan:             cliCtx.String("older-than"),
+		newerThan:             cliCtx.String("newer-than"),
+		storageClass:          cliCtx.String("storage-class"),
+		userMetadata:          userMetadata,
+		encKeyDB:              encKeyDB,
+		compareChecksum:       cliCtx.Bool("compare-checksum"),
+		detectRenames:         cliCtx.Bool("detect-renames"),
+		filter:                parseFilterFromFlag(cliCtx),
+		rename:                parseRenameFlag(cliCtx),
+	}
+	// Objects only on the target matter for removals and renames only.
+	opts.sourceListingOnly = !opts.isRemove && !opts.detectRenames
+
+	plan, err := newSyncPlan(ctx, srcURL, tgtURL, opts)
+	fatalIf(err, "Unable to plan the synchronization of `%s` with `%s`.", srcURL, tgtURL)
+
+	planBytes, e := json.MarshalIndent(plan, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	output := cliCtx.String("output")
+	if output == "" {
+		_, e = os.Stdout.Write(append(planBytes, '\n'))
+		fatalIf(probe.NewError(e), "Unable to write the plan.")
+		return nil
+	}
+	e = os.WriteFile(output, append(planBytes, '\n'), 0o644)
+	fatalIf(probe.NewError(e).Trace(output), "Unable to write the plan to `%s`.", output)
+
+	printMsg(syncPlanMessage{
+		Plan:            output,
+		syncPlanSummary: plan.Summary,
+	})
+	return nil
+}
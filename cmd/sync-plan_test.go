@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSyncPlanAdd(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	content := func(path string, size int64, etag string) *ClientContent {
+		return &ClientContent{URL: *newClientURL("https://s3.example.com" + path), Size: size, ETag: etag, Time: at}
+	}
+
+	var plan syncPlan
+	plan.add(URLs{
+		SourceAlias: "src", SourceContent: content("/bucket/a.txt", 10, `"etag-a"`),
+		TargetAlias: "dst", TargetContent: content("/backup/a.txt", 0, ""),
+		diff: differInFirst,
+	}, "src/bucket", "dst/backup", false)
+	plan.add(URLs{
+		SourceAlias: "src", SourceContent: content("/bucket/b.txt", 20, "etag-b"),
+		TargetAlias: "dst", TargetContent: content("/backup/b.txt", 0, ""),
+		diff: differInMetadata,
+	}, "src/bucket", "dst/backup", false)
+	plan.add(URLs{
+		SourceAlias: "src", SourceContent: content("/bucket/c.txt", 30, "etag-c"),
+		TargetAlias: "dst", TargetContent: content("/backup/c.txt", 0, ""),
+		MoveFrom: content("/backup/old-c.txt", 30, "etag-c"),
+		diff:     differInFirst,
+	}, "src/bucket", "dst/backup", false)
+	plan.add(URLs{
+		TargetAlias: "dst", TargetContent: content("/backup/dir/d.txt", 40, "etag-d"),
+		diff: differInSecond,
+	}, "src/bucket", "dst/backup", false)
+	plan.add(URLs{
+		TargetAlias: "dst", TargetContent: content("/backup/e.txt", 50, "etag-e"),
+		diff: differInSecond,
+	}, "src/bucket", "dst/backup", true)
+
+	expected := []syncAction{
+		{Action: syncActionCopy, Reason: "only-in-first", Source: "src/bucket/a.txt", Target: "dst/backup/a.txt", Size: 10, ETag: "etag-a", LastModified: at},
+		{Action: syncActionMetadata, Reason: "metadata", Source: "src/bucket/b.txt", Target: "dst/backup/b.txt", Size: 20, ETag: "etag-b", LastModified: at},
+		{Action: syncActionMove, Reason: "only-in-first", Source: "src/bucket/c.txt", Target: "dst/backup/c.txt", MoveFrom: "dst/backup/old-c.txt", Size: 30, ETag: "etag-c", LastModified: at, MoveFromETag: "etag-c"},
+		{Action: syncActionRemove, Reason: "only-in-second", Source: "src/bucket/dir/d.txt", Target: "dst/backup/dir/d.txt", Size: 40, ETag: "etag-d", LastModified: at},
+		{Action: syncActionRemove, Reason: "only-in-second", Target: "dst/backup/e.txt", Size: 50, ETag: "etag-e", LastModified: at},
+	}
+	if !reflect.DeepEqual(plan.Actions, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, plan.Actions)
+	}
+	if summary := (syncPlanSummary{Copies: 2, Moves: 1, Removals: 2, Bytes: 30}); plan.Summary != summary {
+		t.Fatalf("expected summary %+v, got %+v", summary, plan.Summary)
+	}
+}
+
+func TestSyncActionChanged(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		action  syncAction
+		content ClientContent
+		changed bool
+	}{
+		{action: syncAction{Size: 10, ETag: "etag", LastModified: at}, content: ClientContent{Size: 10, ETag: `"etag"`, Time: at.Add(time.Hour)}},
+		{action: syncAction{Size: 10, ETag: "etag", LastModified: at}, content: ClientContent{Size: 10, ETag: "other", Time: at}, changed: true},
+		{action: syncAction{Size: 10, ETag: "etag", LastModified: at}, content: ClientContent{Size: 11, ETag: "etag", Time: at}, changed: true},
+		{action: syncAction{Size: 10, LastModified: at}, content: ClientContent{Size: 10, Time: at}},
+		{action: syncAction{Size: 10, LastModified: at}, content: ClientContent{Size: 10, Time: at.Add(time.Second)}, changed: true},
+		{action: syncAction{Size: 0, LastModified: at}, content: ClientContent{Type: os.ModeDir, Time: at}, changed: true},
+		{action: syncAction{Size: 10, ETag: "etag", VersionID: "v1", LastModified: at}, content: ClientContent{Size: 10, ETag: "etag", VersionID: "v2", Time: at}, changed: true},
+		{action: syncAction{Size: 10, ETag: "etag", LastModified: at}, content: ClientContent{Size: 10, ETag: "etag", VersionID: "v2", Time: at}},
+	}
+
+	for i, testCase := range testCases {
+		if why := testCase.action.changed(&testCase.content); (why != "") != testCase.changed {
+			t.Fatalf("Test %d: expected changed %v, got %q", i+1, testCase.changed, why)
+		}
+	}
+}
+
+func TestSyncActionMoveFromChanged(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	action := syncAction{Action: syncActionMove, Size: 10, ETag: "etag", MoveFromETag: "etag-old", MoveFromVersionID: "v1"}
+	testCases := []struct {
+		content ClientContent
+		changed bool
+	}{
+		{ClientContent{Size: 10, ETag: `"etag-old"`, VersionID: "v1", Time: at}, false},
+		{ClientContent{Size: 10, ETag: "etag-old", Time: at.Add(time.Hour)}, false},
+		{ClientContent{Size: 10, ETag: "etag-new", VersionID: "v1", Time: at}, true},
+		{ClientContent{Size: 10, ETag: "etag-old", VersionID: "v2", Time: at}, true},
+		{ClientContent{Size: 11, ETag: "etag-old", VersionID: "v1", Time: at}, true},
+	}
+
+	for i, testCase := range testCases {
+		if why := action.moveFromChanged(&testCase.content); (why != "") != testCase.changed {
+			t.Fatalf("Test %d: expected changed %v, got %q", i+1, testCase.changed, why)
+		}
+	}
+}
+
+func TestLoadSyncPlan(t *testing.T) {
+	testCases := []struct {
+		plan string
+		err  bool
+	}{
+		{plan: `{"version": 1, "source": "src/bucket", "target": "dst/bucket", "actions": [{"action": "copy", "source": "src/bucket/a", "target": "dst/bucket/a"}]}`},
+		{plan: `{"version": 2, "actions": []}`, err: true},
+		{plan: `{"version": 1, "actions": [{"action": "rename", "target": "dst/bucket/a"}]}`, err: true},
+		{plan: `{"version": 1,`, err: true},
+	}
+
+	dir := t.TempDir()
+	for i, testCase := range testCases {
+		planFile := filepath.Join(dir, "plan.json")
+		if e := os.WriteFile(planFile, []byte(testCase.plan), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		_, err := loadSyncPlan(planFile)
+		if testCase.err != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+	}
+}
@@ -133,13 +133,15 @@ func parseRemoveTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRe
 }
 
 // Delete tags of a bucket or a specified object/version
-func deleteTags(ctx context.Context, clnt Client, versionID string) {
+func deleteTags(ctx context.Context, alias string, clnt Client, versionID string) {
 	targetName := clnt.GetURL().String()
 	if versionID != "" {
 		targetName += " (" + versionID + ")"
 	}
 
-	err := clnt.DeleteTags(ctx, versionID)
+	err := aliasRetryPolicy(alias).do(ctx, nil, nil, func() *probe.Error {
+		return clnt.DeleteTags(ctx, versionID)
+	})
 	if err != nil {
 		fatalIf(err, "Unable to remove tags for "+targetName)
 		return
@@ -158,7 +160,7 @@ func deleteTagsSingle(ctx context.Context, alias, url, versionID string) *probe.
 		return err
 	}
 
-	deleteTags(ctx, newClnt, versionID)
+	deleteTags(ctx, alias, newClnt, versionID)
 	return nil
 }
 
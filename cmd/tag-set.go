@@ -145,13 +145,15 @@ func parseSetTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef t
 }
 
 // Set tags to a bucket or to a specified object/version
-func setTags(ctx context.Context, clnt Client, versionID, tags string) {
+func setTags(ctx context.Context, alias string, clnt Client, versionID, tags string) {
 	targetName := clnt.GetURL().String()
 	if versionID != "" {
 		targetName += " (" + versionID + ")"
 	}
 
-	err := clnt.SetTags(ctx, versionID, tags)
+	err := aliasRetryPolicy(alias).do(ctx, nil, nil, func() *probe.Error {
+		return clnt.SetTags(ctx, versionID, tags)
+	})
 	if err != nil {
 		fatalIf(err.Trace(tags), "Failed to set tags for "+targetName)
 		return
@@ -169,7 +171,7 @@ func setTagsSingle(ctx context.Context, alias, url, versionID, tags string) *pro
 		return err
 	}
 
-	setTags(ctx, newClnt, versionID, tags)
+	setTags(ctx, alias, newClnt, versionID, tags)
 	return nil
 }
 
@@ -32,8 +32,15 @@ type URLs struct {
 	TotalSize        int64
 	MD5              bool
 	DisableMultipart bool
+	MoveFrom         *ClientContent // same content on the target, copied instead of uploaded
+	matchETag        string         // ETag the source of a copy or the target of a removal must still have, empty for any
 	checksum         minio.ChecksumType
+	compress         string // codec compressing the uploaded object, empty when not compressed
 	encKeyDB         map[string][]prefixSSEPair
+	targetIndex      int          // target of a fan-out command, 0 is the first target
+	fanOut           []URLs       // the same source object to copy to the other targets
+	verify           verifyStatus // outcome of the verification of the copy with --verify
+	diff             differType   // difference of source and target causing the copy or removal
 	Error            *probe.Error `json:"-"`
 	ErrorCond        differType   `json:"-"`
 }
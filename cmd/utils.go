@@ -31,6 +31,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mattn/go-ieproxy"
@@ -38,6 +39,7 @@ import (
 	"github.com/minio/minio-go/v7"
 
 	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/mc/pkg/limiter"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -139,8 +141,8 @@ func NewS3Config(alias, urlStr string, aliasCfg *aliasConfigV10) *Config {
 	s3Config.Insecure = globalInsecure
 	s3Config.ConnReadDeadline = globalConnReadDeadline
 	s3Config.ConnWriteDeadline = globalConnWriteDeadline
-	s3Config.UploadLimit = int64(globalLimitUpload)
-	s3Config.DownloadLimit = int64(globalLimitDownload)
+	s3Config.UploadLimit = globalLimitUpload
+	s3Config.DownloadLimit = globalLimitDownload
 
 	s3Config.HostURL = urlStr
 	s3Config.Alias = alias
@@ -151,9 +153,43 @@ func NewS3Config(alias, urlStr string, aliasCfg *aliasConfigV10) *Config {
 		s3Config.Signature = aliasCfg.API
 		s3Config.Lookup = getLookupType(aliasCfg.Path)
 	}
+	s3Config.RequestLimit = aliasRequestLimit(alias, aliasCfg)
 	return s3Config
 }
 
+var (
+	requestLimitsMu sync.Mutex
+	requestLimits   = map[string]*limiter.Requests{}
+)
+
+// aliasRequestLimit - the limit of the requests to an alias, set with
+// --limit-requests or else in the config of the alias. All the clients
+// of an alias share its limit.
+func aliasRequestLimit(alias string, aliasCfg *aliasConfigV10) *limiter.Requests {
+	var rates limiter.RequestRates
+	switch {
+	case globalLimitRequests != nil:
+		rates = *globalLimitRequests
+	case aliasCfg != nil && aliasCfg.RequestLimit != "":
+		var e error
+		rates, e = limiter.ParseRequestRates(aliasCfg.RequestLimit)
+		fatalIf(probe.NewError(e).Trace(alias), "Invalid request limit of alias `"+alias+"`.")
+	}
+	if rates.IsUnlimited() {
+		return nil
+	}
+
+	requestLimitsMu.Lock()
+	defer requestLimitsMu.Unlock()
+	key := alias + "=" + rates.String()
+	if requests, ok := requestLimits[key]; ok {
+		return requests
+	}
+	requests := limiter.NewRequests(rates)
+	requestLimits[key] = requests
+	return requests
+}
+
 // lineTrunc - truncates a string to the given maximum length by
 // adding ellipsis in the middle
 func lineTrunc(content string, maxLen int) string {
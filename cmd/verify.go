@@ -0,0 +1,225 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/v3/console"
+)
+
+// verifyStatus - outcome of the verification of a copy with --verify.
+type verifyStatus int
+
+const (
+	verifyNone verifyStatus = iota
+	verifyPassed
+	verifyUnknown
+)
+
+// verifyChecksumOrder - the checksums compared by --verify, strongest first.
+var verifyChecksumOrder = []string{"SHA256", "SHA1", "CRC64NVME", "CRC32C", "CRC32"}
+
+// isEncrypted - true when the ETag of an object is not the MD5 of its
+// content because it is encrypted on the server or by mc, or compressed
+// by mc.
+func isEncrypted(content *ClientContent) bool {
+	if isClientEncrypted(content) || isCompressed(content) {
+		return true
+	}
+	for k := range content.Metadata {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-server-side-encryption") {
+			return true
+		}
+	}
+	return false
+}
+
+// fullObjectChecksum - the value of a checksum computed over the whole
+// content, composite checksums of multipart uploads are not.
+func fullObjectChecksum(content *ClientContent, key string) (string, bool) {
+	value, ok := content.Checksum[key]
+	if !ok || strings.Contains(value, "-") {
+		return "", false
+	}
+	return value, true
+}
+
+// verifyContent - compare the content of a copy with its source. Local
+// files are hashed to match a full object checksum or the ETag of the
+// other side, objects are compared by a checksum of the same type or by
+// their ETags when they are plain MD5 sums. known is false when nothing
+// comparable is available.
+func verifyContent(src, tgt *ClientContent) (match, known bool) {
+	if src.Size != tgt.Size {
+		return false, true
+	}
+	srcLocal := src.URL.Type == fileSystem
+	tgtLocal := tgt.URL.Type == fileSystem
+
+	switch {
+	case srcLocal && tgtLocal:
+		srcSum, e := hashFile(src.URL.Path, md5.New())
+		if e != nil {
+			return false, false
+		}
+		tgtSum, e := hashFile(tgt.URL.Path, md5.New())
+		if e != nil {
+			return false, false
+		}
+		return bytes.Equal(srcSum, tgtSum), true
+	case srcLocal || tgtLocal:
+		local, remote := src, tgt
+		if tgtLocal {
+			local, remote = tgt, src
+		}
+		for _, key := range verifyChecksumOrder {
+			value, ok := fullObjectChecksum(remote, key)
+			if !ok {
+				continue
+			}
+			sum, e := hashFile(local.URL.Path, checksumTypes[key].Hasher())
+			if e != nil {
+				return false, false
+			}
+			return base64.StdEncoding.EncodeToString(sum) == value, true
+		}
+		if isEncrypted(remote) {
+			return false, false
+		}
+		return localETagEqual(local.URL.Path, local.Size, remote.ETag)
+	default:
+		for _, key := range verifyChecksumOrder {
+			srcValue, ok := fullObjectChecksum(src, key)
+			if !ok {
+				continue
+			}
+			if tgtValue, ok := fullObjectChecksum(tgt, key); ok {
+				return srcValue == tgtValue, true
+			}
+		}
+		srcETag, tgtETag := trimETag(src.ETag), trimETag(tgt.ETag)
+		if srcETag == "" || tgtETag == "" || isEncrypted(src) || isEncrypted(tgt) ||
+			etagPartsCount(srcETag) != 0 || etagPartsCount(tgtETag) != 0 {
+			return false, false
+		}
+		return srcETag == tgtETag, true
+	}
+}
+
+// verifyCopy - compare a copied object with its source, a mismatch
+// fails with ContentMismatch to have the object copied again.
+func verifyCopy(ctx context.Context, urls URLs, srcSSE, tgtSSE encrypt.ServerSide) (verifyStatus, *probe.Error) {
+	sourceURL := urls.SourceContent.URL.String()
+	targetURL := urls.TargetContent.URL.String()
+
+	srcClnt, err := newClientFromAlias(urls.SourceAlias, sourceURL)
+	if err != nil {
+		return verifyNone, err.Trace(sourceURL)
+	}
+	src, err := srcClnt.Stat(ctx, StatOptions{sse: srcSSE, versionID: urls.SourceContent.VersionID})
+	if err != nil {
+		return verifyNone, err.Trace(sourceURL)
+	}
+	tgtClnt, err := newClientFromAlias(urls.TargetAlias, targetURL)
+	if err != nil {
+		return verifyNone, err.Trace(targetURL)
+	}
+	tgt, err := tgtClnt.Stat(ctx, StatOptions{sse: tgtSSE})
+	if err != nil {
+		return verifyNone, err.Trace(targetURL)
+	}
+
+	match, known := verifyContent(src, tgt)
+	switch {
+	case !known:
+		return verifyUnknown, nil
+	case !match:
+		sourcePath := filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+		targetPath := filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+		return verifyNone, probe.NewError(ContentMismatch{Source: sourcePath, Target: targetPath})
+	}
+	return verifyPassed, nil
+}
+
+// verifyMessage - summary of the verification of the copies of a command.
+type verifyMessage struct {
+	Status       string   `json:"status"`
+	Verified     int64    `json:"verified"`
+	Unverifiable []string `json:"unverifiable,omitempty"`
+}
+
+func (m verifyMessage) String() string {
+	msg := console.Colorize("Verify", fmt.Sprintf("Verified %d objects.", m.Verified))
+	if len(m.Unverifiable) > 0 {
+		msg += "\n" + console.Colorize("VerifyUnknown", fmt.Sprintf("Unable to verify %d objects without a comparable checksum:", len(m.Unverifiable)))
+		for _, object := range m.Unverifiable {
+			msg += "\n  " + object
+		}
+	}
+	return msg
+}
+
+func (m verifyMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// verifyStats - accounting of the verified copies of a command.
+type verifyStats struct {
+	mu  sync.Mutex
+	msg verifyMessage
+}
+
+// add - account for the verification of a copy.
+func (s *verifyStats) add(urls URLs) {
+	if urls.Error != nil || urls.SourceContent == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch urls.verify {
+	case verifyPassed:
+		s.msg.Verified++
+	case verifyUnknown:
+		s.msg.Unverifiable = append(s.msg.Unverifiable, filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)))
+	}
+}
+
+// print - show the summary of the verification.
+func (s *verifyStats) print() {
+	console.SetColor("Verify", color.New(color.FgGreen, color.Bold))
+	console.SetColor("VerifyUnknown", color.New(color.FgYellow, color.Bold))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	printMsg(s.msg)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyContent(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) *ClientContent {
+		path := filepath.Join(dir, name)
+		if e := os.WriteFile(path, []byte(data), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		return &ClientContent{URL: *newClientURL(path), Size: int64(len(data))}
+	}
+	remote := func(etag string, checksum map[string]string, metadata map[string]string) *ClientContent {
+		return &ClientContent{
+			URL:      *newClientURL("https://play.min.io/bucket/object"),
+			Size:     8,
+			ETag:     etag,
+			Checksum: checksum,
+			Metadata: metadata,
+		}
+	}
+
+	first := writeFile("first", "01234567")
+	second := writeFile("second", "01234568")
+	sum := md5.Sum([]byte("01234567"))
+	etag := hex.EncodeToString(sum[:])
+	sha := sha256.Sum256([]byte("01234567"))
+	sha256sum := base64.StdEncoding.EncodeToString(sha[:])
+	sse := map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}
+
+	testCases := []struct {
+		src, tgt     *ClientContent
+		match, known bool
+	}{
+		{first, writeFile("copy", "01234567"), true, true},
+		{first, second, false, true},
+		{first, writeFile("short", "0123"), false, true},
+		{first, remote(etag, nil, nil), true, true},
+		{remote(etag, nil, nil), second, false, true},
+		{first, remote("", map[string]string{"SHA256": sha256sum}, nil), true, true},
+		{second, remote("", map[string]string{"SHA256": sha256sum}, nil), false, true},
+		// CRC32C of "01234567".
+		{first, remote(etag, map[string]string{"CRC32C": "rCIjIA=="}, sse), true, true},
+		// Neither composite checksums nor ETags of encrypted objects are comparable.
+		{first, remote(etag, map[string]string{"CRC32C": "rCIjIA==-2"}, sse), false, false},
+		{first, remote(etag+"-3", nil, nil), false, false},
+		{remote(etag, map[string]string{"SHA256": sha256sum}, nil), remote("", map[string]string{"SHA256": sha256sum}, nil), true, true},
+		{remote(etag, map[string]string{"SHA256": sha256sum}, nil), remote("", map[string]string{"SHA256": "x"}, nil), false, true},
+		{remote(etag, nil, nil), remote(etag, nil, nil), true, true},
+		{remote(etag, nil, nil), remote("9af2f8218b150c351ad802c6f3d66abe", nil, nil), false, true},
+		{remote(etag, nil, nil), remote(etag, nil, sse), false, false},
+		{remote(etag, map[string]string{"CRC32C": "rCIjIA=="}, nil), remote(etag+"-1", map[string]string{"SHA256": sha256sum}, nil), false, false},
+	}
+	for i, testCase := range testCases {
+		match, known := verifyContent(testCase.src, testCase.tgt)
+		if match != testCase.match || known != testCase.known {
+			t.Errorf("Test %d: expected match %t known %t, got %t %t", i+1, testCase.match, testCase.known, match, known)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package inventory reads S3 Inventory reports, the manifest.json of a
+// report and the CSV and Parquet data files it references.
+package inventory
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the data files of a report.
+const (
+	FormatCSV     = "CSV"
+	FormatParquet = "Parquet"
+	FormatORC     = "ORC"
+)
+
+// File - a data file of a report.
+type File struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// Manifest - the manifest.json of a report.
+type Manifest struct {
+	SourceBucket      string `json:"sourceBucket"`
+	DestinationBucket string `json:"destinationBucket"`
+	Version           string `json:"version"`
+	CreationTimestamp string `json:"creationTimestamp"`
+	FileFormat        string `json:"fileFormat"`
+	FileSchema        string `json:"fileSchema"`
+	Files             []File `json:"files"`
+}
+
+// ParseManifest - parse the manifest.json of a report.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if e := json.NewDecoder(r).Decode(&m); e != nil {
+		return nil, e
+	}
+	m.DestinationBucket = strings.TrimPrefix(m.DestinationBucket, "arn:aws:s3:::")
+	switch {
+	case m.SourceBucket == "":
+		return nil, errors.New("missing sourceBucket in manifest")
+	case strings.EqualFold(m.FileFormat, FormatCSV):
+		m.FileFormat = FormatCSV
+		if m.FileSchema == "" {
+			return nil, errors.New("missing fileSchema in manifest of a CSV report")
+		}
+	case strings.EqualFold(m.FileFormat, FormatParquet):
+		m.FileFormat = FormatParquet
+	case strings.EqualFold(m.FileFormat, FormatORC):
+		return nil, errors.New("ORC inventory reports are not supported")
+	default:
+		return nil, fmt.Errorf("unknown inventory file format '%s'", m.FileFormat)
+	}
+	return &m, nil
+}
+
+// Object - a row of a report, fields not part of the report are zero.
+type Object struct {
+	Bucket         string
+	Key            string
+	VersionID      string
+	IsLatest       bool
+	IsDeleteMarker bool
+	Size           int64
+	LastModified   time.Time
+	ETag           string
+	StorageClass   string
+}
+
+// field - the name of a field of a report without case and underscores,
+// CSV reports name them 'LastModifiedDate' and Parquet 'last_modified_date'.
+func field(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", ""))
+}
+
+// setField - set the field of obj named by field() from its text.
+func setField(obj *Object, name, value string) error {
+	var e error
+	switch name {
+	case "bucket":
+		obj.Bucket = value
+	case "key":
+		obj.Key = value
+	case "versionid":
+		obj.VersionID = value
+	case "islatest":
+		obj.IsLatest, e = parseBool(value)
+	case "isdeletemarker":
+		obj.IsDeleteMarker, e = parseBool(value)
+	case "size":
+		if value != "" {
+			obj.Size, e = strconv.ParseInt(value, 10, 64)
+		}
+	case "lastmodifieddate":
+		if value != "" {
+			obj.LastModified, e = time.Parse(time.RFC3339Nano, value)
+		}
+	case "etag":
+		obj.ETag = value
+	case "storageclass":
+		obj.StorageClass = value
+	}
+	if e != nil {
+		return fmt.Errorf("invalid %s '%s': %w", name, value, e)
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// ReadCSV - call fn for every row of a CSV data file, compressed with
+// gzip or not, with the columns of schema. Keys of CSV reports are URL
+// encoded.
+func ReadCSV(r io.Reader, schema string, fn func(Object) error) error {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, e := gzip.NewReader(br)
+		if e != nil {
+			return e
+		}
+		defer zr.Close()
+		br = bufio.NewReader(zr)
+	}
+
+	var columns []string
+	for _, name := range strings.Split(schema, ",") {
+		columns = append(columns, field(name))
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = len(columns)
+	reader.ReuseRecord = true
+	for {
+		record, e := reader.Read()
+		if errors.Is(e, io.EOF) {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+		var obj Object
+		for i, name := range columns {
+			value := record[i]
+			if name == "key" {
+				if value, e = url.QueryUnescape(value); e != nil {
+					return fmt.Errorf("invalid key '%s': %w", record[i], e)
+				}
+			}
+			if e = setField(&obj, name, value); e != nil {
+				return e
+			}
+		}
+		if e = fn(obj); e != nil {
+			return e
+		}
+	}
+}
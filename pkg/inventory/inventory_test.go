@@ -0,0 +1,189 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseManifest(t *testing.T) {
+	testCases := []struct {
+		manifest string
+		format   string
+		err      bool
+	}{
+		{manifest: `{"sourceBucket":"src","destinationBucket":"arn:aws:s3:::dst","fileFormat":"CSV","fileSchema":"Bucket, Key","files":[{"key":"a.csv.gz"}]}`, format: FormatCSV},
+		{manifest: `{"sourceBucket":"src","destinationBucket":"dst","fileFormat":"Parquet","fileSchema":"message s3.inventory {}","files":[]}`, format: FormatParquet},
+		{manifest: `{"sourceBucket":"src","fileFormat":"CSV"}`, err: true},
+		{manifest: `{"sourceBucket":"src","fileFormat":"ORC"}`, err: true},
+		{manifest: `{"fileFormat":"Parquet"}`, err: true},
+		{manifest: `{"sourceBucket":`, err: true},
+	}
+
+	for i, testCase := range testCases {
+		m, e := ParseManifest(strings.NewReader(testCase.manifest))
+		if testCase.err {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if m.FileFormat != testCase.format || m.DestinationBucket != "dst" {
+			t.Fatalf("Test %d: unexpected manifest %+v", i+1, m)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	const schema = "Bucket, Key, VersionId, IsLatest, IsDeleteMarker, Size, LastModifiedDate, ETag, StorageClass"
+	const data = `"bkt","dir%2Fa+b.txt","v1","true","false","10","2024-05-01T10:00:00.000Z","etag","STANDARD"
+"bkt","dir%2Fb.txt","v2","true","true","","2024-05-01T10:00:01.000Z","","STANDARD"
+`
+	expected := []Object{
+		{Bucket: "bkt", Key: "dir/a b.txt", VersionID: "v1", IsLatest: true, Size: 10, LastModified: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), ETag: "etag", StorageClass: "STANDARD"},
+		{Bucket: "bkt", Key: "dir/b.txt", VersionID: "v2", IsLatest: true, IsDeleteMarker: true, LastModified: time.Date(2024, 5, 1, 10, 0, 1, 0, time.UTC), StorageClass: "STANDARD"},
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write([]byte(data))
+	zw.Close()
+
+	for _, r := range []*bytes.Reader{bytes.NewReader([]byte(data)), bytes.NewReader(compressed.Bytes())} {
+		var objects []Object
+		if e := ReadCSV(r, schema, func(obj Object) error {
+			objects = append(objects, obj)
+			return nil
+		}); e != nil {
+			t.Fatal(e)
+		}
+		if !reflect.DeepEqual(objects, expected) {
+			t.Fatalf("expected %+v, got %+v", expected, objects)
+		}
+	}
+
+	if e := ReadCSV(strings.NewReader(`"bkt","key"`+"\n"), schema, func(Object) error { return nil }); e == nil {
+		t.Fatal("expected an error for missing columns")
+	}
+}
+
+// testdata/inventory.parquet is a snappy compressed report of 3 copies
+// of the 4 rows below written by github.com/xitongsys/parquet-go, in
+// several row groups with dictionary encoded and optional columns.
+func TestReadParquet(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := []Object{
+		{Bucket: "mybucket", Key: "photos/a.jpg", VersionID: "v2", IsLatest: true, Size: 1024, LastModified: at, ETag: "etag-a", StorageClass: "STANDARD"},
+		{Bucket: "mybucket", Key: "photos/a.jpg", VersionID: "v1", Size: 512, LastModified: at.Add(-time.Second), ETag: "etag-a1", StorageClass: "STANDARD"},
+		{Bucket: "mybucket", Key: "photos/b.jpg", VersionID: "v3", IsLatest: true, IsDeleteMarker: true, LastModified: at.Add(time.Second), StorageClass: "STANDARD"},
+		{Bucket: "mybucket", Key: "docs/c.txt", IsLatest: true, Size: 10, LastModified: at.Add(2 * time.Second), ETag: "etag-c", StorageClass: "GLACIER"},
+	}
+	var expected []Object
+	for i := 0; i < 3; i++ {
+		expected = append(expected, rows...)
+	}
+
+	f, e := os.Open("testdata/inventory.parquet")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	st, e := f.Stat()
+	if e != nil {
+		t.Fatal(e)
+	}
+	var objects []Object
+	if e = ReadParquet(f, st.Size(), func(obj Object) error {
+		objects = append(objects, obj)
+		return nil
+	}); e != nil {
+		t.Fatal(e)
+	}
+	if !reflect.DeepEqual(objects, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, objects)
+	}
+
+	if e = ReadParquet(bytes.NewReader([]byte("PAR1 not parquet")), 16, func(Object) error { return nil }); e == nil {
+		t.Fatal("expected an error for an invalid file")
+	}
+}
+
+func TestNewColumnChunk(t *testing.T) {
+	testCases := []struct {
+		meta thriftFields
+		err  bool
+	}{
+		{thriftFields{2: []any{int64(encodingPlain), int64(encodingRLE)}, 4: int64(codecSnappy), 5: int64(4)}, false},
+		{thriftFields{2: []any{int64(encodingPlainDictionary), int64(encodingBitPacked), int64(encodingRLEDictionary)}, 4: int64(codecZstd), 5: int64(4)}, false},
+		// DELTA_BINARY_PACKED and BYTE_STREAM_SPLIT.
+		{thriftFields{2: []any{int64(5)}, 4: int64(codecSnappy), 5: int64(4)}, true},
+		{thriftFields{2: []any{int64(encodingPlain), int64(9)}, 4: int64(codecSnappy), 5: int64(4)}, true},
+		// LZ4.
+		{thriftFields{2: []any{int64(encodingPlain)}, 4: int64(7), 5: int64(4)}, true},
+		// A chunk of another number of values than the rows of its group.
+		{thriftFields{2: []any{int64(encodingPlain)}, 4: int64(codecSnappy), 5: int64(3)}, true},
+	}
+	for i, testCase := range testCases {
+		_, e := newColumnChunk(nil, parquetColumn{name: "key"}, testCase.meta, 4)
+		if testCase.err != (e != nil) {
+			t.Fatalf("Test %d: expected error %t, got %v", i+1, testCase.err, e)
+		}
+	}
+}
+
+func TestDecodeRLE(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		bitWidth int
+		n        int
+		expected []int
+	}{
+		// A run of 5 ones.
+		{data: []byte{5 << 1, 1}, bitWidth: 1, n: 5, expected: []int{1, 1, 1, 1, 1}},
+		// A bit-packed group of 8 values of 3 bits 0..7.
+		{data: []byte{1<<1 | 1, 0x88, 0xc6, 0xfa}, bitWidth: 3, n: 8, expected: []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		// A run of 2 values of 2 bytes and a truncated bit-packed group.
+		{data: []byte{2 << 1, 0x34, 0x12, 1<<1 | 1, 0xff}, bitWidth: 9, n: 3, expected: nil},
+		{data: []byte{2 << 1, 0x34, 0x12}, bitWidth: 9, n: 2, expected: []int{0x1234, 0x1234}},
+	}
+
+	for i, testCase := range testCases {
+		values, e := decodeRLE(testCase.data, testCase.bitWidth, testCase.n)
+		if testCase.expected == nil {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if !reflect.DeepEqual(values, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, values)
+		}
+	}
+}
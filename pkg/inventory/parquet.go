@@ -0,0 +1,681 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// This is a reader of the subset of Parquet written for inventory
+// reports: flat columns, of which only the fields of Object are
+// decoded, in PLAIN or dictionary encoded data pages. Other encodings
+// are refused before any row is read.
+
+const parquetMagic = "PAR1"
+
+// Physical types of Parquet.
+const (
+	parquetBoolean = iota
+	parquetInt32
+	parquetInt64
+	parquetInt96
+	parquetFloat
+	parquetDouble
+	parquetByteArray
+	parquetFixedLenByteArray
+)
+
+// Page types of Parquet.
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// Encodings of Parquet, bit-packed levels are only listed by older writers.
+const (
+	encodingPlain           = 0
+	encodingPlainDictionary = 2
+	encodingRLE             = 3
+	encodingBitPacked       = 4
+	encodingRLEDictionary   = 8
+)
+
+const (
+	// pageHeaderWindow - the first read of a page header, headers with
+	// long statistics are read again with a larger window.
+	pageHeaderWindow = 4 << 10
+
+	// maxPageSize - writers default to pages of 1MiB, larger pages are
+	// refused rather than held in memory.
+	maxPageSize = 256 << 20
+)
+
+// Compression codecs of Parquet.
+const (
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+	codecZstd         = 6
+)
+
+// Converted types of timestamps in Parquet.
+const (
+	convertedTimestampMillis = 9
+	convertedTimestampMicros = 10
+)
+
+// julianUnixEpoch - the julian day of 1970-01-01, the epoch of INT96 timestamps.
+const julianUnixEpoch = 2440588
+
+// objectFields - the fields of a report read into an Object.
+var objectFields = map[string]bool{
+	"bucket":           true,
+	"key":              true,
+	"versionid":        true,
+	"islatest":         true,
+	"isdeletemarker":   true,
+	"size":             true,
+	"lastmodifieddate": true,
+	"etag":             true,
+	"storageclass":     true,
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdDecoder *zstd.Decoder
+)
+
+// parquetColumn - a leaf column of the schema of a Parquet file.
+type parquetColumn struct {
+	name       string
+	typ        int64
+	typeLength int
+	maxDef     int
+	maxRep     int
+	unit       time.Duration
+}
+
+// ReadParquet - call fn for every row of a Parquet data file of size bytes.
+// The encodings and codecs of the columns are checked before the first row,
+// then the rows are read page by page.
+func ReadParquet(r io.ReaderAt, size int64, fn func(Object) error) error {
+	footer := make([]byte, 8)
+	if size < int64(len(parquetMagic)+len(footer)) {
+		return errors.New("not a parquet file")
+	}
+	if _, e := r.ReadAt(footer, size-8); e != nil {
+		return e
+	}
+	if string(footer[4:]) != parquetMagic {
+		return errors.New("not a parquet file")
+	}
+	metaLen := int64(binary.LittleEndian.Uint32(footer))
+	if metaLen > size-8-int64(len(parquetMagic)) {
+		return errors.New("invalid parquet footer")
+	}
+	buf := make([]byte, metaLen)
+	if _, e := r.ReadAt(buf, size-8-metaLen); e != nil {
+		return e
+	}
+	meta, e := (&thriftDecoder{buf: buf}).readStruct()
+	if e != nil {
+		return fmt.Errorf("invalid parquet metadata: %w", e)
+	}
+
+	columns := make(map[string]parquetColumn)
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return errors.New("missing parquet schema")
+	}
+	root, _ := schema[0].(thriftFields)
+	if _, e = parquetSchema(schema, 1, int(root.int(5)), nil, 0, 0, columns); e != nil {
+		return e
+	}
+	for _, name := range []string{"bucket", "key"} {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("missing column '%s' in parquet schema", name)
+		}
+	}
+
+	type parquetRowGroup struct {
+		numRows int64
+		chunks  []*columnChunk
+	}
+	var rowGroups []parquetRowGroup
+	for _, v := range meta.list(4) {
+		rowGroup, _ := v.(thriftFields)
+		group := parquetRowGroup{numRows: rowGroup.int(3)}
+		for _, v := range rowGroup.list(1) {
+			chunk, _ := v.(thriftFields)
+			chunkMeta := chunk.strct(3)
+			var path []string
+			for _, p := range chunkMeta.list(3) {
+				s, _ := p.([]byte)
+				path = append(path, string(s))
+			}
+			column, ok := columns[field(strings.Join(path, "."))]
+			if !ok {
+				continue
+			}
+			if chunk.str(1) != "" {
+				return fmt.Errorf("column '%s' in external file '%s' is not supported", column.name, chunk.str(1))
+			}
+			c, e := newColumnChunk(r, column, chunkMeta, group.numRows)
+			if e != nil {
+				return fmt.Errorf("column '%s': %w", column.name, e)
+			}
+			group.chunks = append(group.chunks, c)
+		}
+		rowGroups = append(rowGroups, group)
+	}
+
+	for _, group := range rowGroups {
+		for n := group.numRows; n > 0; n-- {
+			var obj Object
+			for _, c := range group.chunks {
+				value, e := c.next()
+				if e != nil {
+					return fmt.Errorf("column '%s': %w", c.name, e)
+				}
+				setValue(&obj, field(c.name), value)
+			}
+			if e = fn(obj); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// parquetSchema - collect the leaf columns of the n children of an
+// element of the schema at index i, returns the index after them.
+func parquetSchema(schema []any, i, n int, path []string, maxDef, maxRep int, columns map[string]parquetColumn) (int, error) {
+	for ; n > 0; n-- {
+		if i >= len(schema) {
+			return 0, errors.New("invalid parquet schema")
+		}
+		element, _ := schema[i].(thriftFields)
+		i++
+		def, rep := maxDef, maxRep
+		switch element.int(3) {
+		case 1: // OPTIONAL
+			def++
+		case 2: // REPEATED
+			def++
+			rep++
+		}
+		elementPath := append(append([]string{}, path...), element.str(4))
+		if children := int(element.int(5)); children > 0 {
+			var e error
+			if i, e = parquetSchema(schema, i, children, elementPath, def, rep, columns); e != nil {
+				return 0, e
+			}
+			continue
+		}
+		name := strings.Join(elementPath, ".")
+		if !objectFields[field(name)] {
+			continue
+		}
+		if rep > 0 {
+			return 0, fmt.Errorf("repeated column '%s' is not supported", name)
+		}
+		column := parquetColumn{
+			name:       name,
+			typ:        element.int(1),
+			typeLength: int(element.int(2)),
+			maxDef:     def,
+			maxRep:     rep,
+		}
+		switch element.int(6) {
+		case convertedTimestampMillis:
+			column.unit = time.Millisecond
+		case convertedTimestampMicros:
+			column.unit = time.Microsecond
+		}
+		if timestamp := element.strct(10).strct(8); timestamp != nil {
+			switch unit := timestamp.strct(2); {
+			case unit.has(1):
+				column.unit = time.Millisecond
+			case unit.has(2):
+				column.unit = time.Microsecond
+			case unit.has(3):
+				column.unit = time.Nanosecond
+			}
+		}
+		columns[field(name)] = column
+	}
+	return i, nil
+}
+
+// columnChunk - the values of a column chunk of a row group, read one
+// page at a time.
+type columnChunk struct {
+	parquetColumn
+	r      io.ReaderAt
+	codec  int64
+	offset int64 // offset of the next page
+	end    int64
+	left   int64 // values left in the pages to read
+	dict   []any
+	values []any // values left in the current page
+}
+
+// newColumnChunk - the reader of the values of a column chunk of numRows
+// values, the encodings and the codec of the chunk must be supported.
+func newColumnChunk(r io.ReaderAt, column parquetColumn, meta thriftFields, numRows int64) (*columnChunk, error) {
+	for _, v := range meta.list(2) {
+		switch encoding, _ := v.(int64); encoding {
+		case encodingPlain, encodingPlainDictionary, encodingRLE, encodingBitPacked, encodingRLEDictionary:
+		default:
+			return nil, fmt.Errorf("encoding %d is not supported", encoding)
+		}
+	}
+	switch codec := meta.int(4); codec {
+	case codecUncompressed, codecSnappy, codecGzip, codecZstd:
+	default:
+		return nil, fmt.Errorf("compression codec %d is not supported", codec)
+	}
+	if numValues := meta.int(5); numValues != numRows {
+		return nil, fmt.Errorf("expected %d values, got %d", numRows, numValues)
+	}
+
+	offset := meta.int(9)
+	if dictOffset := meta.int(11); dictOffset > 0 && dictOffset < offset {
+		offset = dictOffset
+	}
+	length := meta.int(7)
+	if offset < 0 || length < 0 || offset > math.MaxInt64-length {
+		return nil, errors.New("invalid column chunk")
+	}
+	return &columnChunk{
+		parquetColumn: column,
+		r:             r,
+		codec:         meta.int(4),
+		offset:        offset,
+		end:           offset + length,
+		left:          numRows,
+	}, nil
+}
+
+// next - the next value of the chunk, nil for nulls.
+func (c *columnChunk) next() (any, error) {
+	for len(c.values) == 0 {
+		if c.left <= 0 || c.offset >= c.end {
+			return nil, errors.New("truncated column chunk")
+		}
+		if e := c.readPage(); e != nil {
+			return nil, e
+		}
+	}
+	v := c.values[0]
+	c.values = c.values[1:]
+	return v, nil
+}
+
+// readPageHeader - decode the header of the next page, headers have no
+// size and are read with a growing window.
+func (c *columnChunk) readPageHeader() (thriftFields, error) {
+	for window := int64(pageHeaderWindow); ; window *= 2 {
+		window = min(window, c.end-c.offset)
+		buf := make([]byte, window)
+		if n, e := c.r.ReadAt(buf, c.offset); n < len(buf) {
+			return nil, e
+		}
+		d := &thriftDecoder{buf: buf}
+		header, e := d.readStruct()
+		if e == nil {
+			c.offset += int64(d.pos)
+			return header, nil
+		}
+		if !errors.Is(e, errThriftShort) || window == c.end-c.offset {
+			return nil, fmt.Errorf("invalid page header: %w", e)
+		}
+	}
+}
+
+// readPage - read the next page, a dictionary or the values of a data page.
+func (c *columnChunk) readPage() error {
+	header, e := c.readPageHeader()
+	if e != nil {
+		return e
+	}
+	pageSize := header.int(3)
+	if pageSize < 0 || pageSize > c.end-c.offset {
+		return errors.New("truncated page")
+	}
+	if pageSize > maxPageSize || header.int(2) > maxPageSize {
+		return fmt.Errorf("page of %d bytes is too large", max(pageSize, header.int(2)))
+	}
+	page := make([]byte, pageSize)
+	if n, e := c.r.ReadAt(page, c.offset); n < len(page) {
+		return e
+	}
+	c.offset += pageSize
+
+	switch header.int(1) {
+	case pageDictionary:
+		data, e := decompress(c.codec, page)
+		if e != nil {
+			return e
+		}
+		c.dict, e = c.plain(data, int(header.strct(7).int(1)))
+		return e
+	case pageData:
+		h := header.strct(5)
+		data, e := decompress(c.codec, page)
+		if e != nil {
+			return e
+		}
+		n := int(h.int(1))
+		var defs []int
+		if c.maxDef > 0 {
+			if h.int(3) != encodingRLE {
+				return fmt.Errorf("definition levels encoding %d is not supported", h.int(3))
+			}
+			if len(data) < 4 {
+				return errors.New("truncated definition levels")
+			}
+			l := int(binary.LittleEndian.Uint32(data))
+			if l > len(data)-4 {
+				return errors.New("truncated definition levels")
+			}
+			if defs, e = decodeRLE(data[4:4+l], bits.Len(uint(c.maxDef)), n); e != nil {
+				return e
+			}
+			data = data[4+l:]
+		}
+		return c.pageValues(data, h.int(2), defs, n)
+	case pageDataV2:
+		h := header.strct(8)
+		n := int(h.int(1))
+		defLen, repLen := h.int(5), h.int(6)
+		if defLen < 0 || repLen < 0 || defLen+repLen > int64(len(page)) {
+			return errors.New("truncated levels")
+		}
+		var defs []int
+		if c.maxDef > 0 {
+			if defs, e = decodeRLE(page[repLen:repLen+defLen], bits.Len(uint(c.maxDef)), n); e != nil {
+				return e
+			}
+		}
+		data := page[repLen+defLen:]
+		if h.bool(7, true) {
+			if data, e = decompress(c.codec, data); e != nil {
+				return e
+			}
+		}
+		return c.pageValues(data, h.int(4), defs, n)
+	}
+	// Index pages.
+	return nil
+}
+
+// pageValues - the n values of a data page become the current values.
+func (c *columnChunk) pageValues(data []byte, encoding int64, defs []int, n int) error {
+	if int64(n) > c.left {
+		return fmt.Errorf("expected %d values, got %d", c.left, n)
+	}
+	values, e := c.appendValues(c.values[:0], data, encoding, defs, n, c.dict)
+	if e != nil {
+		return e
+	}
+	c.values = values
+	c.left -= int64(n)
+	return nil
+}
+
+// appendValues - append the n values of a data page, defs are their
+// definition levels, values below the maximum level are null.
+func (c parquetColumn) appendValues(values []any, data []byte, encoding int64, defs []int, n int, dict []any) ([]any, error) {
+	count := n
+	if defs != nil {
+		count = 0
+		for _, def := range defs {
+			if def == c.maxDef {
+				count++
+			}
+		}
+	}
+
+	var decoded []any
+	var e error
+	switch encoding {
+	case encodingPlain:
+		decoded, e = c.plain(data, count)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if dict == nil {
+			return nil, errors.New("missing dictionary page")
+		}
+		if len(data) == 0 {
+			if count > 0 {
+				return nil, errors.New("truncated dictionary indices")
+			}
+			break
+		}
+		indices, e := decodeRLE(data[1:], int(data[0]), count)
+		if e != nil {
+			return nil, e
+		}
+		decoded = make([]any, count)
+		for i, index := range indices {
+			if index < 0 || index >= len(dict) {
+				return nil, errors.New("invalid dictionary index")
+			}
+			decoded[i] = dict[index]
+		}
+	case encodingRLE:
+		if c.typ != parquetBoolean || len(data) < 4 {
+			return nil, errors.New("invalid RLE encoded values")
+		}
+		l := int(binary.LittleEndian.Uint32(data))
+		if l > len(data)-4 {
+			return nil, errors.New("truncated RLE encoded values")
+		}
+		ints, e := decodeRLE(data[4:4+l], 1, count)
+		if e != nil {
+			return nil, e
+		}
+		decoded = make([]any, count)
+		for i, v := range ints {
+			decoded[i] = v == 1
+		}
+	default:
+		return nil, fmt.Errorf("encoding %d is not supported", encoding)
+	}
+	if e != nil {
+		return nil, e
+	}
+
+	if defs == nil {
+		return append(values, decoded...), nil
+	}
+	for _, def := range defs {
+		if def != c.maxDef {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, decoded[0])
+		decoded = decoded[1:]
+	}
+	return values, nil
+}
+
+// plain - decode n PLAIN encoded values.
+func (c parquetColumn) plain(data []byte, n int) ([]any, error) {
+	values := make([]any, 0, n)
+	width := map[int64]int{parquetInt32: 4, parquetInt64: 8, parquetInt96: 12, parquetFloat: 4, parquetDouble: 8}[c.typ]
+	if c.typ == parquetFixedLenByteArray {
+		width = c.typeLength
+	}
+	for i := 0; i < n; i++ {
+		switch c.typ {
+		case parquetBoolean:
+			if i/8 >= len(data) {
+				return nil, errors.New("truncated values")
+			}
+			values = append(values, data[i/8]&(1<<(i%8)) != 0)
+			continue
+		case parquetByteArray:
+			if len(data) < 4 {
+				return nil, errors.New("truncated values")
+			}
+			l := int(binary.LittleEndian.Uint32(data))
+			if l > len(data)-4 {
+				return nil, errors.New("truncated values")
+			}
+			values = append(values, string(data[4:4+l]))
+			data = data[4+l:]
+			continue
+		}
+		if width <= 0 || len(data) < width {
+			return nil, errors.New("truncated values")
+		}
+		switch c.typ {
+		case parquetInt32:
+			values = append(values, int64(int32(binary.LittleEndian.Uint32(data))))
+		case parquetInt64:
+			v := int64(binary.LittleEndian.Uint64(data))
+			if c.unit != 0 {
+				values = append(values, time.Unix(0, v*int64(c.unit)).UTC())
+			} else {
+				values = append(values, v)
+			}
+		case parquetInt96:
+			nanos := int64(binary.LittleEndian.Uint64(data))
+			days := int64(binary.LittleEndian.Uint32(data[8:]))
+			values = append(values, time.Unix((days-julianUnixEpoch)*86400, nanos).UTC())
+		case parquetFloat:
+			values = append(values, float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+		case parquetDouble:
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		case parquetFixedLenByteArray:
+			values = append(values, string(data[:width]))
+		}
+		data = data[width:]
+	}
+	return values, nil
+}
+
+// decodeRLE - decode n values of the RLE/bit-packing hybrid encoding of
+// Parquet levels, dictionary indices and booleans.
+func decodeRLE(data []byte, bitWidth, n int) ([]int, error) {
+	if bitWidth > 32 {
+		return nil, fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+	values := make([]int, 0, n)
+	byteWidth := (bitWidth + 7) / 8
+	for len(values) < n {
+		header, l := binary.Uvarint(data)
+		if l <= 0 {
+			return nil, errors.New("truncated RLE data")
+		}
+		data = data[l:]
+		if header&1 == 1 {
+			count := int(header>>1) * 8
+			size := int(header>>1) * bitWidth
+			if size > len(data) {
+				return nil, errors.New("truncated bit-packed data")
+			}
+			for i := 0; i < count && len(values) < n; i++ {
+				v := 0
+				for b := 0; b < bitWidth; b++ {
+					bit := i*bitWidth + b
+					if data[bit/8]&(1<<(bit%8)) != 0 {
+						v |= 1 << b
+					}
+				}
+				values = append(values, v)
+			}
+			data = data[size:]
+			continue
+		}
+		if byteWidth > len(data) {
+			return nil, errors.New("truncated RLE run")
+		}
+		v := 0
+		for b := 0; b < byteWidth; b++ {
+			v |= int(data[b]) << (8 * b)
+		}
+		data = data[byteWidth:]
+		for count := int(header >> 1); count > 0 && len(values) < n; count-- {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// decompress - the content of a page compressed with codec.
+func decompress(codec int64, data []byte) ([]byte, error) {
+	switch codec {
+	case codecUncompressed:
+		return data, nil
+	case codecSnappy:
+		return snappy.Decode(nil, data)
+	case codecGzip:
+		zr, e := gzip.NewReader(bytes.NewReader(data))
+		if e != nil {
+			return nil, e
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case codecZstd:
+		zstdOnce.Do(func() {
+			zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		})
+		return zstdDecoder.DecodeAll(data, nil)
+	}
+	return nil, fmt.Errorf("compression codec %d is not supported", codec)
+}
+
+// setValue - set the field of obj named by field() from a decoded value.
+func setValue(obj *Object, name string, value any) {
+	switch name {
+	case "bucket":
+		obj.Bucket, _ = value.(string)
+	case "key":
+		obj.Key, _ = value.(string)
+	case "versionid":
+		obj.VersionID, _ = value.(string)
+	case "islatest":
+		obj.IsLatest, _ = value.(bool)
+	case "isdeletemarker":
+		obj.IsDeleteMarker, _ = value.(bool)
+	case "size":
+		obj.Size, _ = value.(int64)
+	case "lastmodifieddate":
+		obj.LastModified, _ = value.(time.Time)
+	case "etag":
+		obj.ETag, _ = value.(string)
+	case "storageclass":
+		obj.StorageClass, _ = value.(string)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Types of the thrift compact protocol.
+const (
+	thriftStop   = 0
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+var errThriftShort = errors.New("truncated thrift data")
+
+// thriftFields - a decoded thrift struct by field id. Integers are
+// int64, binaries []byte, lists []any and structs thriftFields.
+type thriftFields map[int16]any
+
+func (f thriftFields) int(id int16) int64 {
+	v, _ := f[id].(int64)
+	return v
+}
+
+func (f thriftFields) has(id int16) bool {
+	_, ok := f[id]
+	return ok
+}
+
+func (f thriftFields) bool(id int16, def bool) bool {
+	v, ok := f[id].(bool)
+	if !ok {
+		return def
+	}
+	return v
+}
+
+func (f thriftFields) str(id int16) string {
+	v, _ := f[id].([]byte)
+	return string(v)
+}
+
+func (f thriftFields) list(id int16) []any {
+	v, _ := f[id].([]any)
+	return v
+}
+
+func (f thriftFields) strct(id int16) thriftFields {
+	v, _ := f[id].(thriftFields)
+	return v
+}
+
+// thriftDecoder - a decoder of the thrift compact protocol, the
+// encoding of the metadata of Parquet files.
+type thriftDecoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *thriftDecoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, errThriftShort
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errThriftShort
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *thriftDecoder) varint() (int64, error) {
+	v, e := d.uvarint()
+	return int64(v>>1) ^ -int64(v&1), e
+}
+
+func (d *thriftDecoder) bytes() ([]byte, error) {
+	n, e := d.uvarint()
+	if e != nil {
+		return nil, e
+	}
+	if n > uint64(len(d.buf)-d.pos) {
+		return nil, errThriftShort
+	}
+	b := d.buf[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// readStruct - decode a struct up to its stop field.
+func (d *thriftDecoder) readStruct() (thriftFields, error) {
+	fields := make(thriftFields)
+	var id int16
+	for {
+		header, e := d.byte()
+		if e != nil {
+			return nil, e
+		}
+		typ := header & 0x0f
+		if typ == thriftStop {
+			return fields, nil
+		}
+		if delta := header >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			v, e := d.varint()
+			if e != nil {
+				return nil, e
+			}
+			id = int16(v)
+		}
+		var value any
+		switch typ {
+		case thriftTrue:
+			value = true
+		case thriftFalse:
+			value = false
+		default:
+			if value, e = d.readValue(typ); e != nil {
+				return nil, e
+			}
+		}
+		fields[id] = value
+	}
+}
+
+// readValue - decode a value of a type, booleans outside of a field
+// header are a byte.
+func (d *thriftDecoder) readValue(typ byte) (any, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		b, e := d.byte()
+		return b == thriftTrue, e
+	case thriftByte:
+		b, e := d.byte()
+		return int64(int8(b)), e
+	case thriftI16, thriftI32, thriftI64:
+		return d.varint()
+	case thriftDouble:
+		if len(d.buf)-d.pos < 8 {
+			return nil, errThriftShort
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[d.pos:]))
+		d.pos += 8
+		return v, nil
+	case thriftBinary:
+		return d.bytes()
+	case thriftList, thriftSet:
+		header, e := d.byte()
+		if e != nil {
+			return nil, e
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, e = d.uvarint(); e != nil {
+				return nil, e
+			}
+		}
+		if size > uint64(len(d.buf)-d.pos) {
+			return nil, errThriftShort
+		}
+		list := make([]any, 0, size)
+		for i := uint64(0); i < size; i++ {
+			v, e := d.readValue(header & 0x0f)
+			if e != nil {
+				return nil, e
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case thriftMap:
+		size, e := d.uvarint()
+		if e != nil || size == 0 {
+			return nil, e
+		}
+		types, e := d.byte()
+		if e != nil {
+			return nil, e
+		}
+		for i := uint64(0); i < size; i++ {
+			if _, e = d.readValue(types >> 4); e != nil {
+				return nil, e
+			}
+			if _, e = d.readValue(types & 0x0f); e != nil {
+				return nil, e
+			}
+		}
+		return nil, nil
+	case thriftStruct:
+		return d.readStruct()
+	}
+	return nil, fmt.Errorf("unknown thrift type %d", typ)
+}
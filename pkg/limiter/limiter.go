@@ -22,21 +22,100 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/juju/ratelimit"
 )
 
+// Limit is a rate limit following a schedule, a Limit shared by
+// several transports limits their combined throughput.
+type Limit struct {
+	schedule Schedule
+
+	mu     sync.Mutex
+	rate   int64
+	bucket atomic.Pointer[ratelimit.Bucket]
+	// Time of the next check of the schedule, in Unix nanoseconds.
+	next atomic.Int64
+}
+
+// NewLimit returns a limit following the schedule, nil when the
+// schedule never limits the rate.
+func NewLimit(schedule Schedule) *Limit {
+	if schedule.IsUnlimited() {
+		return nil
+	}
+	l := &Limit{schedule: schedule}
+	l.retune(time.Now())
+	return l
+}
+
+// retune sets the rate of the schedule at now, the bucket is
+// only replaced when the rate changes.
+func (l *Limit) retune(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.UnixNano() < l.next.Load() {
+		return
+	}
+	if rate := l.schedule.RateAt(now); rate != l.rate || l.next.Load() == 0 {
+		l.rate = rate
+		if rate > 0 {
+			l.bucket.Store(ratelimit.NewBucketWithRate(float64(rate), rate))
+		} else {
+			l.bucket.Store(nil)
+		}
+	}
+	// Windows are set with a minute resolution.
+	l.next.Store(now.Truncate(time.Minute).Add(time.Minute).UnixNano())
+}
+
+// current returns the bucket of the current rate, nil when unlimited.
+func (l *Limit) current() *ratelimit.Bucket {
+	if l == nil {
+		return nil
+	}
+	if now := time.Now(); now.UnixNano() >= l.next.Load() {
+		l.retune(now)
+	}
+	return l.bucket.Load()
+}
+
+// reader is an io.Reader limited by the rate of a Limit at the time
+// of each read, a long transfer follows the changes of the schedule.
+type reader struct {
+	io.Reader
+	limit *Limit
+}
+
+func (r reader) Read(p []byte) (int, error) {
+	bucket := r.limit.current()
+	if bucket == nil {
+		return r.Reader.Read(p)
+	}
+	if capacity := bucket.Capacity(); int64(len(p)) > capacity {
+		p = p[:capacity]
+	}
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		bucket.Wait(int64(n))
+	}
+	return n, err
+}
+
 type limiter struct {
-	upload    *ratelimit.Bucket
-	download  *ratelimit.Bucket
+	upload    *Limit
+	download  *Limit
 	transport http.RoundTripper // HTTP transport that needs to be intercepted
 }
 
-func (l limiter) limitReader(r io.Reader, b *ratelimit.Bucket) io.Reader {
-	if b == nil {
+func (l limiter) limitReader(r io.Reader, limit *Limit) io.Reader {
+	if limit == nil {
 		return r
 	}
-	return ratelimit.Reader(r, b)
+	return reader{Reader: r, limit: limit}
 }
 
 // RoundTrip executes user provided request and response hooks for each HTTP call.
@@ -70,26 +149,19 @@ func (l limiter) RoundTrip(req *http.Request) (res *http.Response, err error) {
 
 // New return a ratelimited transport
 func New(uploadLimit, downloadLimit int64, transport http.RoundTripper) http.RoundTripper {
-	if uploadLimit == 0 && downloadLimit == 0 {
-		return transport
-	}
-
-	var (
-		uploadBucket   *ratelimit.Bucket
-		downloadBucket *ratelimit.Bucket
-	)
-
-	if uploadLimit > 0 {
-		uploadBucket = ratelimit.NewBucketWithRate(float64(uploadLimit), uploadLimit)
-	}
+	return NewWithLimits(NewLimit(Schedule{Default: uploadLimit}), NewLimit(Schedule{Default: downloadLimit}), transport)
+}
 
-	if downloadLimit > 0 {
-		downloadBucket = ratelimit.NewBucketWithRate(float64(downloadLimit), downloadLimit)
+// NewWithLimits return a transport ratelimited by limits which
+// may be shared with other transports, nil limits are unlimited.
+func NewWithLimits(upload, download *Limit, transport http.RoundTripper) http.RoundTripper {
+	if upload == nil && download == nil {
+		return transport
 	}
 
 	return &limiter{
-		upload:    uploadBucket,
-		download:  downloadBucket,
+		upload:    upload,
+		download:  download,
 		transport: transport,
 	}
 }
@@ -0,0 +1,215 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limiter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/ratelimit"
+)
+
+// Class is the class of API of a request.
+type Class int
+
+// Classes of API, their requests are limited separately.
+const (
+	ClassList Class = iota
+	ClassRead
+	ClassWrite
+	ClassDelete
+	numClasses
+)
+
+var classNames = [numClasses]string{"list", "read", "write", "delete"}
+
+func (c Class) String() string {
+	return classNames[c]
+}
+
+// Query parameters of the listing APIs.
+var listQueries = []string{"list-type", "versions", "uploads", "uploadId", "prefix", "delimiter", "marker"}
+
+// ClassOf returns the class of API of an S3 request.
+func ClassOf(req *http.Request) Class {
+	query := req.URL.Query()
+	switch req.Method {
+	case http.MethodDelete:
+		return ClassDelete
+	case http.MethodGet:
+		for _, q := range listQueries {
+			if query.Has(q) {
+				return ClassList
+			}
+		}
+		return ClassRead
+	case http.MethodHead:
+		return ClassRead
+	case http.MethodPost:
+		if query.Has("delete") {
+			return ClassDelete
+		}
+	}
+	return ClassWrite
+}
+
+// RequestRates are rates in requests per second, zero is unlimited.
+type RequestRates struct {
+	// Total of all requests.
+	All float64
+	// Requests of each class.
+	Class [numClasses]float64
+}
+
+// IsUnlimited returns true when no request is limited.
+func (r RequestRates) IsUnlimited() bool {
+	if r.All > 0 {
+		return false
+	}
+	for _, rate := range r.Class {
+		if rate > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the rates in the format of ParseRequestRates.
+func (r RequestRates) String() string {
+	var s []string
+	if r.All > 0 {
+		s = append(s, strconv.FormatFloat(r.All, 'f', -1, 64))
+	}
+	for c, rate := range r.Class {
+		if rate > 0 {
+			s = append(s, Class(c).String()+"="+strconv.FormatFloat(rate, 'f', -1, 64))
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+// ParseRequestRates parses a comma separated list of rates in requests
+// per second, "CLASS=RATE" limits a class of API among list, read, write
+// and delete, a single "RATE" limits all requests, e.g. "100,delete=20".
+func ParseRequestRates(s string) (RequestRates, error) {
+	var rates RequestRates
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		name, value, hasClass := strings.Cut(entry, "=")
+		if !hasClass {
+			value = name
+		}
+		rate, e := strconv.ParseFloat(value, 64)
+		if e != nil || rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return RequestRates{}, fmt.Errorf("invalid request rate '%s'", entry)
+		}
+		if !hasClass {
+			rates.All = rate
+			continue
+		}
+		found := false
+		for c, className := range classNames {
+			if strings.EqualFold(name, className) {
+				rates.Class[c] = rate
+				found = true
+			}
+		}
+		if !found {
+			return RequestRates{}, fmt.Errorf("invalid API class '%s', valid classes are '%s'", name, strings.Join(classNames[:], ", "))
+		}
+	}
+	return rates, nil
+}
+
+// Requests limits the rate of requests, shared by several transports
+// it limits their combined requests.
+type Requests struct {
+	all   *ratelimit.Bucket
+	class [numClasses]*ratelimit.Bucket
+}
+
+func newRequestBucket(rate float64) *ratelimit.Bucket {
+	if rate <= 0 {
+		return nil
+	}
+	return ratelimit.NewBucketWithRate(rate, int64(math.Max(1, rate)))
+}
+
+// NewRequests returns a limit of the rates of requests, nil when unlimited.
+func NewRequests(rates RequestRates) *Requests {
+	if rates.IsUnlimited() {
+		return nil
+	}
+	r := &Requests{all: newRequestBucket(rates.All)}
+	for c, rate := range rates.Class {
+		r.class[c] = newRequestBucket(rate)
+	}
+	return r
+}
+
+// wait takes a token of each bucket of the class of req, returns
+// early when the request is canceled.
+func (r *Requests) wait(req *http.Request) error {
+	for _, bucket := range []*ratelimit.Bucket{r.class[ClassOf(req)], r.all} {
+		if bucket == nil {
+			continue
+		}
+		d := bucket.Take(1)
+		if d <= 0 {
+			continue
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return req.Context().Err()
+		}
+	}
+	return nil
+}
+
+type requestLimiter struct {
+	requests  *Requests
+	transport http.RoundTripper
+}
+
+// RoundTrip waits for the rate of requests to allow req before sending it.
+func (l requestLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if l.transport == nil {
+		return nil, errors.New("Invalid Argument")
+	}
+	if err := l.requests.wait(req); err != nil {
+		return nil, err
+	}
+	return l.transport.RoundTrip(req)
+}
+
+// NewRequestLimiter return a transport limited to the rates of
+// requests, which may be shared with other transports.
+func NewRequestLimiter(requests *Requests, transport http.RoundTripper) http.RoundTripper {
+	if requests == nil {
+		return transport
+	}
+	return requestLimiter{requests: requests, transport: transport}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limiter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRequestRates(t *testing.T) {
+	testCases := []struct {
+		rates    string
+		expected string
+		err      bool
+	}{
+		{rates: "100", expected: "100"},
+		{rates: "0.5", expected: "0.5"},
+		{rates: "100, delete=20", expected: "100,delete=20"},
+		{rates: "LIST=10,read=100,write=50,delete=20", expected: "list=10,read=100,write=50,delete=20"},
+		{rates: "0", expected: ""},
+		{rates: "", err: true},
+		{rates: "-1", err: true},
+		{rates: "tag=10", err: true},
+		{rates: "read=fast", err: true},
+	}
+
+	for i, testCase := range testCases {
+		rates, e := ParseRequestRates(testCase.rates)
+		if testCase.err {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error for '%s'", i+1, testCase.rates)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if rates.String() != testCase.expected {
+			t.Fatalf("Test %d: expected '%s', got '%s'", i+1, testCase.expected, rates.String())
+		}
+	}
+}
+
+func TestClassOf(t *testing.T) {
+	testCases := []struct {
+		method string
+		url    string
+		class  Class
+	}{
+		{http.MethodGet, "http://localhost/bucket?list-type=2&prefix=a", ClassList},
+		{http.MethodGet, "http://localhost/bucket?versions", ClassList},
+		{http.MethodGet, "http://localhost/bucket/object?uploadId=1", ClassList},
+		{http.MethodGet, "http://localhost/bucket/object", ClassRead},
+		{http.MethodGet, "http://localhost/bucket/object?tagging", ClassRead},
+		{http.MethodHead, "http://localhost/bucket/object", ClassRead},
+		{http.MethodPut, "http://localhost/bucket/object?tagging", ClassWrite},
+		{http.MethodPut, "http://localhost/bucket/object?partNumber=1&uploadId=1", ClassWrite},
+		{http.MethodPost, "http://localhost/bucket/object?uploads", ClassWrite},
+		{http.MethodPost, "http://localhost/bucket?delete", ClassDelete},
+		{http.MethodDelete, "http://localhost/bucket/object", ClassDelete},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.url, nil)
+		if class := ClassOf(req); class != testCase.class {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.class, class)
+		}
+	}
+}
+
+type countTransport struct {
+	requests int
+}
+
+func (c *countTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	c.requests++
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestRequestLimiter(t *testing.T) {
+	if NewRequests(RequestRates{}) != nil {
+		t.Fatal("expected no limit")
+	}
+
+	rates, e := ParseRequestRates("delete=10")
+	if e != nil {
+		t.Fatal(e)
+	}
+	count := &countTransport{}
+	transport := NewRequestLimiter(NewRequests(rates), count)
+
+	// Other classes are not limited.
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if _, e := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://localhost/bucket/object", nil)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("unexpected limit of reads")
+	}
+
+	// A burst of 10 deletes, then 10 per second.
+	start = time.Now()
+	for i := 0; i < 15; i++ {
+		if _, e := transport.RoundTrip(httptest.NewRequest(http.MethodDelete, "http://localhost/bucket/object", nil)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected deletes to be limited, took %s", elapsed)
+	}
+
+	// A canceled request is not sent.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodDelete, "http://localhost/bucket/object", nil).WithContext(ctx)
+	if _, e := transport.RoundTrip(req); !errors.Is(e, context.Canceled) {
+		t.Fatalf("expected the request to be canceled, got %v", e)
+	}
+	if count.requests != 115 {
+		t.Fatalf("expected 115 requests, got %d", count.requests)
+	}
+}
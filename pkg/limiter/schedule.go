@@ -0,0 +1,150 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limiter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Window is a time of the day with its own rate, from Start
+// included to End excluded. A window ending before its start
+// spans midnight.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+	// Rate in bytes per second, zero is unlimited.
+	Rate int64
+}
+
+func (w Window) contains(t time.Duration) bool {
+	if w.Start <= w.End {
+		return t >= w.Start && t < w.End
+	}
+	return t >= w.Start || t < w.End
+}
+
+// Schedule is a rate changing with the time of the day, the
+// first window containing the time gives the rate, Default
+// applies outside all windows.
+type Schedule struct {
+	Windows []Window
+	// Rate in bytes per second, zero is unlimited.
+	Default int64
+}
+
+// RateAt returns the rate in bytes per second at t, zero is unlimited.
+func (s Schedule) RateAt(t time.Time) int64 {
+	hour, minute, sec := t.Clock()
+	clock := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(sec)*time.Second
+	for _, w := range s.Windows {
+		if w.contains(clock) {
+			return w.Rate
+		}
+	}
+	return s.Default
+}
+
+// IsUnlimited returns true when the schedule never limits the rate.
+func (s Schedule) IsUnlimited() bool {
+	if s.Default > 0 {
+		return false
+	}
+	for _, w := range s.Windows {
+		if w.Rate > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSchedule parses a comma separated list of windows "HH:MM-HH:MM RATE"
+// and of at most one default "RATE", e.g. "08:00-18:00 20MiB/s, otherwise
+// unlimited". A rate is a size per second such as 20MiB or 20MiB/s, or
+// "unlimited". A single rate is a constant limit.
+func ParseSchedule(s string) (Schedule, error) {
+	var schedule Schedule
+	var hasDefault bool
+	for _, entry := range strings.Split(s, ",") {
+		fields := strings.Fields(entry)
+		switch {
+		case len(fields) == 0:
+			return Schedule{}, fmt.Errorf("empty entry in bandwidth schedule '%s'", s)
+		case len(fields) == 2 && fields[0] == "otherwise":
+			fields = fields[1:]
+		}
+
+		switch len(fields) {
+		case 1:
+			if hasDefault {
+				return Schedule{}, fmt.Errorf("more than one default rate in bandwidth schedule '%s'", s)
+			}
+			rate, e := parseRate(fields[0])
+			if e != nil {
+				return Schedule{}, e
+			}
+			schedule.Default = rate
+			hasDefault = true
+		case 2:
+			start, end, ok := strings.Cut(fields[0], "-")
+			if !ok {
+				return Schedule{}, fmt.Errorf("invalid window '%s', expected HH:MM-HH:MM", fields[0])
+			}
+			var w Window
+			var e error
+			if w.Start, e = parseClock(start); e != nil {
+				return Schedule{}, e
+			}
+			if w.End, e = parseClock(end); e != nil {
+				return Schedule{}, e
+			}
+			if w.Rate, e = parseRate(fields[1]); e != nil {
+				return Schedule{}, e
+			}
+			schedule.Windows = append(schedule.Windows, w)
+		default:
+			return Schedule{}, fmt.Errorf("invalid entry '%s' in bandwidth schedule", strings.TrimSpace(entry))
+		}
+	}
+	return schedule, nil
+}
+
+// parseClock parses a time of the day HH:MM, 24:00 is the end of the day.
+func parseClock(s string) (time.Duration, error) {
+	var hour, minute int
+	if n, e := fmt.Sscanf(s, "%d:%d", &hour, &minute); e != nil || n != 2 || len(s) != 5 ||
+		hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time of the day '%s', expected HH:MM", s)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+// parseRate parses a rate in bytes per second, zero is unlimited.
+func parseRate(s string) (int64, error) {
+	if strings.EqualFold(s, "unlimited") {
+		return 0, nil
+	}
+	rate, e := humanize.ParseBytes(strings.TrimSuffix(s, "/s"))
+	if e != nil {
+		return 0, fmt.Errorf("invalid rate '%s': %w", s, e)
+	}
+	return int64(rate), nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2025 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	at := func(clock string) time.Time {
+		t, e := time.Parse("15:04", clock)
+		if e != nil {
+			panic(e)
+		}
+		return t
+	}
+
+	testCases := []struct {
+		schedule string
+		rates    map[string]int64
+		err      bool
+	}{
+		{schedule: "10MiB", rates: map[string]int64{"00:00": 10 << 20, "12:00": 10 << 20}},
+		{schedule: "08:00-18:00 20MiB/s, otherwise unlimited", rates: map[string]int64{
+			"07:59": 0, "08:00": 20 << 20, "17:59": 20 << 20, "18:00": 0,
+		}},
+		{schedule: "22:00-06:00 100MiB, 08:00-18:00 1MiB, 10MiB", rates: map[string]int64{
+			"23:00": 100 << 20, "05:59": 100 << 20, "06:00": 10 << 20, "09:00": 1 << 20,
+		}},
+		// The first window containing the time wins.
+		{schedule: "00:00-24:00 1KiB, 12:00-13:00 2KiB", rates: map[string]int64{"12:30": 1 << 10}},
+		{schedule: "", err: true},
+		{schedule: "10MiB, 20MiB", err: true},
+		{schedule: "8:00-18:00 1MiB", err: true},
+		{schedule: "08:00-25:00 1MiB", err: true},
+		{schedule: "08:00 1MiB", err: true},
+		{schedule: "08:00-18:00 fast", err: true},
+		{schedule: "08:00-18:00 1MiB extra", err: true},
+	}
+
+	for i, testCase := range testCases {
+		schedule, e := ParseSchedule(testCase.schedule)
+		if testCase.err {
+			if e == nil {
+				t.Fatalf("Test %d: expected an error for '%s'", i+1, testCase.schedule)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		for clock, rate := range testCase.rates {
+			if got := schedule.RateAt(at(clock)); got != rate {
+				t.Fatalf("Test %d: expected %d at %s, got %d", i+1, rate, clock, got)
+			}
+		}
+	}
+}
+
+func TestNewLimit(t *testing.T) {
+	if NewLimit(Schedule{}) != nil {
+		t.Fatal("expected no limit for an unlimited schedule")
+	}
+	if NewLimit(Schedule{Windows: []Window{{Start: 0, End: time.Hour}}}) != nil {
+		t.Fatal("expected no limit for unlimited windows")
+	}
+
+	limit := NewLimit(Schedule{Default: 1 << 20})
+	bucket := limit.current()
+	if bucket == nil || bucket.Capacity() != 1<<20 {
+		t.Fatal("expected a bucket of 1MiB/s")
+	}
+	// The bucket is kept while the rate does not change.
+	limit.retune(time.Now().Add(time.Hour))
+	if limit.current() != bucket {
+		t.Fatal("expected the same bucket")
+	}
+}